@@ -5,11 +5,273 @@
 
 import * as ir from '../ir/nodes';
 import { CompilerOptions } from '../config/options';
+import { UtilityTypeInfo, DiscriminatedUnionInfo, UnionVariantInfo, UnionFieldInfo, VariantLiteralInfo, OptionsObjectInfo, ErrorCallInfo } from '../optimizer/optimizer';
+import { getBrandBaseType, UTILITY_TYPE_KINDS } from './type-mapper';
+import { goStringLiteral, escapeFormatString } from './go-string';
 import { SourceMap } from './sourcemap';
 
 export interface GeneratedCode {
   code: string;
   sourceMap?: SourceMap;
+  /** 產生的程式碼匯入的套件，供 Compiler 決定 go.mod 的 require */
+  imports: string[];
+}
+
+/**
+ * async 函式本體的降階狀態
+ * - sync: 回傳 (T, error) 或 error（sync 與 errgroup 策略）
+ * - future: 位於 runtime.NewFuture 的閉包內，回傳 (T, error)
+ * - task: errgroup 的 g.Go 閉包內，回傳 error，結果寫入 target
+ */
+interface AsyncFrame {
+  kind: 'sync' | 'future' | 'task' | GeneratorKind;
+  /** Go 結果型別，void 為空字串 */
+  resultType: string;
+  target?: string;
+}
+
+/**
+ * 呼叫可選 / 預設值參數的函式時，由 IRTransformer 標記的被呼叫端資訊
+ */
+interface OptionalArgsInfo {
+  name: string;
+  owner?: string;
+  parameters: ir.Parameter[];
+  passThrough: boolean[];
+  /** 函式運算式或建構函式：參數逐一傳入，不併入 options struct */
+  inline?: boolean;
+}
+
+/**
+ * 呼叫提升為函式的泛型方法時，由 IRTransformer 標記的宣告類別資訊
+ */
+interface GenericMethodCallInfo {
+  className: string;
+  methodName: string;
+  exported: boolean;
+  /** 接收者為子類別，需傳入內嵌的父類別 */
+  embedded: boolean;
+  classTypeArguments?: ir.IRType[];
+}
+
+/**
+ * 接收者為陣列的 Array.prototype 方法，由 IRTransformer 依 TypeChecker 標記
+ */
+interface ArrayMethodInfo {
+  method: string;
+  elementType?: ir.IRType;
+  /** flat 展開的層數（依型別決定，Infinity 為最內層） */
+  flatDepth?: number;
+  /** 回呼宣告的參數個數，接收索引時改用 Indexed helper */
+  callbackArity: number;
+  /** 各引數是否為陣列（concat 展開用） */
+  arrayArgs: boolean[];
+}
+
+/** includes/startsWith/endsWith 對應的 strings 函式 */
+const STRING_PREDICATES: Record<string, string> = {
+  includes: 'Contains',
+  startsWith: 'HasPrefix',
+  endsWith: 'HasSuffix'
+};
+
+/** Date 的 getX / getUTCX 對應的 time.Time 方法 */
+const DATE_GETTERS: Record<string, string> = {
+  FullYear: 'Year()',
+  Month: 'Month() - 1',
+  Date: 'Day()',
+  Day: 'Weekday()',
+  Hours: 'Hour()',
+  Minutes: 'Minute()',
+  Seconds: 'Second()',
+  Milliseconds: 'Nanosecond() / 1e6'
+};
+
+/** 與 math 套件語義相同的 Math 函式，Go 名稱為首字大寫（Math.log1p → math.Log1p） */
+const MATH_FUNCTIONS = new Set([
+  'abs', 'floor', 'ceil', 'trunc', 'sqrt', 'cbrt', 'exp', 'expm1', 'log', 'log2', 'log10', 'log1p',
+  'sin', 'cos', 'tan', 'asin', 'acos', 'atan', 'atan2', 'sinh', 'cosh', 'tanh', 'asinh', 'acosh', 'atanh', 'pow'
+]);
+
+/** Math 與 Number 的常數 */
+const NUMERIC_CONSTANTS: Record<string, string> = {
+  'Math.PI': 'math.Pi',
+  'Math.E': 'math.E',
+  'Math.LN2': 'math.Ln2',
+  'Math.LN10': 'math.Ln10',
+  'Math.LOG2E': 'math.Log2E',
+  'Math.LOG10E': 'math.Log10E',
+  'Math.SQRT2': 'math.Sqrt2',
+  'Math.SQRT1_2': '(math.Sqrt2 / 2)',
+  'Number.MAX_SAFE_INTEGER': '9007199254740991',
+  'Number.MIN_SAFE_INTEGER': '(-9007199254740991)',
+  'Number.EPSILON': '0x1p-52',
+  'Number.MAX_VALUE': 'math.MaxFloat64',
+  'Number.MIN_VALUE': 'math.SmallestNonzeroFloat64'
+};
+
+/** Number.isX 與全域函式對應的 runtime helper */
+const NUMBER_PREDICATES: Record<string, string> = {
+  isInteger: 'IsInteger',
+  isSafeInteger: 'IsSafeInteger',
+  isFinite: 'IsFinite'
+};
+
+/** 全域與 Number 上以 runtime / math 降階的函式 */
+const NUMBER_FUNCTIONS = new Set(['parseInt', 'parseFloat', 'isNaN', ...Object.keys(NUMBER_PREDICATES)]);
+
+/** Map / Set 型別名稱對應的集合種類 */
+const COLLECTION_TYPES = new Map<string, 'map' | 'set'>([
+  ['Map', 'map'], ['ReadonlyMap', 'map'], ['WeakMap', 'map'],
+  ['Set', 'set'], ['ReadonlySet', 'set'], ['WeakSet', 'set']
+]);
+
+/** JS 的月份自 0 起算 */
+const GO_MONTHS = [
+  'January', 'February', 'March', 'April', 'May', 'June',
+  'July', 'August', 'September', 'October', 'November', 'December'
+];
+
+/**
+ * 接收者為字串的 String.prototype 方法，由 IRTransformer 依 TypeChecker 標記
+ */
+interface StringMethodInfo {
+  method: string;
+  /** 各引數是否為 RegExp */
+  regexArgs: boolean[];
+  /** 第一個引數為帶 g 旗標的正規表達式 */
+  globalRegex: boolean;
+  /** replace 的取代函式宣告的參數個數（非函式為 0） */
+  callbackArity: number;
+}
+
+/**
+ * Map / Set（含 Readonly、Weak 版本），由 IRTransformer 依 TypeChecker 標記
+ */
+interface CollectionInfo {
+  kind: 'map' | 'set';
+  /** WeakMap / WeakSet 不可走訪，不論 mapStrategy 皆對應 Go map */
+  weak: boolean;
+}
+
+interface CollectionMethodInfo extends CollectionInfo {
+  method: string;
+  /** forEach 回呼宣告的參數個數 */
+  callbackArity: number;
+}
+
+interface CollectionConstructorInfo extends CollectionInfo {
+  /** Map 為 [K, V]，Set 為 [T] */
+  typeArguments: ir.IRType[];
+  source: 'empty' | 'literal' | 'copy' | 'iteration' | 'array';
+  /** 以陣列建立 Map 時的 [key, value] 元素型別 */
+  entryType?: ir.IRType;
+}
+
+/**
+ * 走訪（for...of、展開）Map / Set 本身或其 keys() / values() / entries()
+ */
+interface CollectionIterationInfo extends CollectionInfo {
+  iterate: 'entries' | 'keys' | 'values';
+  /** for (const [key, value] of map) 的變數名稱，省略的位置為 undefined */
+  bindings?: (string | undefined)[];
+}
+
+/**
+ * Object 靜態方法引數的形狀，由 IRTransformer 經 TypeChecker 判斷：
+ * map 為 Record / index signature（Go map），struct 為 interface 或物件型別別名，literal 為物件字面量
+ */
+type ObjectShape =
+  | { kind: 'map'; type: ir.IRType; keyType: ir.IRType; valueType: ir.IRType }
+  | { kind: 'struct'; type: ir.TypeReference; fields: ObjectField[] }
+  | { kind: 'literal' };
+
+interface ObjectField {
+  name: string;
+  /** 可選欄位於 Go 為指標 */
+  optional: boolean;
+  type: ir.IRType;
+}
+
+interface ObjectMethodInfo {
+  method: 'keys' | 'values' | 'entries' | 'assign' | 'freeze' | 'fromEntries';
+  /** keys / values / entries 的引數；assign 的目標（目標為 {} 時為第一個來源） */
+  shape?: ObjectShape;
+  /** assign 的目標為 {}，結果為新的值 */
+  fresh?: boolean;
+  /** assign 各來源的形狀 */
+  sources?: ObjectShape[];
+  entriesSource?: 'literal' | 'entries' | 'map' | 'array';
+  /** fromEntries 的引數為 tuple 陣列時的元素型別 */
+  entryType?: ir.TupleType;
+  keyType?: ir.IRType;
+  valueType?: ir.IRType;
+}
+
+/**
+ * 正規表達式字面量提升的套件層級變數，由 IRTransformer 記錄於 module metadata
+ */
+interface HoistedRegExp {
+  name: string;
+  /** Go regexp 語法的 pattern */
+  source: string;
+}
+
+/**
+ * 函式參數列，以及本體開頭取出可選 / 預設值參數的陳述式
+ */
+interface GeneratedParameters {
+  params: string[];
+  prelude: string[];
+  types: string;
+}
+
+/**
+ * 迭代器型別名稱 → 是否為非同步
+ */
+const GENERATOR_TYPES = new Map<string, boolean>([
+  ['Generator', false],
+  ['IterableIterator', false],
+  ['AsyncGenerator', true],
+  ['AsyncIterable', true],
+  ['AsyncIterableIterator', true]
+]);
+
+/**
+ * generator 函式的降階方式：iter.Seq、iter.Seq2 或 runtime.Stream 的 producer goroutine
+ */
+type GeneratorKind = 'seq' | 'seq2' | 'stream';
+
+/**
+ * function* / async function* 上由 IRTransformer 標記的資訊
+ */
+interface GeneratorInfo {
+  async: boolean;
+  yieldType?: ir.IRType;
+}
+
+/**
+ * Promise.all 等呼叫上由 IRTransformer 標記的資訊
+ */
+interface PromiseCombinatorInfo {
+  method: string;
+  valueType?: ir.IRType;
+}
+
+/**
+ * Promise 組合子對應的 runtime 結構化組合子
+ */
+const TASK_COMBINATORS: Record<string, string> = {
+  all: 'AllTasks',
+  allSettled: 'AllSettledTasks',
+  race: 'RaceTasks',
+  any: 'AnyTasks'
+};
+
+interface PromiseMethodInfo {
+  method: 'then' | 'catch' | 'finally';
+  valueType: ir.IRType;
+  resultType: ir.IRType;
 }
 
 export class GoCodeGenerator implements ir.IRVisitor<string> {
@@ -31,6 +293,16 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   private fieldTypeMap = new Map<string, string>(); // Track field types (e.g., 'count' -> 'int')
   private exportedNames = new Set<string>(); // Track names that are exported via export statements
   private currentClassTypeParams: ir.TypeParameter[] = []; // Track current class type parameters for method receivers
+  private asyncFrames: (AsyncFrame | null)[] = []; // 目前函式的 async 降階狀態（非 async 函式為 null）
+  private contextName = ''; // 目前可用的 context.Context 變數名稱
+  private usesEventLoop = false; // 模組使用計時器，main 結束後需執行事件迴圈
+  private pendingStatements: string[] = []; // 巢狀 await 提升至目前陳述式之前的程式碼
+  private awaitCounter = 0;
+  private openStreams: string[] = []; // 目前所在 for...of 走訪的 runtime.Stream，離開函式前須 Close
+  private openCancels: string[] = []; // 迴圈中尚未釋放的 AbortController 的 cancel，錯誤返回前須呼叫
+  private taggedUnions = new Set<string>(); // 模組內產生 tagged union struct（含 JSON 編解碼）的型別別名
+  private objectHelpers = new Map<string, string>(); // Object.keys / values / entries 為 struct 產生的函式、faithful 的 localeCompare
+  private declaredNames = new Set<string>(); // 模組頂層宣告的名稱，產生的套件層級函式需避開
 
   constructor(options: CompilerOptions) {
     this.options = options;
@@ -44,11 +316,25 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
    */
   generate(module: ir.Module): GeneratedCode {
     this.reset();
+    for (const stmt of module.statements) {
+      if (stmt instanceof ir.TypeAliasDeclaration && stmt.type instanceof ir.UnionType && this.isTaggedUnion(stmt.type)) {
+        this.taggedUnions.add(stmt.name);
+      }
+      if (stmt instanceof ir.FunctionDeclaration || stmt instanceof ir.VariableDeclaration ||
+          stmt instanceof ir.ClassDeclaration || stmt instanceof ir.InterfaceDeclaration ||
+          stmt instanceof ir.TypeAliasDeclaration || stmt instanceof ir.EnumDeclaration) {
+        this.declaredNames.add(stmt.name);
+      }
+    }
+    for (const re of (module.metadata.get('regexps') as HoistedRegExp[] | undefined) || []) {
+      this.declaredNames.add(re.name);
+    }
     const code = this.visitModule(module);
 
     return {
       code,
-      sourceMap: this.sourceMap
+      sourceMap: this.sourceMap,
+      imports: Array.from(this.imports).sort()
     };
   }
 
@@ -60,6 +346,16 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     this.tupleTypes.clear();
     this.generatedTupleTypes.clear();
     this.exportedNames.clear();
+    this.asyncFrames = [];
+    this.contextName = '';
+    this.pendingStatements = [];
+    this.awaitCounter = 0;
+    this.usesEventLoop = false;
+    this.objectHelpers.clear();
+    this.declaredNames.clear();
+    this.openStreams = [];
+    this.openCancels = [];
+    this.taggedUnions.clear();
   }
 
   /**
@@ -189,6 +485,7 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
   visitModule(node: ir.Module): string {
     let result = `package ${this.currentPackage}\n\n`;
+    this.usesEventLoop = !!node.metadata.get('eventLoop') && this.options.timerStrategy !== 'goroutine';
 
     // First, collect exported names from export statements
     for (const exportDecl of node.exports) {
@@ -267,9 +564,24 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       });
     }
 
+    // Object.keys / values / entries 為 struct 產生的欄位列舉函式與 localeCompare 的共用 Collator
+    for (const code of this.objectHelpers.values()) {
+      declarations.push({ code, type: 'func', originalIndex: node.statements.length, hadSkippedAfter: false });
+    }
+
+    // 正規表達式字面量提升的套件層級變數
+    const regexps = node.metadata.get('regexps') as HoistedRegExp[] | undefined;
+    if (regexps) {
+      this.addImport('regexp');
+    }
+
     // Generate imports
     result += this.generateImports();
 
+    if (regexps) {
+      result += this.generateRegExpVars(regexps);
+    }
+
     // Third pass: generate code with smart spacing
     for (let i = 0; i < declarations.length; i++) {
       const decl = declarations[i];
@@ -363,6 +675,20 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     return result;
   }
 
+  /**
+   * var regex1 = regexp.MustCompile(`\d+`)；多個時以 var ( ... ) 區塊對齊
+   */
+  private generateRegExpVars(regexps: HoistedRegExp[]): string {
+    const compile = (re: HoistedRegExp) => `regexp.MustCompile(${goStringLiteral(re.source)})`;
+    if (regexps.length === 1) {
+      return `var ${regexps[0].name} = ${compile(regexps[0])}\n\n`;
+    }
+    const width = Math.max(...regexps.map(re => re.name.length));
+    return 'var (\n' +
+      regexps.map(re => `\t${re.name.padEnd(width)} = ${compile(re)}`).join('\n') +
+      '\n)\n\n';
+  }
+
   // @ts-ignore-next-line - node parameter required by interface but not used
   visitImportDeclaration(node: ir.ImportDeclaration): string {
     // Go 的 import 處理在 module 層級
@@ -419,6 +745,11 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   visitObjectType(node: ir.ObjectType): string {
+    // { [key: string]: T } → map[string]T
+    if (node.indexSignature && node.properties.length === 0) {
+      return node.indexSignature.accept(this);
+    }
+
     const fields = node.properties.map(prop => {
       const typeName = prop.type.accept(this);
       const fieldName = this.capitalize(prop.name);
@@ -436,7 +767,8 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     if (node.isAsync) {
       this.needsContext = true;
       this.addImport('context');
-      return `func(context.Context, ${params}) (${returnType}, error)`;
+      const asyncReturnType = this.asyncReturnType(this.asyncResultType(node.returnType));
+      return `func(context.Context${params ? ', ' + params : ''}) ${asyncReturnType}`;
     }
 
     return `func(${params}) ${returnType}`;
@@ -444,6 +776,14 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
   // @ts-ignore-next-line - node parameter required by interface but not used
   visitUnionType(node: ir.UnionType): string {
+    // 同型別的字面量 union 退化為其基本型別
+    if (node.types.length > 0 && node.types.every(t => t instanceof ir.LiteralType)) {
+      const baseTypes = new Set(node.types.map(t => t.accept(this)));
+      if (baseTypes.size === 1) {
+        return [...baseTypes][0];
+      }
+    }
+
     switch (this.options.unionStrategy) {
       case 'interface':
         // Interface-based union
@@ -459,8 +799,13 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     }
   }
 
-  // @ts-ignore-next-line - node parameter required by interface but not used
   visitIntersectionType(node: ir.IntersectionType): string {
+    // 行內 branded type 無具名型別可用，退化為基本型別
+    const brandBase = getBrandBaseType(node);
+    if (brandBase) {
+      return brandBase.accept(this);
+    }
+
     // Intersection 通過 struct embedding 實現
     // 這裡返回 placeholder，實際實現在 TypeAliasDeclaration
     return 'interface{}';
@@ -469,18 +814,76 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   visitTypeReference(node: ir.TypeReference): string {
     let typeName = node.name;
 
+    // setTimeout/setInterval 的回傳值
+    if (typeName === 'Timeout' || typeName === 'NodeJS.Timeout') {
+      return this.runtimeRef(this.options.timerStrategy === 'goroutine' ? 'Timer' : 'TimerID');
+    }
+
     // Special handling for built-in types
     if (typeName === 'Date') {
       this.addImport('time');
       return 'time.Time';
     }
 
+    if (typeName === 'RegExp') {
+      this.addImport('regexp');
+      return '*regexp.Regexp';
+    }
+
+    // exec / match 的結果：符合的字串與各捕獲群組，無符合時為 nil
+    if (typeName === 'RegExpExecArray' || typeName === 'RegExpMatchArray') {
+      return '[]string';
+    }
+
+    // future 策略下 Promise<T> → *runtime.Future[T]
+    if (typeName === 'Promise' && this.options.asyncStrategy === 'future') {
+      return `*${this.runtimeRef('Future')}[${this.asyncResultType(node) || 'struct{}'}]`;
+    }
+
+    // Promise.allSettled 的結果
+    if (typeName === 'PromiseSettledResult' && node.typeArguments && node.typeArguments.length === 1) {
+      return `${this.runtimeRef('SettledResult')}[${node.typeArguments[0].accept(this)}]`;
+    }
+
+    // Generator<T> / AsyncGenerator<T> → iter.Seq / iter.Seq2 / *runtime.Stream
+    if (GENERATOR_TYPES.has(typeName)) {
+      return this.generatorReturnType({
+        async: GENERATOR_TYPES.get(typeName)!,
+        yieldType: node.typeArguments && node.typeArguments[0]
+      });
+    }
+
+    // Map / Set 依 mapStrategy 對應 runtime.OrderedMap / OrderedSet 或 Go map
+    const collectionKind = COLLECTION_TYPES.get(typeName);
+    if (collectionKind) {
+      return this.collectionType(
+        { kind: collectionKind, weak: typeName.startsWith('Weak') },
+        (node.typeArguments || []).map(t => t.accept(this))
+      );
+    }
+
+    // Record<K, V> → map[K]V
+    if (typeName === 'Record' && node.typeArguments && node.typeArguments.length === 2) {
+      return `map[${node.typeArguments[0].accept(this)}]${node.typeArguments[1].accept(this)}`;
+    }
+
     // Special handling for Array<T> → []T
     if (typeName === 'Array' && node.typeArguments && node.typeArguments.length === 1) {
       const elementType = node.typeArguments[0].accept(this);
       return `[]${elementType}`;
     }
 
+    // TypeSimplificationPass 未具體化的 utility type（來源為型別參數或其他檔案的型別）沿用來源型別
+    if (UTILITY_TYPE_KINDS.has(typeName) && node.typeArguments && node.typeArguments.length > 0) {
+      return node.typeArguments[0].accept(this);
+    }
+
+    // AbortSignal 以 ctx 表示取消狀態
+    if (typeName === 'AbortSignal') {
+      this.addImport('context');
+      return 'context.Context';
+    }
+
     // 處理泛型參數
     if (node.typeArguments && node.typeArguments.length > 0) {
       const typeArgs = node.typeArguments.map(t => t.accept(this)).join(', ');
@@ -525,6 +928,26 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
   visitVariableDeclaration(node: ir.VariableDeclaration): string {
     const name = this.exportName(node.name, this.hasModifier(node.modifiers, 'export'));
+
+    // const x = await f() → x, err := f() + error 檢查
+    const frame = this.currentAsyncFrame();
+    if (frame && node.initializer instanceof ir.AwaitExpression) {
+      return this.generateAwaitStatement(node.initializer, frame, name);
+    }
+
+    // const controller = new AbortController() → context.WithCancel / WithTimeout
+    const abortController = node.metadata.get('abortController') as { timeout?: ir.Expression; scoped?: boolean } | undefined;
+    if (abortController) {
+      return this.generateAbortController(node.name, abortController.timeout, abortController.scoped);
+    }
+
+    // const merged = Object.assign({}, a, b)（struct）→ merged := a 與其後的欄位複製
+    const objectMethod = node.initializer?.metadata.get('objectMethod') as ObjectMethodInfo | undefined;
+    if (objectMethod?.method === 'assign' && objectMethod.fresh && objectMethod.shape?.kind === 'struct' &&
+        this.asyncFrames.length > 0) {
+      this.generateStructAssign(node.initializer as ir.CallExpression, objectMethod, name);
+      return '';
+    }
     // @ts-ignore - isConst tracked for future const/var distinction
     const isConst = node.isConst || this.hasModifier(node.modifiers, 'export');
 
@@ -607,15 +1030,13 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     // 型別參數（泛型）
     let typeParams = '';
     if (node.typeParameters && node.typeParameters.length > 0) {
-      typeParams = '[' + node.typeParameters.map(tp => {
-        const constraint = tp.constraint ? ` ${tp.constraint.accept(this)}` : ' any';
-        return tp.name + constraint;
-      }).join(', ') + ']';
+      typeParams = '[' + node.typeParameters.map(tp => tp.accept(this)).join(', ') + ']';
     }
 
     // 參數
-    let params = node.parameters.map(p => this.visitParameter(p)).join(', ');
-    if (isAsync) {
+    const parameters = this.generateParameters(node.parameters, node.name);
+    let params = parameters.params.join(', ');
+    if (isAsync || node.metadata.get('contextParam')) {
       this.needsContext = true;
       this.addImport('context');
       params = `ctx context.Context` + (params ? ', ' + params : '');
@@ -623,13 +1044,18 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     // 返回型別
     let returnType = '';
-    if (node.returnType && node.returnType.accept(this)) {
-      returnType = node.returnType.accept(this);
-      if (isAsync) {
-        returnType = `(${returnType}, error)`;
-      }
+    const resultType = isAsync ? this.asyncResultType(node.returnType) : '';
+    const generator = node.metadata.get('generator') as GeneratorInfo | undefined;
+    if (generator) {
+      returnType = this.generatorReturnType(generator);
     } else if (isAsync) {
-      returnType = 'error';
+      returnType = this.asyncReturnType(resultType);
+    } else if (node.returnType && node.returnType.accept(this)) {
+      returnType = node.returnType.accept(this);
+    }
+    const errorFrame = this.errorReturnFrame(node, returnType);
+    if (errorFrame) {
+      returnType = this.errorReturnType(errorFrame.resultType);
     }
 
     // 函式簽名
@@ -640,50 +1066,53 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     // 函式體
     if (node.body) {
-      // Generate default parameter initialization code
-      const defaultInits: string[] = [];
-      for (const param of node.parameters) {
-        if (param.defaultValue) {
-          const paramType = param.type?.accept(this) || 'interface{}';
-          const defaultValue = param.defaultValue.accept(this);
-
-          // Check if parameter is at zero value and assign default
-          if (paramType === 'string') {
-            defaultInits.push(`if ${param.name} == "" {\n\t\t${param.name} = ${defaultValue}\n\t}`);
-          } else if (paramType.startsWith('*')) {
-            // Pointer type - check for nil
-            defaultInits.push(`if ${param.name} == nil {\n\t\tval := ${defaultValue}\n\t\t${param.name} = &val\n\t}`);
-          } else {
-            // For other types, check against zero value
-            defaultInits.push(`if ${param.name} == 0 {\n\t\t${param.name} = ${defaultValue}\n\t}`);
-          }
-        }
+      const prelude = parameters.prelude;
+
+      if (generator) {
+        const contextName = isAsync ? 'ctx' : this.functionContext(node);
+        return `${parameters.types}${signature} ${this.generateGeneratorBody(node.body, generator, contextName, prelude)}`;
+      }
+      if (isAsync) {
+        return `${parameters.types}${signature} ${this.generateAsyncBody(node.body, resultType, prelude)}`;
       }
 
       // Generate function body with default initializations
       let result = '{\n';
       this.increaseIndent();
 
-      // Add default parameter initializations
-      for (const init of defaultInits) {
-        result += `${this.indent()}${init}\n`;
+      // 進入點建立根 context，供其中呼叫的 async 函式使用
+      if (node.metadata.get('contextEntry')) {
+        this.addImport('context');
+        result += `${this.indent()}ctx := context.Background()\n`;
+      }
+
+      // 計時器與 microtask 於 main 本體之後執行，如同 Node.js 的事件迴圈
+      if (node.name === 'main' && this.usesEventLoop) {
+        result += `${this.indent()}defer ${this.runtimeRef('RunEventLoop')}()\n`;
+      }
+
+      // 取出可選 / 預設值參數的值
+      for (const line of prelude) {
+        result += `${this.indent()}${line}\n`;
       }
 
       // Add original body statements
-      for (const stmt of node.body.statements) {
-        const stmtCode = stmt.accept(this);
-        if (stmtCode) {
-          result += `${this.indent()}${stmtCode}\n`;
+      this.inFunction(errorFrame, this.functionContext(node), () => {
+        for (const stmt of node.body!.statements) {
+          const stmtCode = this.emitStatement(stmt);
+          if (stmtCode) {
+            result += `${this.indent()}${stmtCode}\n`;
+          }
         }
-      }
+      });
 
       this.decreaseIndent();
       result += `${this.indent()}}`;
 
-      return `${signature} ${result}`;
+      return `${parameters.types}${signature} ${this.withErrorReturn(result, node.body, errorFrame)}`;
     }
 
-    return signature;
+    return `${parameters.types}${signature}`;
   }
 
   visitParameter(node: ir.Parameter): string {
@@ -703,13 +1132,83 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   visitTypeParameter(node: ir.TypeParameter): string {
-    let result = node.name;
-    if (node.constraint) {
-      result += ` ${node.constraint.accept(this)}`;
-    } else {
-      result += ' any';
+    return `${node.name} ${this.generateConstraint(node)}`;
+  }
+
+  // ============= Generic Constraints =============
+
+  /**
+   * 型別參數限制：
+   * - 基本型別的 union → type set（~string | ~float64）
+   * - 結構型別 → getter 介面（interface{ GetID() string }）
+   * - 作為 map key 或以 === 比較 → comparable
+   */
+  private generateConstraint(node: ir.TypeParameter): string {
+    const comparable = !!node.metadata.get('comparable');
+    const getters = node.metadata.get('constraintGetters') as ir.PropertySignature[] | undefined;
+
+    if (getters) {
+      const methods = getters.map(m => `${this.getterName(m.name)}() ${this.getterType(m)}`);
+      return `interface{ ${(comparable ? ['comparable', ...methods] : methods).join('; ')} }`;
     }
-    return result;
+
+    // 基本型別本身即為 comparable
+    const typeSet = this.typeSetConstraint(node.constraint);
+    if (typeSet) return typeSet;
+
+    const constraint = node.constraint?.accept(this);
+    if (!constraint || constraint === 'interface{}') {
+      return comparable ? 'comparable' : 'any';
+    }
+    return comparable ? `interface{ comparable; ${constraint} }` : constraint;
+  }
+
+  /**
+   * string | number → ~string | ~float64；字面量取其基本型別，其餘回傳 undefined
+   */
+  private typeSetConstraint(type?: ir.IRType): string | undefined {
+    const members = type instanceof ir.UnionType ? type.types : type ? [type] : [];
+    if (members.length === 0) return undefined;
+
+    const terms: string[] = [];
+    for (const member of members) {
+      const isBasic = member instanceof ir.PrimitiveType ?
+        ['string', 'number', 'boolean'].includes(member.kind) :
+        member instanceof ir.LiteralType && ['string', 'number', 'boolean'].includes(typeof member.value);
+      if (!isBasic) return undefined;
+
+      const term = `~${member.accept(this)}`;
+      if (!terms.includes(term)) terms.push(term);
+    }
+    return terms.join(' | ');
+  }
+
+  private getterName(field: string): string {
+    return `Get${this.capitalize(field)}`;
+  }
+
+  private getterType(member: ir.PropertySignature): string {
+    const type = member.type.accept(this);
+    return member.optional ? `*${type}` : type;
+  }
+
+  /**
+   * 為 struct 產生滿足結構型別限制的 getter，欄位型別不同（如 int 與 float64）時加上轉換
+   */
+  private generateConstraintGetters(
+    receiver: string,
+    getters: ir.PropertySignature[],
+    fields: Map<string, string>
+  ): string {
+    const [receiverName] = receiver.split(' ');
+    return getters.map(getter => {
+      const field = this.capitalize(getter.name);
+      const type = this.getterType(getter);
+      const value = fields.get(getter.name) === type ?
+        `${receiverName}.${field}` :
+        `${type}(${receiverName}.${field})`;
+      return `func (${receiver}) ${this.getterName(getter.name)}() ${type} { return ${value} }`;
+    }).join('\n');
   }
 
   visitClassDeclaration(node: ir.ClassDeclaration): string {
@@ -785,6 +1284,7 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       type: string;
     }
     const fields: FieldInfo[] = [];
+    const fieldTypes = new Map<string, string>();
     for (const member of instanceProperties) {
       const isPrivate = this.hasModifier(member.modifiers, 'private');
       const fieldName = isPrivate ? member.name : this.capitalize(member.name);
@@ -811,6 +1311,7 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       }
 
       fields.push({ name: fieldName, type: typeName });
+      fieldTypes.set(member.name, typeName);
     }
 
     // Calculate padding width
@@ -872,6 +1373,13 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       result += '\n\n' + this.generateGenericMethod(name, genericMethods[i]);
     }
 
+    // 滿足結構型別限制的 getter
+    const getters = node.metadata.get('constraintGetters') as ir.PropertySignature[] | undefined;
+    if (getters) {
+      const receiverType = this.options.usePointerReceivers ? `*${name}` : name;
+      result += '\n\n' + this.generateConstraintGetters(`${name.charAt(0).toLowerCase()} ${receiverType}`, getters, fieldTypes);
+    }
+
     return result;
   }
 
@@ -923,34 +1431,24 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     }
 
     // Build parameter list - if there's a super() call, we need to get params from constructor method
-    const allParams: string[] = [];
+    // 預設值參數與一般函式相同，以 *T / OptionalValue[T] 傳入，於建構函式開頭套用
+    let constructorParameters: ir.Parameter[];
 
     if (superCall && constructorMethod) {
       // Use the constructor method's parameters (which include parent params passed to super())
-      for (const param of constructorMethod.parameters) {
-        let typeName = param.type?.accept(this) || 'interface{}';
-        if (param.optional && this.options.nullabilityStrategy === 'pointer') {
-          typeName = `*${typeName}`;
-        }
-        allParams.push(`${param.name} ${typeName}`);
-      }
+      constructorParameters = constructorMethod.parameters;
     } else {
       // No super() call - just use constructor parameter properties
-      for (const p of constructorParams) {
+      constructorParameters = constructorParams.map(p => {
         const isPrivate = this.hasModifier(p.modifiers, 'private');
         const paramName = isPrivate ? p.name : p.name.toLowerCase();
-        let typeName = p.type?.accept(this) || 'interface{}';
-
-        const isOptional = p.metadata.get('isOptional');
-        if (isOptional && this.options.nullabilityStrategy === 'pointer') {
-          typeName = `*${typeName}`;
-        }
-
-        allParams.push(`${paramName} ${typeName}`);
-      }
+        const source = constructorMethod?.parameters.find(param => param.name === p.name);
+        return new ir.Parameter(paramName, p.type, !!p.metadata.get('isOptional'), source?.defaultValue);
+      });
     }
 
-    const params = allParams.join(', ');
+    const parameters = this.generatePresenceParameters(constructorParameters);
+    const params = parameters.params.join(', ');
 
     // Include type parameters in constructor signature
     let typeParams = '';
@@ -968,6 +1466,10 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     result += ' {\n';
 
+    for (const line of parameters.prelude) {
+      result += `${this.indent()}\t${line}\n`;
+    }
+
     // If there's a super() call with email parameter, create pointer variable
     if (superCall && node.extendsClause) {
       // Check if any super() arg needs to be converted to pointer
@@ -1136,8 +1638,9 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     }
 
     // 參數
-    let params = node.parameters.map(p => this.visitParameter(p)).join(', ');
-    if (isAsync) {
+    const parameters = this.generateParameters(node.parameters, node.name, className);
+    let params = parameters.params.join(', ');
+    if (isAsync || node.metadata.get('contextParam')) {
       this.needsContext = true;
       this.addImport('context');
       params = `ctx context.Context` + (params ? ', ' + params : '');
@@ -1145,7 +1648,13 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     // 返回型別
     let returnType = '';
-    if (node.returnType && node.returnType.accept(this)) {
+    const resultType = isAsync ? this.asyncResultType(node.returnType) : '';
+    const generator = node.metadata.get('generator') as GeneratorInfo | undefined;
+    if (generator) {
+      returnType = this.generatorReturnType(generator);
+    } else if (isAsync) {
+      returnType = this.asyncReturnType(resultType);
+    } else if (node.returnType && node.returnType.accept(this)) {
       let baseReturnType = node.returnType.accept(this);
 
       // Check if the return type is 'number' and if this method returns an int-typed field
@@ -1161,11 +1670,10 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       }
 
       returnType = baseReturnType;
-      if (isAsync) {
-        returnType = `(${returnType}, error)`;
-      }
-    } else if (isAsync) {
-      returnType = 'error';
+    }
+    const errorFrame = this.errorReturnFrame(node, returnType);
+    if (errorFrame) {
+      returnType = this.errorReturnType(errorFrame.resultType);
     }
 
     // 方法簽名
@@ -1176,15 +1684,21 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     // 方法體
     if (node.body) {
-      const body = this.visitBlockStatement(node.body);
+      const body = generator ?
+        this.generateGeneratorBody(node.body, generator, isAsync ? 'ctx' : this.functionContext(node), parameters.prelude) :
+        isAsync ?
+        this.generateAsyncBody(node.body, resultType, parameters.prelude) :
+        this.withPrelude(this.withErrorReturn(
+          this.inFunction(errorFrame, this.functionContext(node), () => this.visitBlockStatement(node.body!)), node.body, errorFrame
+        ), parameters.prelude);
       // Reset receiver name after generating method body
       this.currentReceiverName = '';
-      return `${signature} ${body}`;
+      return `${parameters.types}${signature} ${body}`;
     }
 
     // Reset receiver name
     this.currentReceiverName = '';
-    return signature;
+    return `${parameters.types}${signature}`;
   }
 
   private generateStaticMethod(className: string, node: ir.MethodMember): string {
@@ -1203,8 +1717,9 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     }
 
     // 參數
-    let params = node.parameters.map(p => this.visitParameter(p)).join(', ');
-    if (isAsync) {
+    const parameters = this.generateParameters(node.parameters, node.name, className);
+    let params = parameters.params.join(', ');
+    if (isAsync || node.metadata.get('contextParam')) {
       this.needsContext = true;
       this.addImport('context');
       params = `ctx context.Context` + (params ? ', ' + params : '');
@@ -1212,18 +1727,23 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     // 返回型別
     let returnType = '';
-    if (node.returnType && node.returnType.accept(this)) {
+    const resultType = isAsync ? this.asyncResultType(node.returnType) : '';
+    const generator = node.metadata.get('generator') as GeneratorInfo | undefined;
+    if (generator) {
+      returnType = this.generatorReturnType(generator);
+    } else if (isAsync) {
+      returnType = this.asyncReturnType(resultType);
+    } else if (node.returnType && node.returnType.accept(this)) {
       let baseReturnType = node.returnType.accept(this);
       // If the return type is the same as the class name, make it a pointer (e.g., Counter → *Counter)
       if (baseReturnType === className) {
         baseReturnType = `*${baseReturnType}`;
       }
       returnType = baseReturnType;
-      if (isAsync) {
-        returnType = `(${returnType}, error)`;
-      }
-    } else if (isAsync) {
-      returnType = 'error';
+    }
+    const errorFrame = this.errorReturnFrame(node, returnType);
+    if (errorFrame) {
+      returnType = this.errorReturnType(errorFrame.resultType);
     }
 
     // 函式簽名 (no receiver for static methods)
@@ -1235,18 +1755,24 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     // 方法體 - need to transform static member references
     if (node.body) {
       // Generate the body with static member transformations
-      const body = this.visitBlockStatementStatic(className, node.body);
-      return `${signature} ${body}`;
+      const body = generator ?
+        this.generateGeneratorBody(node.body, generator, isAsync ? 'ctx' : this.functionContext(node), parameters.prelude) :
+        isAsync ?
+        this.generateAsyncBody(node.body, resultType, parameters.prelude) :
+        this.withPrelude(this.withErrorReturn(
+          this.inFunction(errorFrame, this.functionContext(node), () => this.visitBlockStatementStatic(className, node.body!)),
+          node.body, errorFrame
+        ), parameters.prelude);
+      return `${parameters.types}${signature} ${body}`;
     }
 
-    return signature;
+    return `${parameters.types}${signature}`;
   }
 
   private generateGenericMethod(className: string, node: ir.MethodMember): string {
     const isAsync = this.hasModifier(node.modifiers, 'async');
-    const methodName = this.capitalize(node.name);
-    // Generate function name: Map → MapBox
-    const functionName = `${methodName}${className}`;
+    // Generate function name: Map → MapBox（依 genericMethodNaming）
+    const functionName = this.genericMethodName(className, node.name);
 
     // Combine class type parameters with method's own type parameters
     let allTypeParams: string[] = [];
@@ -1276,12 +1802,13 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     const receiverParam = `${receiverName} ${receiverType}`;
 
     // Regular parameters
-    const regularParams = node.parameters.map(p => this.visitParameter(p));
+    const parameters = this.generateParameters(node.parameters, node.name, className);
+    const regularParams = parameters.params;
 
     // Combine receiver with regular parameters
     let allParams = [receiverParam].concat(regularParams).join(', ');
 
-    if (isAsync) {
+    if (isAsync || node.metadata.get('contextParam')) {
       this.needsContext = true;
       this.addImport('context');
       allParams = `ctx context.Context, ${allParams}`;
@@ -1289,7 +1816,13 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     // Return type - need to handle generic return types
     let returnType = '';
-    if (node.returnType && node.returnType.accept(this)) {
+    const resultType = isAsync ? this.asyncResultType(node.returnType) : '';
+    const generator = node.metadata.get('generator') as GeneratorInfo | undefined;
+    if (generator) {
+      returnType = this.generatorReturnType(generator);
+    } else if (isAsync) {
+      returnType = this.asyncReturnType(resultType);
+    } else if (node.returnType && node.returnType.accept(this)) {
       let baseReturnType = node.returnType.accept(this);
 
       // If return type references the class with type parameters, add pointer
@@ -1300,11 +1833,10 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       }
 
       returnType = baseReturnType;
-      if (isAsync) {
-        returnType = `(${returnType}, error)`;
-      }
-    } else if (isAsync) {
-      returnType = 'error';
+    }
+    const errorFrame = this.errorReturnFrame(node, returnType);
+    if (errorFrame) {
+      returnType = this.errorReturnType(errorFrame.resultType);
     }
 
     // Function signature
@@ -1316,13 +1848,19 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     // Function body - set receiver name for 'this' replacement
     if (node.body) {
       this.currentReceiverName = receiverName;
-      const body = this.visitBlockStatement(node.body);
+      const body = generator ?
+        this.generateGeneratorBody(node.body, generator, isAsync ? 'ctx' : this.functionContext(node), parameters.prelude) :
+        isAsync ?
+        this.generateAsyncBody(node.body, resultType, parameters.prelude) :
+        this.withPrelude(this.withErrorReturn(
+          this.inFunction(errorFrame, this.functionContext(node), () => this.visitBlockStatement(node.body!)), node.body, errorFrame
+        ), parameters.prelude);
       this.currentReceiverName = '';
-      return `${signature} ${body}`;
+      return `${parameters.types}${signature} ${body}`;
     }
 
     this.currentReceiverName = '';
-    return signature;
+    return `${parameters.types}${signature}`;
   }
 
   private visitBlockStatementStatic(className: string, node: ir.BlockStatement): string {
@@ -1330,7 +1868,7 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     this.increaseIndent();
     for (const stmt of node.statements) {
-      const stmtCode = this.visitStatementStatic(className, stmt);
+      const stmtCode = this.emitStatement(stmt, () => this.visitStatementStatic(className, stmt));
       if (stmtCode) {
         result += `${this.indent()}${stmtCode}\n`;
       }
@@ -1348,7 +1886,7 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     if (stmt instanceof ir.IfStatement) {
       return this.visitIfStatementStatic(className, stmt);
-    } else if (stmt instanceof ir.ExpressionStatement) {
+    } else if (stmt instanceof ir.ExpressionStatement && !stmt.expression.metadata.get('errorCall')) {
       return this.visitExpressionStatementStatic(className, stmt);
     } else if (stmt instanceof ir.ReturnStatement) {
       return this.visitReturnStatementStatic(className, stmt);
@@ -1379,6 +1917,16 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   private visitReturnStatementStatic(className: string, node: ir.ReturnStatement): string {
+    return this.closeStreams(this.generateReturnStatementStatic(className, node));
+  }
+
+  private generateReturnStatementStatic(className: string, node: ir.ReturnStatement): string {
+    const frame = this.currentAsyncFrame();
+    if (frame) {
+      const errorResult = node.argument && this.errorResultCall(node.argument, frame);
+      if (errorResult) return `return ${errorResult}`;
+      return this.frameReturn(frame, node.argument && this.visitExpressionStatic(className, node.argument));
+    }
     if (node.argument) {
       return `return ${this.visitExpressionStatic(className, node.argument)}`;
     }
@@ -1448,7 +1996,7 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
         return 'nil';
       }
       if (typeof expr.value === 'string') {
-        return `"${expr.value}"`;
+        return goStringLiteral(expr.value);
       }
       return String(expr.value);
     }
@@ -1469,9 +2017,23 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     return '';
   }
 
+  /**
+   * 型別（或其陣列元素）是否為本模組的 tagged union
+   */
+  private hasTaggedUnion(type: ir.IRType): boolean {
+    if (type instanceof ir.ArrayType) return this.hasTaggedUnion(type.elementType);
+    return type instanceof ir.TypeReference && this.taggedUnions.has(type.name);
+  }
+
   visitInterfaceDeclaration(node: ir.InterfaceDeclaration): string {
     const name = this.exportName(node.name, this.hasModifier(node.modifiers, 'export'));
 
+    // TypeSimplificationPass 具體化的 utility type
+    const utility = node.metadata.get('utilityType') as UtilityTypeInfo | undefined;
+    if (utility) {
+      return this.generateUtilityStruct(name, node, utility);
+    }
+
     // 型別參數
     let typeParams = '';
     if (node.typeParameters && node.typeParameters.length > 0) {
@@ -1506,6 +2068,10 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     // Data interfaces (only properties, no methods) become structs
     if (hasOnlyProperties && node.members.length > 0) {
+      const variantInfo = node.metadata.get('unionVariant') as UnionVariantInfo | undefined;
+      const unionFields = node.metadata.get('unionFields') as UnionFieldInfo[] | undefined;
+      // Union variant 與含 union 欄位的 struct 需與 TS 值的 JSON 形式一致
+      const jsonTags = !!variantInfo || !!unionFields || node.members.some(m => this.hasTaggedUnion(m.type));
       let result = `type ${name}${typeParams} struct {\n`;
 
       // Embedding (extends)
@@ -1533,11 +2099,44 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
         // Pad field name for alignment
         const paddedName = fieldName.padEnd(fieldPaddingWidth);
-        result += `${this.indent()}${paddedName}${fieldType}\n`;
+        const jsonTag = jsonTags ?
+          ` \`json:"${member.name}${member.optional ? ',omitempty' : ''}"\`` :
+          '';
+        result += `${this.indent()}${paddedName}${fieldType}${jsonTag}\n`;
       }
       this.decreaseIndent();
 
       result += '}';
+
+      // Sealed interface 的 marker method 與判別欄位 getter
+      if (variantInfo) {
+        const unionName = this.exportName(variantInfo.union);
+        const field = this.capitalize(variantInfo.discriminant);
+        const member = node.members.find(m => m.name === variantInfo.discriminant)!;
+        result += `\n\nfunc (${name}) is${unionName}() {}\n`;
+        result += `func (v ${name}) Get${field}() ${member.type.accept(this)} { return v.${field} }\n\n`;
+
+        // 編碼時一律寫入判別值，零值 struct 亦能正確解碼
+        this.addImport('encoding/json');
+        const value = typeof variantInfo.value === 'string' ? goStringLiteral(variantInfo.value) : String(variantInfo.value);
+        result += `func (v ${name}) MarshalJSON() ([]byte, error) {\n`;
+        result += `\ttype plain ${name}\n`;
+        result += '\tp := plain(v)\n';
+        result += `\tp.${field} = ${value}\n`;
+        result += '\treturn json.Marshal(p)\n';
+        result += '}';
+      }
+
+      const getters = node.metadata.get('constraintGetters') as ir.PropertySignature[] | undefined;
+      if (getters) {
+        const fields = new Map(node.members.map(m => [m.name, this.getterType(m)]));
+        result += '\n\n' + this.generateConstraintGetters(`v ${name}`, getters, fields);
+      }
+
+      if (unionFields) {
+        result += '\n\n' + this.generateUnionFieldsUnmarshal(name, unionFields);
+      }
+
       return result;
     }
 
@@ -1577,6 +2176,78 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     return result;
   }
 
+  /**
+   * 產生 utility type 的 struct 與來源 struct 互轉的 helper
+   * Readonly 使用未匯出欄位搭配 getter，避免外部修改
+   */
+  private generateUtilityStruct(name: string, node: ir.InterfaceDeclaration, utility: UtilityTypeInfo): string {
+    const source = this.exportName(utility.source);
+    const readonly = utility.kind === 'Readonly';
+    const fieldName = (n: string) => readonly ? n.charAt(0).toLowerCase() + n.slice(1) : this.capitalize(n);
+    const sourceField = (n: string) => utility.sourceUnexported ? n.charAt(0).toLowerCase() + n.slice(1) : this.capitalize(n);
+
+    let result = `type ${name} struct {\n`;
+    this.increaseIndent();
+    const maxFieldLen = Math.max(0, ...node.members.map(m => fieldName(m.name).length));
+    const fieldPaddingWidth = Math.max(maxFieldLen + 1, 10);
+    for (const member of node.members) {
+      const typeName = member.type.accept(this);
+      const fieldType = member.optional ? `*${typeName}` : typeName;
+      result += `${this.indent()}${fieldName(member.name).padEnd(fieldPaddingWidth)}${fieldType}\n`;
+    }
+    this.decreaseIndent();
+    result += '}\n\n';
+
+    // Readonly getters
+    if (readonly) {
+      for (const member of node.members) {
+        const typeName = member.type.accept(this);
+        const fieldType = member.optional ? `*${typeName}` : typeName;
+        result += `func (v ${name}) ${this.capitalize(member.name)}() ${fieldType} {\n`;
+        result += `\treturn v.${fieldName(member.name)}\n`;
+        result += '}\n\n';
+      }
+    }
+
+    // 依欄位可選性在 T 與 *T 之間轉換
+    const assign = (dst: string, src: string, dstOptional: boolean, srcOptional: boolean): string => {
+      if (dstOptional === srcOptional) {
+        return `\t${dst} = ${src}\n`;
+      }
+      if (dstOptional) {
+        // src 與 receiver 皆為值複本，取址不會與呼叫端共用
+        return `\t${dst} = &${src}\n`;
+      }
+      return `\tif ${src} != nil {\n\t\t${dst} = *${src}\n\t}\n`;
+    };
+
+    // 來源 → utility
+    result += `// ${name}From${source} 由 ${source} 建立 ${name}\n`;
+    result += `func ${name}From${source}(src ${source}) ${name} {\n`;
+    result += `\tvar dst ${name}\n`;
+    for (const field of utility.fields) {
+      const member = node.members.find(m => m.name === field.name);
+      if (!member) continue;
+      result += assign(`dst.${fieldName(field.name)}`, `src.${sourceField(field.name)}`, member.optional, field.sourceOptional);
+    }
+    result += '\treturn dst\n';
+    result += '}\n\n';
+
+    // utility → 來源（未包含的欄位保留零值）
+    result += `// To${source} 將 ${name} 轉回 ${source}\n`;
+    result += `func (v ${name}) To${source}() ${source} {\n`;
+    result += `\tvar dst ${source}\n`;
+    for (const field of utility.fields) {
+      const member = node.members.find(m => m.name === field.name);
+      if (!member) continue;
+      result += assign(`dst.${sourceField(field.name)}`, `v.${fieldName(field.name)}`, field.sourceOptional, member.optional);
+    }
+    result += '\treturn dst\n';
+    result += '}';
+
+    return result;
+  }
+
   visitTypeAliasDeclaration(node: ir.TypeAliasDeclaration): string {
     const name = this.exportName(node.name, this.hasModifier(node.modifiers, 'export'));
 
@@ -1586,12 +2257,28 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       typeParams = '[' + node.typeParameters.map(tp => tp.accept(this)).join(', ') + ']';
     }
 
+    // Discriminated union → sealed interface，variant struct 另行宣告
+    const discriminated = node.metadata.get('discriminatedUnion') as DiscriminatedUnionInfo | undefined;
+    if (discriminated) {
+      let result = `type ${name} interface {\n`;
+      result += `\tis${name}()\n`;
+      result += `\tGet${this.capitalize(discriminated.discriminant)}() ${discriminated.discriminantType.accept(this)}\n`;
+      result += '}\n\n';
+      result += this.generateDiscriminatedUnmarshal(name, discriminated);
+      return result;
+    }
+
     // 特殊處理 Union 和 Intersection
     if (node.type instanceof ir.UnionType) {
       return this.generateUnionType(name, node.type, typeParams);
     }
 
     if (node.type instanceof ir.IntersectionType) {
+      // Branded type → Go defined type，由編譯器區分 UserId 與 PostId
+      const brandBase = getBrandBaseType(node.type);
+      if (brandBase) {
+        return `type ${name}${typeParams} ${brandBase.accept(this)}`;
+      }
       return this.generateIntersectionType(name, node.type, typeParams);
     }
 
@@ -1599,7 +2286,24 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     return `type ${name}${typeParams} ${typeName}`;
   }
 
+  /**
+   * 產生 tagged union struct 的 union：unionStrategy 為 tagged，且不是字串字面量 union（字串列舉）
+   */
+  private isTaggedUnion(union: ir.UnionType): boolean {
+    return this.options.unionStrategy !== 'interface' && this.options.unionStrategy !== 'any' &&
+      !this.isStringLiteralUnion(union);
+  }
+
+  private isStringLiteralUnion(union: ir.UnionType): boolean {
+    return union.types.every(t => t instanceof ir.LiteralType && typeof t.value === 'string');
+  }
+
   private generateUnionType(name: string, union: ir.UnionType, typeParams: string): string {
+    // 字串字面量 union 產生具型別的字串列舉
+    if (this.isStringLiteralUnion(union)) {
+      return this.generateStringEnum(name, union.types.map(t => (t as ir.LiteralType).value as string));
+    }
+
     switch (this.options.unionStrategy) {
       case 'interface':
         // Interface-based discriminated union
@@ -1644,16 +2348,193 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
           taggedResult += '}\n\n';
         }
 
+        taggedResult += this.generateUnionJSON(name, union, typeParams);
+
         return taggedResult.trim();
     }
   }
 
-  private generateIntersectionType(name: string, intersection: ir.IntersectionType, typeParams: string): string {
-    // Intersection 通過 struct embedding 實現
-    let result = `type ${name}${typeParams} struct {\n`;
+  /**
+   * Tagged union 的 JSON 編解碼，與 TS 值的 JSON 形式一致
+   * 解碼依宣告順序嘗試各 variant（不接受未知欄位），null 對應 null variant
+   */
+  private generateUnionJSON(name: string, union: ir.UnionType, typeParams: string): string {
+    this.addImport('encoding/json');
+    this.addImport('bytes');
+    this.addImport('fmt');
 
-    this.increaseIndent();
-    for (const type of intersection.types) {
+    // 型別參數 [T any] → 接收者使用 [T]
+    const receiverType = typeParams ?
+      `${name}[${typeParams.slice(1, -1).split(',').map(p => p.trim().split(' ')[0]).join(', ')}]` :
+      name;
+    const isNull = (t: ir.IRType) => t instanceof ir.LiteralType && (t.value === null || t.value === undefined);
+
+    let result = `func (u ${receiverType}) MarshalJSON() ([]byte, error) {\n`;
+    result += '\tswitch u.tag {\n';
+    union.types.forEach((t, i) => {
+      if (isNull(t)) return;
+      result += `\tcase ${i}:\n`;
+      result += `\t\treturn json.Marshal(u.value${i})\n`;
+    });
+    result += '\t}\n';
+    result += '\treturn []byte("null"), nil\n';
+    result += '}\n\n';
+
+    result += `func (u *${receiverType}) UnmarshalJSON(data []byte) error {\n`;
+    const nullIndex = union.types.findIndex(isNull);
+    result += '\tif string(bytes.TrimSpace(data)) == "null" {\n';
+    result += nullIndex >= 0 ?
+      `\t\t*u = ${receiverType}{tag: ${nullIndex}}\n` :
+      `\t\t*u = ${receiverType}{}\n`;
+    result += '\t\treturn nil\n';
+    result += '\t}\n';
+
+    union.types.forEach((t, i) => {
+      if (isNull(t)) return;
+      const typeName = t.accept(this);
+      // 字面量 variant 需比對值本身
+      const literalCheck = t instanceof ir.LiteralType ?
+        ` && v == ${typeof t.value === 'string' ? goStringLiteral(t.value) : String(t.value)}` :
+        '';
+      result += '\t{\n';
+      result += `\t\tvar v ${typeName}\n`;
+      result += '\t\tdec := json.NewDecoder(bytes.NewReader(data))\n';
+      result += '\t\tdec.DisallowUnknownFields()\n';
+      result += `\t\tif err := dec.Decode(&v); err == nil${literalCheck} {\n`;
+      result += `\t\t\t*u = ${receiverType}{tag: ${i}, value${i}: &v}\n`;
+      result += '\t\t\treturn nil\n';
+      result += '\t\t}\n';
+      result += '\t}\n';
+    });
+
+    result += `\treturn fmt.Errorf("${name}: no variant matches %s", data)\n`;
+    result += '}\n\n';
+
+    return result;
+  }
+
+  /**
+   * Sealed interface 的解碼函式：先讀判別欄位，再解碼為對應 variant
+   */
+  private generateDiscriminatedUnmarshal(name: string, info: DiscriminatedUnionInfo): string {
+    this.addImport('encoding/json');
+    this.addImport('fmt');
+
+    const field = this.capitalize(info.discriminant);
+    const goValue = (v: string | number | boolean) => typeof v === 'string' ? goStringLiteral(v) : String(v);
+
+    let result = `// Unmarshal${name} 依判別欄位 ${info.discriminant} 解碼 ${name}\n`;
+    result += `func Unmarshal${name}(data []byte) (${name}, error) {\n`;
+    result += '\tvar probe struct {\n';
+    result += `\t\t${field} ${info.discriminantType.accept(this)} \`json:"${info.discriminant}"\`\n`;
+    result += '\t}\n';
+    result += '\tif err := json.Unmarshal(data, &probe); err != nil {\n';
+    result += '\t\treturn nil, err\n';
+    result += '\t}\n';
+    result += `\tswitch probe.${field} {\n`;
+    for (const variant of info.variants) {
+      result += `\tcase ${goValue(variant.value)}:\n`;
+      result += `\t\tvar v ${variant.name}\n`;
+      result += '\t\terr := json.Unmarshal(data, &v)\n';
+      result += '\t\treturn v, err\n';
+    }
+    result += '\t}\n';
+    result += `\treturn nil, fmt.Errorf("${name}: unknown ${info.discriminant} %v", probe.${field})\n`;
+    result += '}';
+    return result;
+  }
+
+  /**
+   * 含 sealed union 欄位的 struct：encoding/json 無法解碼 interface 欄位，
+   * 這些欄位先解碼為 json.RawMessage，再以 UnmarshalXxx 依判別欄位解碼；其餘欄位照常解碼至 plain
+   */
+  private generateUnionFieldsUnmarshal(name: string, fields: UnionFieldInfo[]): string {
+    this.addImport('encoding/json');
+
+    const width = Math.max(...fields.map(f => this.capitalize(f.name).length));
+    const rawWidth = fields.some(f => f.array) ? '[]json.RawMessage'.length : 'json.RawMessage'.length;
+    let result = `// UnmarshalJSON 以 Unmarshal${fields[0].union.accept(this)} 等解碼 sealed union 欄位\n`;
+    result += `func (v *${name}) UnmarshalJSON(data []byte) error {\n`;
+    result += `\ttype plain ${name}\n`;
+    result += '\tvar raw struct {\n';
+    result += '\t\t*plain\n';
+    for (const field of fields) {
+      const rawType = field.array ? '[]json.RawMessage' : 'json.RawMessage';
+      result += `\t\t${this.capitalize(field.name).padEnd(width)} ${rawType.padEnd(rawWidth)} \`json:"${field.name}"\`\n`;
+    }
+    result += '\t}\n';
+    result += '\traw.plain = (*plain)(v)\n';
+    result += '\tif err := json.Unmarshal(data, &raw); err != nil {\n';
+    result += '\t\treturn err\n';
+    result += '\t}\n';
+
+    for (const field of fields) {
+      const union = field.union.accept(this);
+      const goField = this.capitalize(field.name);
+      const decode = (source: string, target: string, indent: string) =>
+        `${indent}value, err := Unmarshal${union}(${source})\n` +
+        `${indent}if err != nil {\n` +
+        `${indent}\treturn err\n` +
+        `${indent}}\n` +
+        `${indent}${target}\n`;
+
+      if (field.array) {
+        // null 或未出現的陣列維持 nil
+        result += `\tif raw.${goField} != nil {\n`;
+        result += `\t\titems := make([]${union}, len(raw.${goField}))\n`;
+        result += `\t\tfor i, item := range raw.${goField} {\n`;
+        result += decode('item', 'items[i] = value', '\t\t\t');
+        result += '\t\t}\n';
+        result += `\t\tv.${goField} = ${field.optional ? '&items' : 'items'}\n`;
+        result += '\t}\n';
+      } else {
+        result += `\tif len(raw.${goField}) > 0 && string(raw.${goField}) != "null" {\n`;
+        result += decode(`raw.${goField}`, `v.${goField} = ${field.optional ? '&value' : 'value'}`, '\t\t');
+        result += '\t}\n';
+      }
+    }
+    result += '\treturn nil\n';
+    result += '}';
+    return result;
+  }
+
+  /**
+   * 字串字面量 union → 具名 string 型別與常數：'in-progress' → StatusInProgress。
+   * 沒有英數字的值（''）以 Empty 為後綴；轉換後同名的值（'a-b' 與 'a_b'）依序加上數字後綴
+   */
+  private generateStringEnum(name: string, values: string[]): string {
+    const used = new Set<string>([name]);
+    const constNames = values.map(value => {
+      const suffix = value
+        .split(/[^A-Za-z0-9]+/)
+        .filter(part => part.length > 0)
+        .map(part => this.capitalize(part))
+        .join('');
+      const base = name + (suffix || 'Empty');
+      let constName = base;
+      for (let i = 2; used.has(constName); i++) {
+        constName = `${base}${i}`;
+      }
+      used.add(constName);
+      return constName;
+    });
+    const width = Math.max(...constNames.map(c => c.length));
+
+    let result = `type ${name} string\n\n`;
+    result += 'const (\n';
+    values.forEach((value, i) => {
+      result += `\t${constNames[i].padEnd(width)} ${name} = ${goStringLiteral(value)}\n`;
+    });
+    result += ')';
+    return result;
+  }
+
+  private generateIntersectionType(name: string, intersection: ir.IntersectionType, typeParams: string): string {
+    // Intersection 通過 struct embedding 實現
+    let result = `type ${name}${typeParams} struct {\n`;
+
+    this.increaseIndent();
+    for (const type of intersection.types) {
       const typeName = type.accept(this);
       result += `${this.indent()}${typeName}\n`;
     }
@@ -1682,7 +2563,7 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       this.increaseIndent();
       for (const member of node.members) {
         const memberName = `${name}${this.capitalize(member.name)}`;
-        const value = member.value ? member.value.accept(this) : `"${member.name}"`;
+        const value = member.value ? member.value.accept(this) : goStringLiteral(member.name);
         result += `${this.indent()}${memberName} ${name} = ${value}\n`;
       }
       this.decreaseIndent();
@@ -1731,7 +2612,7 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     this.increaseIndent();
     for (const stmt of node.statements) {
-      const stmtCode = stmt.accept(this);
+      const stmtCode = this.emitStatement(stmt);
       if (stmtCode) {
         result += `${this.indent()}${stmtCode}\n`;
       }
@@ -1744,20 +2625,53 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   visitExpressionStatement(node: ir.ExpressionStatement): string {
+    const frame = this.currentAsyncFrame();
+    if (frame && node.expression instanceof ir.AwaitExpression) {
+      return this.generateAwaitStatement(node.expression, frame);
+    }
+
+    // 回傳 error 的呼叫：只檢查 error，不需要結果
+    const errorCall = node.expression.metadata.get('errorCall') as ErrorCallInfo | undefined;
+    if (errorCall && node.expression instanceof ir.CallExpression) {
+      const init = `${errorCall.void ? 'err' : '_, err'} := ${this.generateCall(node.expression)}`;
+      return `if ${init}; err != nil ${frame ? this.errorBlock(frame) : this.panicBlock()}`;
+    }
+
     // Skip reassignments to any/unknown typed variables as they don't make sense in Go
-    if (node.expression instanceof ir.AssignmentExpression) {
+    // 複合指定（x **= y 等）不會改變型別，照常輸出
+    if (node.expression instanceof ir.AssignmentExpression && node.expression.operator === '=') {
       return '';
     }
 
     // Handle array.push() calls that need to be converted to assignment statements
     if (node.expression instanceof ir.CallExpression) {
       const callExpr = node.expression as ir.CallExpression;
+
+      // Object.assign 到 struct 的欄位複製已提升為陳述式，Object.freeze 於 Go 沒有作用，結果皆不需使用
+      const objectMethod = callExpr.metadata.get('objectMethod') as ObjectMethodInfo | undefined;
+      if (objectMethod?.method === 'freeze' || (objectMethod?.method === 'assign' && objectMethod.shape?.kind === 'struct')) {
+        callExpr.accept(this);
+        return '';
+      }
+
       if (callExpr.callee instanceof ir.MemberExpression) {
         const memberExpr = callExpr.callee as ir.MemberExpression;
         const methodName = memberExpr.property instanceof ir.Identifier
           ? memberExpr.property.name
           : null;
 
+        // 陳述式中的 arr.reverse() / arr.sort(cmp) 不需要回傳值，直接原地修改
+        const arrayMethod = callExpr.metadata.get('arrayMethod') as ArrayMethodInfo | undefined;
+        if (arrayMethod?.method === 'reverse') {
+          this.addImport('slices');
+          return `slices.Reverse(${memberExpr.object.accept(this)})`;
+        }
+        if (arrayMethod?.method === 'sort' && callExpr.args.length > 0) {
+          this.addImport('slices');
+          const comparator = `${this.runtimeRef('Comparator')}(${callExpr.args[0].accept(this)})`;
+          return `slices.SortStableFunc(${memberExpr.object.accept(this)}, ${comparator})`;
+        }
+
         // Handle array.push() → array = append(array, element)
         if (methodName === 'push') {
           const arrayExpr = memberExpr.object.accept(this);
@@ -1772,36 +2686,16 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   visitReturnStatement(node: ir.ReturnStatement): string {
-    if (node.argument) {
-      // Special handling for array.includes() - expand to for loop statements
-      if (node.argument instanceof ir.CallExpression) {
-        const callExpr = node.argument as ir.CallExpression;
-        if (callExpr.callee instanceof ir.MemberExpression) {
-          const memberExpr = callExpr.callee as ir.MemberExpression;
-          const methodName = memberExpr.property instanceof ir.Identifier
-            ? memberExpr.property.name
-            : null;
-
-          if (methodName === 'includes' && callExpr.args.length === 1) {
-            // Generate multi-line for loop instead of inline IIFE
-            const arrayExpr = memberExpr.object.accept(this);
-            const valueExpr = callExpr.args[0].accept(this);
-            // Return the for loop as a multi-line statement block
-            let result = `for _, p := range ${arrayExpr} {\n`;
-            this.increaseIndent();
-            result += `${this.indent()}if p == ${valueExpr} {\n`;
-            this.increaseIndent();
-            result += `${this.indent()}return true\n`;
-            this.decreaseIndent();
-            result += `${this.indent()}}\n`;
-            this.decreaseIndent();
-            result += `${this.indent()}}\n`;
-            result += `${this.indent()}return false`;
-            return result;
-          }
-        }
-      }
+    return this.closeStreams(this.generateReturnStatement(node));
+  }
+
+  private generateReturnStatement(node: ir.ReturnStatement): string {
+    const frame = this.currentAsyncFrame();
+    if (frame) {
+      return this.generateAsyncReturn(node.argument, frame);
+    }
 
+    if (node.argument) {
       // Special handling for prefix increment/decrement in return statement
       // In Go, ++ and -- are statements, not expressions
       // So: return ++x  →  x++; return x
@@ -1820,6 +2714,19 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   visitIfStatement(node: ir.IfStatement): string {
+    // 判別欄位比較 → type assertion，綁定變數在 else 分支亦可見
+    const guard = node.metadata.get('typeGuard') as
+      { subject: string; variant: string; binding?: string; negated: boolean } | undefined;
+    if (guard) {
+      const subject = new ir.Identifier(guard.subject).accept(this);
+      let result = `if ${guard.binding || '_'}, ok := ${subject}.(${guard.variant}); ${guard.negated ? '!ok' : 'ok'} ` +
+        node.consequent.accept(this);
+      if (node.alternate) {
+        result += ` else ${node.alternate.accept(this)}`;
+      }
+      return result;
+    }
+
     let testExpr = node.test.accept(this);
 
     // If test is just an identifier that might be a pointer, add != nil check
@@ -1857,6 +2764,21 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   visitForOfStatement(node: ir.ForOfStatement): string {
+    const iterator = node.metadata.get('iterator') as 'sync' | 'async' | undefined;
+    if (iterator) {
+      return this.generateIteratorLoop(node, iterator === 'async');
+    }
+
+    const iteration = node.metadata.get('collection') as CollectionIterationInfo | undefined;
+    if (iteration) {
+      return this.generateCollectionLoop(node, iteration);
+    }
+
+    const entryBindings = node.metadata.get('entryBindings') as (string | undefined)[] | undefined;
+    if (entryBindings) {
+      return this.generateEntriesLoop(node, entryBindings);
+    }
+
     const varName = node.left.name;
     const collection = node.right.accept(this);
 
@@ -1912,24 +2834,90 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   visitThrowStatement(node: ir.ThrowStatement): string {
+    const frame = this.currentAsyncFrame();
     if (this.options.errorHandling === 'panic') {
       return `panic(${node.argument.accept(this)})`;
+    } else if (frame) {
+      return this.closeStreams(this.frameError(frame, node.argument.accept(this)));
     } else {
-      return `return ${node.argument.accept(this)}`;
+      return this.closeStreams(`return ${node.argument.accept(this)}`);
     }
   }
 
   visitSwitchStatement(node: ir.SwitchStatement): string {
-    let result = `switch ${node.discriminant.accept(this)} {\n`;
+    const typeSwitch = node.metadata.get('typeSwitch') as { subject: string; binding?: string } | undefined;
+    if (typeSwitch) {
+      return this.generateTypeSwitch(node, typeSwitch.subject, typeSwitch.binding);
+    }
+
+    const discriminant = node.discriminant.accept(this);
+    let result = `switch ${discriminant} {\n`;
 
     this.increaseIndent();
     for (const caseNode of node.cases) {
       result += this.visitSwitchCase(caseNode);
     }
+    if (node.metadata.get('exhaustive')) {
+      result += this.generateUnreachableDefault(discriminant);
+    }
+    this.decreaseIndent();
+
+    result += `${this.indent()}}`;
+
+    return result;
+  }
+
+  /**
+   * Discriminated union 的 switch → type switch
+   * TS 中沒有內容的 case 會 fallthrough，於 Go 中合併為 case A, B:
+   */
+  private generateTypeSwitch(node: ir.SwitchStatement, subjectName: string, binding?: string): string {
+    const subject = new ir.Identifier(subjectName).accept(this);
+    let result = binding ? `switch ${binding} := ${subject}.(type) {\n` : `switch ${subject}.(type) {\n`;
+
+    this.increaseIndent();
+    let pending: string[] = [];
+    for (const caseNode of node.cases) {
+      const variant = caseNode.metadata.get('variant') as string | undefined;
+      if (variant && caseNode.consequent.length === 0) {
+        pending.push(variant);
+        continue;
+      }
+
+      if (variant) {
+        result += `${this.indent()}case ${[...pending, variant].join(', ')}:\n`;
+      } else {
+        result += `${this.indent()}default:\n`;
+      }
+      pending = [];
+
+      this.increaseIndent();
+      for (const stmt of caseNode.consequent) {
+        result += `${this.indent()}${this.emitStatement(stmt)}\n`;
+      }
+      this.decreaseIndent();
+    }
+    if (pending.length > 0) {
+      result += `${this.indent()}case ${pending.join(', ')}:\n`;
+    }
+    if (node.metadata.get('exhaustive')) {
+      result += this.generateUnreachableDefault(subject);
+    }
     this.decreaseIndent();
 
     result += `${this.indent()}}`;
+    return result;
+  }
 
+  /**
+   * 已窮盡的 switch 補上 default，保留 TS never 檢查的語義並滿足 Go 的 missing return 檢查
+   */
+  private generateUnreachableDefault(subject: string): string {
+    this.addImport('fmt');
+    let result = `${this.indent()}default:\n`;
+    this.increaseIndent();
+    result += `${this.indent()}panic(fmt.Sprintf("unreachable: %v", ${subject}))\n`;
+    this.decreaseIndent();
     return result;
   }
 
@@ -1944,7 +2932,7 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
 
     this.increaseIndent();
     for (const stmt of node.consequent) {
-      result += `${this.indent()}${stmt.accept(this)}\n`;
+      result += `${this.indent()}${this.emitStatement(stmt)}\n`;
     }
     this.decreaseIndent();
 
@@ -1958,6 +2946,9 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     if (node.name === 'undefined') {
       return 'nil';
     }
+    if (node.name === 'NaN' || node.name === 'Infinity') {
+      return this.numericConstant(node.name);
+    }
     // Replace 'this' with current receiver name in method context
     if (node.name === 'this' && this.currentReceiverName) {
       return this.currentReceiverName;
@@ -1980,12 +2971,20 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       return 'nil';
     }
     if (typeof node.value === 'string') {
-      return `"${node.value}"`;
+      return goStringLiteral(node.value);
     }
     return String(node.value);
   }
 
   visitArrayExpression(node: ir.ArrayExpression): string {
+    // [...set]、[...map.keys()] 即為集合內容的 slice
+    const only = node.elements.length === 1 ? node.elements[0] : null;
+    const collection = only instanceof ir.SpreadElement ?
+      only.metadata.get('collection') as CollectionIterationInfo | undefined : undefined;
+    if (collection) {
+      return this.collectionSlice((only as ir.SpreadElement).argument.accept(this), collection);
+    }
+
     const elements = node.elements
       .map(e => e ? e.accept(this) : 'nil')
       .join(', ');
@@ -1999,15 +2998,40 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   visitObjectExpression(node: ir.ObjectExpression): string {
+    // 上下文型別為 discriminated union：依判別值建立 variant struct
+    const variant = node.metadata.get('variantLiteral') as VariantLiteralInfo | undefined;
+    if (variant) {
+      return this.generateVariantLiteral(node, variant);
+    }
+
     const props = node.properties.map(p => this.visitProperty(p)).join(', ');
 
     // 物件字面量轉為 map
     return `map[string]interface{}{${props}}`;
   }
 
+  /**
+   * { status: 'success', data } → SuccessResult{Status: "success", Data: data}；可選欄位為指標，以 runtime.Ptr 取址
+   */
+  private generateVariantLiteral(node: ir.ObjectExpression, variant: VariantLiteralInfo): string {
+    const members = new Map(variant.members.map(m => [m.name, m]));
+    const fields = node.properties.map(prop => {
+      const name = prop.key instanceof ir.Identifier ? prop.key.name : String((prop.key as ir.Literal).value);
+      const member = members.get(name);
+      const value = prop.value.accept(this);
+      if (!member || !member.optional || value === 'nil') {
+        return `${this.capitalize(name)}: ${value}`;
+      }
+      // 字面量需明確指定型別參數（16 於 *float64 欄位）
+      const typeArgs = prop.value instanceof ir.Literal ? `[${member.type.accept(this)}]` : '';
+      return `${this.capitalize(name)}: ${this.runtimeRef('Ptr')}${typeArgs}(${value})`;
+    });
+    return `${this.exportName(variant.name)}{${fields.join(', ')}}`;
+  }
+
   visitProperty(node: ir.Property): string {
     const key = node.key instanceof ir.Identifier ?
-      `"${node.key.name}"` :
+      goStringLiteral(node.key.name) :
       node.key.accept(this);
     const value = node.value.accept(this);
 
@@ -2015,9 +3039,30 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   visitFunctionExpression(node: ir.FunctionExpression): string {
-    const params = node.parameters.map(p => this.visitParameter(p)).join(', ');
+    const generator = node.metadata.get('generator') as GeneratorInfo | undefined;
+    if (generator) {
+      const parameters = this.generatePresenceParameters(node.parameters);
+      const params = parameters.params;
+      if (generator.async) {
+        this.addImport('context');
+        params.unshift('ctx context.Context');
+      }
+      const contextName = generator.async ? 'ctx' : this.contextName;
+      return `func(${params.join(', ')}) ${this.generatorReturnType(generator)} ` +
+        this.generateGeneratorBody(node.body, generator, contextName, parameters.prelude);
+    }
+
+    if (node.isAsync) {
+      return this.generateAsyncFunctionExpression(node.parameters, node.returnType, node.body);
+    }
+
+    const parameters = this.generatePresenceParameters(node.parameters);
+    const params = parameters.params.join(', ');
     const returnType = node.returnType ? node.returnType.accept(this) : '';
-    const body = this.visitBlockStatement(node.body);
+    const body = this.withPrelude(
+      this.inFunction(null, this.contextName, () => this.visitBlockStatement(node.body)),
+      parameters.prelude
+    );
 
     let signature = `func(${params})`;
     if (returnType) {
@@ -2028,7 +3073,12 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
   }
 
   visitArrowFunctionExpression(node: ir.ArrowFunctionExpression): string {
-    const params = node.parameters.map(p => this.visitParameter(p)).join(', ');
+    if (node.isAsync) {
+      return this.generateAsyncFunctionExpression(node.parameters, node.returnType, node.body);
+    }
+
+    const parameters = this.generatePresenceParameters(node.parameters);
+    const params = parameters.params.join(', ');
     const returnType = node.returnType ? node.returnType.accept(this) : '';
 
     let signature = `func(${params})`;
@@ -2036,15 +3086,132 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       signature += ` ${returnType}`;
     }
 
-    if (node.body instanceof ir.BlockStatement) {
-      return `${signature} ${this.visitBlockStatement(node.body)}`;
+    const body = node.body;
+    if (body instanceof ir.BlockStatement) {
+      const block = this.inFunction(null, this.contextName, () => this.visitBlockStatement(body));
+      return `${signature} ${this.withPrelude(block, parameters.prelude)}`;
+    } else if (parameters.prelude.length > 0) {
+      // 預設值需先套用，運算式本體改為區塊
+      const block = this.inFunction(null, this.contextName, () =>
+        this.visitBlockStatement(new ir.BlockStatement([new ir.ReturnStatement(body)])));
+      return `${signature} ${this.withPrelude(block, parameters.prelude)}`;
     } else {
       // Expression body
-      return `${signature} { return ${node.body.accept(this)} }`;
+      return `${signature} { return ${this.inFunction(null, this.contextName, () => body.accept(this))} }`;
     }
   }
 
   visitCallExpression(node: ir.CallExpression): string {
+    const errorCall = node.metadata.get('errorCall') as ErrorCallInfo | undefined;
+    return errorCall ? this.generateErrorCall(node, errorCall) : this.generateCall(node);
+  }
+
+  private generateCall(node: ir.CallExpression): string {
+    // 型別轉換 UserId(x)
+    const conversion = node.metadata.get('typeConversion') as ir.IRType | undefined;
+    if (conversion) {
+      return `${conversion.accept(this)}(${node.args[0].accept(this)})`;
+    }
+
+    // AbortController / AbortSignal 的方法轉為 ctx 操作
+    const abortController = node.metadata.get('abortCall') as string | undefined;
+    if (abortController) {
+      // 區塊結尾的釋放：其後的錯誤返回不再呼叫此 cancel
+      if (node.metadata.get('releasesAbortController')) {
+        this.openCancels = this.openCancels.filter(cancel => cancel !== `${abortController}Cancel`);
+      }
+      return `${abortController}Cancel()`;
+    }
+    const signalCall = this.generateAbortSignalCall(node);
+    if (signalCall !== null) {
+      return signalCall;
+    }
+
+    const timerCall = this.generateTimerCall(node);
+    if (timerCall) {
+      return timerCall;
+    }
+
+    // future 策略：p.then/catch/finally 與 Promise.all 等轉為 runtime 組合子
+    if (this.options.asyncStrategy === 'future') {
+      const promiseMethod = node.metadata.get('promiseMethod') as PromiseMethodInfo | undefined;
+      if (promiseMethod && node.callee instanceof ir.MemberExpression) {
+        return this.generatePromiseMethod(node, node.callee, promiseMethod);
+      }
+      const combinator = node.metadata.get('promiseCombinator') as PromiseCombinatorInfo | undefined;
+      const lowered = combinator && this.generateFutureCombinator(node, combinator);
+      if (lowered) {
+        return lowered;
+      }
+    }
+
+    // Promise.all/allSettled/race/any 降階為 runtime 的結構化組合子，回傳 (T, error)
+    const taskCombinator = this.getTaskCombinator(node);
+    if (taskCombinator && this.options.asyncStrategy !== 'future') {
+      const lowered = this.generateTaskCombinator(taskCombinator.call, taskCombinator.info);
+      if (lowered) {
+        return lowered;
+      }
+    }
+
+    // 泛型方法提升為函式：box.map(f) → MapBox(box, f)
+    const genericMethod = node.metadata.get('genericMethodCall') as GenericMethodCallInfo | undefined;
+    if (genericMethod && node.callee instanceof ir.MemberExpression) {
+      return this.generateGenericMethodCall(node, node.callee, genericMethod);
+    }
+
+    // Array.prototype 方法降階為 slices / runtime helper
+    const arrayMethod = node.metadata.get('arrayMethod') as ArrayMethodInfo | undefined;
+    if (arrayMethod && node.callee instanceof ir.MemberExpression) {
+      return this.generateArrayMethod(node, node.callee.object, arrayMethod);
+    }
+
+    // Map / Set 的方法依 mapStrategy 降階
+    const collectionMethod = node.metadata.get('collectionMethod') as CollectionMethodInfo | undefined;
+    if (collectionMethod && node.callee instanceof ir.MemberExpression) {
+      return this.generateCollectionMethod(node, node.callee.object, collectionMethod);
+    }
+
+    // Object.keys / values / entries / assign / fromEntries / freeze 依引數的形狀降階
+    const objectMethod = node.metadata.get('objectMethod') as ObjectMethodInfo | undefined;
+    if (objectMethod) {
+      return this.generateObjectMethod(node, objectMethod);
+    }
+
+    // RegExp 的 test / exec
+    const regexpMethod = node.metadata.get('regexpMethod') as string | undefined;
+    if (regexpMethod && node.callee instanceof ir.MemberExpression) {
+      const regex = node.callee.object.accept(this);
+      const input = node.args[0]?.accept(this) ?? '""';
+      return regexpMethod === 'test' ?
+        `${regex}.MatchString(${input})` :
+        `${regex}.FindStringSubmatch(${input})`;
+    }
+
+    // String.prototype 方法依 stringFidelity 降階為 strings / runtime helper
+    const stringMethod = node.metadata.get('stringMethod') as StringMethodInfo | undefined;
+    if (stringMethod && node.callee instanceof ir.MemberExpression) {
+      return this.generateStringMethod(node, node.callee.object, stringMethod);
+    }
+
+    // Date 的方法對應 time.Time；Date.now() 取得目前的毫秒數
+    const dateMethod = node.metadata.get('dateMethod') as string | undefined;
+    if (dateMethod && node.callee instanceof ir.MemberExpression) {
+      return this.generateDateMethod(node, node.callee.object, dateMethod);
+    }
+    if (node.callee instanceof ir.MemberExpression && node.callee.object instanceof ir.Identifier &&
+        node.callee.object.name === 'Date' && node.callee.property instanceof ir.Identifier &&
+        node.callee.property.name === 'now') {
+      this.addImport('time');
+      return this.dateNumber('time.Now().UnixMilli()');
+    }
+
+    // Math、Number 與全域的數值函式，以及 number 的 toFixed / toString
+    const numericCall = this.generateNumericCall(node);
+    if (numericCall) {
+      return numericCall;
+    }
+
     // Handle array methods that need special treatment in Go
     if (node.callee instanceof ir.MemberExpression) {
       const memberExpr = node.callee as ir.MemberExpression;
@@ -2072,7 +3239,12 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     }
 
     const callee = node.callee.accept(this);
-    const args = node.args.map(arg => arg.accept(this)).join(', ');
+    const argList = this.generateArguments(node);
+    // async 函式與需要 ctx 的函式，第一個參數為 ctx
+    if (node.metadata.get('asyncCall') || node.metadata.get('contextCall')) {
+      argList.unshift(this.currentContext());
+    }
+    const args = argList.join(', ');
 
     // 型別參數
     let typeArgs = '';
@@ -2080,140 +3252,3029 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
       typeArgs = '[' + node.typeArguments.map(t => t.accept(this)).join(', ') + ']';
     }
 
+    // 多載變體呼叫共用實作：實作回傳較寬的型別時斷言回簽名的型別
+    const resultAssertion = node.metadata.get('resultAssertion') as ir.IRType | undefined;
+    if (resultAssertion && !node.metadata.get('asyncCall')) {
+      const resultType = resultAssertion.accept(this);
+      if (resultType && resultType !== 'interface{}') {
+        return `${callee}${typeArgs}(${args}).(${resultType})`;
+      }
+    }
+
     return `${callee}${typeArgs}(${args})`;
   }
 
-  visitMemberExpression(node: ir.MemberExpression): string {
-    const object = node.object.accept(this);
+  // ============= Array Methods =============
 
-    if (node.computed) {
-      const property = node.property.accept(this);
-      return `${object}[${property}]`;
-    } else {
-      let property: string;
-      if (node.property instanceof ir.Identifier) {
-        const propName = node.property.name;
-        // Keep private field names lowercase, capitalize public fields
-        if (this.privateFieldNames.has(propName)) {
-          property = propName; // Keep lowercase for private fields
-        } else {
-          property = this.capitalize(propName); // Capitalize public fields
+  /**
+   * JS 陣列方法的對應：
+   * - 查找類（includes/indexOf/slice()/concat/join）盡量使用標準庫 slices / strings
+   * - 其餘使用 runtime helper，保留負索引、找不到時回傳 -1 或 nil（undefined）的語義
+   * - reverse/fill/splice/sort 原地修改（splice 需傳入指標以改變長度），slice/concat/toSorted/toReversed 回傳複本
+   */
+  private generateArrayMethod(node: ir.CallExpression, receiver: ir.Expression, info: ArrayMethodInfo): string {
+    const array = receiver.accept(this);
+    // flat 的深度於編譯期展開，不產生引數（避免 Infinity 匯入未使用的 math）
+    const args = info.method === 'flat' ? [] : node.args.map(arg => arg.accept(this));
+    const helper = (name: string, ...rest: string[]): string =>
+      `${this.runtimeRef(name)}(${[array, ...rest].join(', ')})`;
+    // 回呼接收索引（reduce 為第三個參數）時改用 Indexed 版本
+    const callback = (name: string, indexArity: number = 2): string =>
+      info.callbackArity >= indexArity ? `${name}Indexed` : name;
+
+    switch (info.method) {
+      case 'map':
+      case 'filter':
+      case 'find':
+      case 'findIndex':
+      case 'some':
+      case 'every':
+      case 'flatMap':
+        return helper(callback(this.capitalize(info.method)), args[0]);
+
+      case 'reduce':
+        return args.length > 1 ?
+          helper(callback('Reduce', 3), args[1], args[0]) :
+          helper('ReduceFirst', args[0]);
+
+      case 'includes':
+        if (args.length > 1) {
+          return `(${helper('IndexOf', args[0], args[1])} >= 0)`;
         }
-      } else {
-        property = node.property.accept(this);
+        this.addImport('slices');
+        return `slices.Contains(${array}, ${args[0]})`;
+
+      case 'indexOf':
+        if (args.length > 1) {
+          return helper('IndexOf', args[0], args[1]);
+        }
+        this.addImport('slices');
+        return `slices.Index(${array}, ${args[0]})`;
+
+      // 省略 fromIndex 時傳入 -1（最後一個元素），Go 才能推導索引型別
+      case 'lastIndexOf':
+        return helper('LastIndexOf', args[0], args[1] ?? '-1');
+
+      case 'slice':
+        if (args.length === 0) {
+          this.addImport('slices');
+          return `slices.Clone(${array})`;
+        }
+        return helper('Slice', ...args);
+
+      case 'splice': {
+        // splice(start) 刪除至結尾；len 轉為數字型別，與 start 推導出相同的索引型別
+        const [start = '0', deleteCount = args.length > 0 ? `${this.numberType()}(len(${array}))` : '0', ...items] = args;
+        return `${this.runtimeRef('Splice')}(${[`&${array}`, start, deleteCount, ...items].join(', ')})`;
       }
 
-      // Optional chaining
-      if (node.optional) {
-        this.needsRuntime = true;
-        // TODO: Generate runtime helper
-        return `${object}.${property}`;
+      case 'concat': {
+        // 非陣列的引數視為單一元素
+        const elementType = info.elementType?.accept(this) || 'interface{}';
+        const parts = args.map((arg, i) => info.arrayArgs[i] ? arg : `[]${elementType}{${arg}}`);
+        this.addImport('slices');
+        return `slices.Concat(${[array, ...parts].join(', ')})`;
       }
 
-      return `${object}.${property}`;
-    }
-  }
+      case 'join': {
+        const separator = args[0] ?? '","';
+        if (info.elementType instanceof ir.PrimitiveType && info.elementType.kind === 'string') {
+          this.addImport('strings');
+          return `strings.Join(${array}, ${separator})`;
+        }
+        return helper('Join', separator);
+      }
 
-  visitNewExpression(node: ir.NewExpression): string {
-    const callee = node.callee.accept(this);
-    const args = node.args.map(arg => arg.accept(this)).join(', ');
+      case 'flat': {
+        // flat(depth) 展開為巢狀 Flat；深度由 IRTransformer 依型別決定（Infinity 展開至最內層），flat(0) 僅複製
+        const depthArg = node.args[0];
+        const depth = info.flatDepth ??
+          (depthArg instanceof ir.Literal && typeof depthArg.value === 'number' ? depthArg.value : 1);
+        if (depth <= 0) {
+          this.addImport('slices');
+          return `slices.Clone(${array})`;
+        }
+        let result = array;
+        for (let i = 0; i < depth; i++) {
+          result = `${this.runtimeRef('Flat')}(${result})`;
+        }
+        return result;
+      }
 
-    // Special handling for Date
-    if (callee === 'Date') {
-      this.addImport('time');
-      return 'time.Now()';
-    }
+      case 'reverse':
+        return helper('Reverse');
 
-    // TypeScript's new → Go's constructor function
-    return `New${callee}(${args})`;
-  }
+      // 穩定排序；未提供比較函式時依 JS 以字串形式（UTF-16）比較
+      case 'sort':
+        return args.length > 0 ? helper('SortStable', args[0]) : helper('SortDefault');
 
-  visitSuperExpression(node: ir.SuperExpression): string {
-    // For now, just return a placeholder - this will be handled specially
-    // in the constructor generation code
-    const args = node.args.map(arg => arg.accept(this)).join(', ');
-    return `__SUPER__(${args})`;
-  }
+      // 不修改原陣列的版本先複製
+      case 'toSorted':
+      case 'toReversed': {
+        this.addImport('slices');
+        const copy = `slices.Clone(${array})`;
+        if (info.method === 'toReversed') {
+          return `${this.runtimeRef('Reverse')}(${copy})`;
+        }
+        return args.length > 0 ?
+          `${this.runtimeRef('SortStable')}(${copy}, ${args[0]})` :
+          `${this.runtimeRef('SortDefault')}(${copy})`;
+      }
 
-  visitBinaryExpression(node: ir.BinaryExpression): string {
-    const left = node.left.accept(this);
-    const right = node.right.accept(this);
+      case 'fill':
+        return helper('Fill', ...args);
 
-    // 特殊運算子轉換
-    switch (node.operator) {
-      case '===':
-      case '==':
-        return `${left} == ${right}`;
-      case '!==':
-      case '!=':
-        return `${left} != ${right}`;
-      case '??':
-        // Nullish coalescing
-        return `func() interface{} { if ${left} != nil { return ${left} }; return ${right} }()`;
-      default:
-        return `${left} ${node.operator} ${right}`;
+      case 'at':
+        return helper('At', args[0]);
     }
+
+    return `${array}.${this.capitalize(info.method)}(${args.join(', ')})`;
   }
 
-  visitUnaryExpression(node: ir.UnaryExpression): string {
-    const arg = node.argument.accept(this);
+  // ============= String Methods =============
 
-    switch (node.operator) {
-      case 'typeof':
-        this.addImport('reflect');
-        return `reflect.TypeOf(${arg}).String()`;
-      case 'void':
-        return `func() interface{} { ${arg}; return nil }()`;
-      case 'delete':
-        // delete 在 Go 中用於 map
-        return `delete(/* map */, ${arg})`;
-      default:
-        if (node.prefix) {
-          return `${node.operator}${arg}`;
-        } else {
-          return `${arg}${node.operator}`;
-        }
+  /**
+   * JS 字串方法的對應，依 stringFidelity：
+   * - fast：len() 與位元組索引，使用 strings 套件；非 ASCII 文字的長度與位置與 JS 不同
+   * - faithful：runtime helper 以 UTF-16 code unit 計算位置，並保留 JS 的空白、大小寫對應與取代樣式（$&、$1）
+   * 不涉及位置的查找（includes/startsWith/split）兩種模式皆使用 strings；
+   * 回傳 undefined 的 codePointAt 與 strings 無對應的 padStart/padEnd 一律使用 runtime helper
+   */
+  private generateStringMethod(node: ir.CallExpression, receiver: ir.Expression, info: StringMethodInfo): string {
+    const str = receiver.accept(this);
+    const args = node.args.map(arg => arg.accept(this));
+    const faithful = this.options.stringFidelity === 'faithful';
+    const helper = (name: string, ...rest: string[]): string =>
+      `${this.runtimeRef(name)}(${[str, ...rest].join(', ')})`;
+    const strings = (name: string, ...rest: string[]): string => {
+      this.addImport('strings');
+      return `strings.${name}(${[str, ...rest].join(', ')})`;
+    };
+    const index = (i: number): string => this.byteIndex(node.args[i], args[i], str, receiver);
+
+    switch (info.method) {
+      case 'charAt':
+        return helper(faithful ? 'CharAt' : 'ByteAt', args[0] ?? '0');
+
+      case 'charCodeAt': {
+        if (faithful) return helper('CharCodeAt', args[0] ?? '0');
+        const numberType = this.options.numberStrategy === 'int' ? 'int' : 'float64';
+        return `${numberType}(${str}[${args.length > 0 ? index(0) : '0'}])`;
+      }
+
+      case 'codePointAt':
+        return helper('CodePointAt', args[0] ?? '0');
+
+      case 'slice':
+      case 'substring': {
+        if (args.length === 0) return str;
+        // fast 模式以位元組索引，超出範圍時與 JS 相同地截至字串兩端而非 panic
+        if (info.method === 'slice') {
+          return helper(faithful ? 'StringSlice' : 'ByteSlice', ...args);
+        }
+        return helper(faithful ? 'Substring' : 'ByteSubstring', ...args);
+      }
+
+      case 'padStart':
+      case 'padEnd':
+        return helper(this.capitalize(info.method), ...args);
+
+      case 'indexOf':
+        // 起始位置以 UTF-16 code unit 計算
+        if (faithful || args.length > 1) {
+          // 未傳入起始位置時無法推斷索引型別
+          return args.length > 1 ?
+            helper('StringIndexOf', ...args) :
+            `${this.runtimeRef('StringIndexOf')}[int](${str}, ${args[0]})`;
+        }
+        return strings('Index', args[0]);
+
+      case 'includes':
+      case 'startsWith':
+      case 'endsWith': {
+        const name = STRING_PREDICATES[info.method];
+        if (args.length < 2) {
+          return strings(name, args[0]);
+        }
+        // 第二個引數為起始（endsWith 為結束）位置，負值視為 0
+        let target: string;
+        if (faithful) {
+          target = info.method === 'endsWith' ? helper('Substring', '0', args[1]) : helper('Substring', args[1]);
+        } else {
+          target = info.method === 'endsWith' ? `${str}[:${index(1)}]` : `${str}[${index(1)}:]`;
+        }
+        this.addImport('strings');
+        return `strings.${name}(${target}, ${args[0]})`;
+      }
+
+      case 'split': {
+        if (args.length === 0) return `[]string{${str}}`;
+        if (info.regexArgs[0]) {
+          return `${args[0]}.Split(${str}, -1)`;
+        }
+        // limit 截斷結果（strings.SplitN 會保留剩餘部分）；faithful 的空分隔字串依 code unit 拆分
+        const separator = node.args[0];
+        const nonEmpty = separator instanceof ir.Literal && typeof separator.value === 'string' && separator.value !== '';
+        if (args.length > 1) {
+          return helper('Split', ...args);
+        }
+        if (faithful && !nonEmpty) {
+          return `${this.runtimeRef('Split')}[int](${str}, ${args[0]})`;
+        }
+        return strings('Split', args[0]);
+      }
+
+      case 'replace':
+      case 'replaceAll': {
+        const [pattern, replacement] = args;
+        if (info.callbackArity > 0) {
+          return this.generateReplaceFunc(node, str, info);
+        }
+        const replacementArg = node.args[1];
+        const plain = replacementArg instanceof ir.Literal && typeof replacementArg.value === 'string' &&
+          !replacementArg.value.includes('$');
+        if (info.regexArgs[0]) {
+          const all = info.method === 'replaceAll' || info.globalRegex;
+          // Go 的 ReplaceAllString 以 ${name} 展開 $ 樣式（$1x 為群組 1x，沒有 $&），
+          // 僅不含 $ 的字面量可直接使用，其餘依 JS 的規則由 runtime 展開
+          if (all && !faithful && plain) {
+            return `${pattern}.ReplaceAllString(${str}, ${replacement})`;
+          }
+          return `${this.runtimeRef('ReplaceRegex')}(${pattern}, ${str}, ${replacement}, ${all})`;
+        }
+        // 字串樣式：僅 faithful 模式且取代字串可能含 $ 樣式時展開
+        if (faithful && !plain) {
+          return helper('ReplaceString', pattern, replacement, String(info.method === 'replaceAll'));
+        }
+        return info.method === 'replaceAll' ?
+          strings('ReplaceAll', pattern, replacement) :
+          strings('Replace', pattern, replacement, '1');
+      }
+
+      // 非全域的 match 與 exec 相同：符合的字串與各捕獲群組；全域時為所有符合的字串
+      case 'match':
+      case 'matchAll': {
+        const regex = info.regexArgs[0] ? args[0] : this.compileRegExp(args[0]);
+        if (info.method === 'matchAll') {
+          return `${regex}.FindAllStringSubmatch(${str}, -1)`;
+        }
+        return info.globalRegex ? `${regex}.FindAllString(${str}, -1)` : `${regex}.FindStringSubmatch(${str})`;
+      }
+
+      case 'trim':
+        return faithful ? helper('Trim') : strings('TrimSpace');
+
+      case 'trimStart':
+      case 'trimEnd':
+        if (faithful) {
+          return helper(this.capitalize(info.method));
+        }
+        this.addImport('unicode');
+        return strings(info.method === 'trimStart' ? 'TrimLeftFunc' : 'TrimRightFunc', 'unicode.IsSpace');
+
+      case 'toUpperCase':
+      case 'toLowerCase': {
+        const name = info.method === 'toUpperCase' ? 'ToUpper' : 'ToLower';
+        return faithful ? helper(name) : strings(name);
+      }
+
+      case 'localeCompare':
+        if (faithful) {
+          return `${this.localeCompareFunction()}(${str}, ${args[0]})`;
+        }
+        return strings('Compare', args[0]);
+
+      case 'normalize': {
+        const formArg = node.args[0];
+        const form = formArg instanceof ir.Literal && typeof formArg.value === 'string' &&
+          ['NFC', 'NFD', 'NFKC', 'NFKD'].includes(formArg.value) ? formArg.value : 'NFC';
+        this.addImport('golang.org/x/text/unicode/norm');
+        return `norm.${form}.String(${str})`;
+      }
+
+      case 'repeat':
+        return strings('Repeat', index(0));
+    }
+
+    return `${str}.${this.capitalize(info.method)}(${args.join(', ')})`;
+  }
+
+  /**
+   * 以函式取代：取代函式接收符合的字串與各捕獲群組
+   *   s.replace(/(\w+)@/g, (m, user) => user.toUpperCase())
+   *   → runtime.ReplaceRegexFunc(regex1, s, func(groups []string) string {
+   *       return func(m string, user string) string { ... }(groups[0], groups[1])
+   *     }, true)
+   * 只接收符合字串的全域取代直接使用 ReplaceAllStringFunc；字串樣式以 regexp.QuoteMeta 編譯
+   */
+  private generateReplaceFunc(node: ir.CallExpression, str: string, info: StringMethodInfo): string {
+    const pattern = node.args[0].accept(this);
+    const replacer = node.args[1].accept(this);
+    const regex = info.regexArgs[0] ? pattern : this.compileRegExp(`regexp.QuoteMeta(${pattern})`);
+    const all = info.method === 'replaceAll' || info.globalRegex;
+
+    if (all && info.callbackArity === 1) {
+      return `${regex}.ReplaceAllStringFunc(${str}, ${replacer})`;
+    }
+    const groups = Array.from({ length: info.callbackArity }, (_, i) => `groups[${i}]`).join(', ');
+    const wrapper = `func(groups []string) string { return ${replacer}(${groups}) }`;
+    return `${this.runtimeRef('ReplaceRegexFunc')}(${regex}, ${str}, ${wrapper}, ${all})`;
+  }
+
+  /**
+   * 執行時編譯字串形式的 pattern（match 的字串引數等）
+   */
+  private compileRegExp(pattern: string): string {
+    this.addImport('regexp');
+    return `regexp.MustCompile(${pattern})`;
+  }
+
+  /**
+   * fast 模式的位元組索引：float64 的數字轉為 int，負數字面量（slice(-3)）自結尾起算
+   */
+  private byteIndex(node: ir.Expression, code: string, str: string, receiver: ir.Expression): string {
+    const negated = node instanceof ir.UnaryExpression && node.operator === '-' && node.argument instanceof ir.Literal ?
+      node.argument.raw : undefined;
+    if (negated !== undefined && (receiver instanceof ir.Identifier || receiver instanceof ir.MemberExpression)) {
+      return `len(${str})-${negated}`;
+    }
+    if (this.options.numberStrategy === 'int' || (node instanceof ir.Literal && Number.isInteger(node.value))) {
+      return code;
+    }
+    return `int(${code})`;
+  }
+
+  /**
+   * 提升後的泛型方法名稱，{Method}、{Class} 由 genericMethodNaming 樣板代換
+   */
+  private genericMethodName(className: string, methodName: string): string {
+    const pattern = this.options.genericMethodNaming || '{Method}{Class}';
+    return pattern
+      .replace(/\{Method\}/g, this.capitalize(methodName))
+      .replace(/\{Class\}/g, className);
+  }
+
+  /**
+   * 接收者成為第一個引數，鏈式呼叫 box.map(f).map(g) 自然展開為 MapBox(MapBox(box, f), g)
+   */
+  private generateGenericMethodCall(node: ir.CallExpression, callee: ir.MemberExpression, info: GenericMethodCallInfo): string {
+    const className = this.exportName(info.className, info.exported);
+    let receiver = callee.object.accept(this);
+    if (info.embedded) {
+      receiver = `&${receiver}.${className}`;
+    }
+
+    const args = [receiver, ...this.generateArguments(node)];
+    if (node.metadata.get('asyncCall') || node.metadata.get('contextCall')) {
+      args.unshift(this.currentContext());
+    }
+
+    // 明確的型別引數：類別的型別引數在前，方法的在後
+    let typeArgs = '';
+    if (info.classTypeArguments && node.typeArguments && node.typeArguments.length > 0) {
+      typeArgs = '[' + [...info.classTypeArguments, ...node.typeArguments].map(t => t.accept(this)).join(', ') + ']';
+    }
+
+    return `${this.genericMethodName(className, info.methodName)}${typeArgs}(${args.join(', ')})`;
+  }
+
+  visitMemberExpression(node: ir.MemberExpression): string {
+    // Math.PI、Number.MAX_SAFE_INTEGER 等常數
+    if (!node.computed && node.object instanceof ir.Identifier && node.property instanceof ir.Identifier &&
+        (node.object.name === 'Math' || node.object.name === 'Number')) {
+      const constant = this.numericConstant(`${node.object.name}.${node.property.name}`);
+      if (constant) {
+        return constant;
+      }
+    }
+
+    // controller.signal → controller 對應的 ctx
+    const abortController = node.metadata.get('abortSignalOf') as string | undefined;
+    if (abortController) {
+      return `${abortController}Ctx`;
+    }
+
+    // Object.keys(x).length：map 直接取 len(x)，不需先列出鍵；其他為結果 slice 的長度
+    const objectMethod = node.object.metadata.get('objectMethod') as ObjectMethodInfo | undefined;
+    if (!node.computed && node.property instanceof ir.Identifier && node.property.name === 'length' &&
+        node.object instanceof ir.CallExpression &&
+        (objectMethod?.method === 'keys' || objectMethod?.method === 'values' || objectMethod?.method === 'entries')) {
+      return objectMethod.shape?.kind === 'map' ?
+        `len(${node.object.args[0].accept(this)})` :
+        `len(${node.object.accept(this)})`;
+    }
+
+    const object = node.object.accept(this);
+
+    // signal.aborted / signal.reason → ctx.Err() / context.Cause(ctx)
+    if (node.metadata.get('abortSignal') && !node.computed && node.property instanceof ir.Identifier) {
+      if (node.property.name === 'aborted') {
+        return `${object}.Err() != nil`;
+      }
+      if (node.property.name === 'reason') {
+        this.addImport('context');
+        return `context.Cause(${object})`;
+      }
+    }
+
+    if (node.computed) {
+      const property = node.property.accept(this);
+      return `${object}[${property}]`;
+    } else {
+      let property: string;
+      if (node.property instanceof ir.Identifier) {
+        const propName = node.property.name;
+        // Keep private field names lowercase, capitalize public fields
+        if (this.privateFieldNames.has(propName)) {
+          property = propName; // Keep lowercase for private fields
+        } else {
+          property = this.capitalize(propName); // Capitalize public fields
+        }
+      } else {
+        property = node.property.accept(this);
+      }
+
+      // Map / Set 的 size
+      const collection = node.metadata.get('collectionSize') as CollectionInfo | undefined;
+      if (collection) {
+        return this.isOrderedCollection(collection) ? `${object}.Len()` : `len(${object})`;
+      }
+
+      // 字串長度：fast 為位元組數，faithful 為 UTF-16 code unit 數
+      if (node.metadata.get('stringLength')) {
+        return this.options.stringFidelity === 'faithful' ?
+          `${this.runtimeRef('StringLength')}(${object})` :
+          `len(${object})`;
+      }
+
+      // Sealed interface 上的判別欄位與結構型別限制的欄位以 getter 取得
+      if (node.metadata.get('discriminantGetter') || node.metadata.get('constraintGetter')) {
+        return `${object}.Get${this.capitalize(property)}()`;
+      }
+
+      // Optional chaining
+      if (node.optional) {
+        this.needsRuntime = true;
+        // TODO: Generate runtime helper
+        return `${object}.${property}`;
+      }
+
+      return `${object}.${property}`;
+    }
+  }
+
+  visitNewExpression(node: ir.NewExpression): string {
+    const callee = node.callee.accept(this);
+
+    // 動態 pattern 的 new RegExp(pattern, 'i') 於執行時編譯，旗標以 (?i) 前置
+    if (node.metadata.has('regexpFlags')) {
+      const args = node.args.map(arg => arg.accept(this)).join(', ');
+      this.addImport('regexp');
+      const flags = node.metadata.get('regexpFlags') as string;
+      return `regexp.MustCompile(${flags ? `${goStringLiteral(flags)} + ` : ''}${args})`;
+    }
+
+    const dateConstructor = node.metadata.get('dateConstructor') as string | undefined;
+    if (dateConstructor) {
+      return this.generateDateConstructor(node, dateConstructor);
+    }
+
+    const collection = node.metadata.get('collectionConstructor') as CollectionConstructorInfo | undefined;
+    if (collection) {
+      return this.generateCollectionConstructor(node, collection);
+    }
+
+    // TypeScript's new → Go's constructor function
+    return `New${callee}(${this.generateArguments(node).join(', ')})`;
+  }
+
+  visitSuperExpression(node: ir.SuperExpression): string {
+    // For now, just return a placeholder - this will be handled specially
+    // in the constructor generation code
+    const args = node.args.map(arg => arg.accept(this)).join(', ');
+    return `__SUPER__(${args})`;
+  }
+
+  visitBinaryExpression(node: ir.BinaryExpression): string {
+    const dateOperands = node.metadata.get('dateOperands') as [boolean, boolean] | undefined;
+    if (dateOperands) {
+      return this.generateDateArithmetic(node, dateOperands);
+    }
+
+    const numeric = this.generateNumericOperator(
+      node.operator, node.left, node.right, !!node.metadata.get('numericOperands')
+    );
+    if (numeric) {
+      return numeric;
+    }
+
+    const lookup = this.generateCollectionLookup(node);
+    if (lookup) {
+      return lookup;
+    }
+
+    const left = this.wrapParenthesized(node.left);
+    const right = this.wrapParenthesized(node.right);
+
+    // 特殊運算子轉換
+    switch (node.operator) {
+      case '===':
+      case '==':
+        return `${left} == ${right}`;
+      case '!==':
+      case '!=':
+        return `${left} != ${right}`;
+      case '??':
+        // Nullish coalescing
+        return `func() interface{} { if ${left} != nil { return ${left} }; return ${right} }()`;
+      default:
+        return `${left} ${node.operator} ${right}`;
+    }
+  }
+
+  /**
+   * 保留原始碼中的括號以維持運算優先序
+   */
+  private wrapParenthesized(node: ir.Expression): string {
+    const code = node.accept(this);
+    return node.metadata.get('parenthesized') ? `(${code})` : code;
+  }
+
+  visitUnaryExpression(node: ir.UnaryExpression): string {
+    const arg = this.wrapParenthesized(node.argument);
+
+    // +date → 毫秒數
+    if (node.metadata.get('dateOperand')) {
+      const value = this.dateNumber(`${arg}.UnixMilli()`);
+      return node.operator === '-' ? `-${value}` : value;
+    }
+
+    switch (node.operator) {
+      case '~':
+        // Go 的位元反轉為 ^，運算元為 32 位元整數
+        return `${this.numberType()}(^${this.int32Operand(node.argument, arg, false)})`;
+      case 'typeof':
+        this.addImport('reflect');
+        return `reflect.TypeOf(${arg}).String()`;
+      case 'void':
+        return `func() interface{} { ${arg}; return nil }()`;
+      case 'delete':
+        // delete 在 Go 中用於 map
+        return `delete(/* map */, ${arg})`;
+      default:
+        if (node.prefix) {
+          return `${node.operator}${arg}`;
+        } else {
+          return `${arg}${node.operator}`;
+        }
+    }
+  }
+
+  visitAssignmentExpression(node: ir.AssignmentExpression): string {
+    const left = node.left.accept(this);
+
+    // x **= y、x >>>= y 等 Go 沒有或語義不同的複合指定展開為 x = x ** y
+    if (node.operator !== '=') {
+      const numeric = this.generateNumericOperator(
+        node.operator.slice(0, -1), node.left, node.right, !!node.metadata.get('numericOperands')
+      );
+      if (numeric) {
+        return `${left} = ${numeric}`;
+      }
+    }
+
+    const right = node.right.accept(this);
+    return `${left} ${node.operator} ${right}`;
+  }
+
+  visitConditionalExpression(node: ir.ConditionalExpression): string {
+    const test = node.test.accept(this);
+    const consequent = node.consequent.accept(this);
+    const alternate = node.alternate.accept(this);
+
+    // Go 沒有三元運算子，使用 IIFE
+    return `func() interface{} { if ${test} { return ${consequent} }; return ${alternate} }()`;
+  }
+
+  visitAwaitExpression(node: ir.AwaitExpression): string {
+    // 巢狀於表達式中的 await 先提升為獨立陳述式並檢查 error
+    const frame = this.currentAsyncFrame();
+    if (frame && this.isGoAwaitable(node.argument)) {
+      if (this.isVoidAwait(node)) {
+        this.pendingStatements.push(this.generateAwaitStatement(node, frame));
+        return '';
+      }
+      const name = ++this.awaitCounter === 1 ? 'awaited' : `awaited${this.awaitCounter}`;
+      this.pendingStatements.push(this.generateAwaitStatement(node, frame, name));
+      return name;
+    }
+
+    // await 轉換為同步呼叫 + error check
+    const arg = node.argument.accept(this);
+
+    // 假設 await 的表達式返回 (value, error)
+    return arg; // 呼叫方處理 error
+  }
+
+  // ============= Numbers =============
+
+  /**
+   * number 對應的 Go 型別
+   */
+  private numberType(): string {
+    return this.options.numberStrategy === 'int' ? 'int' : 'float64';
+  }
+
+  /**
+   * NaN、Infinity 與 Math / Number 的常數；int 策略下 Infinity 以 int 的極值表示，NaN 為 0
+   */
+  private numericConstant(name: string): string | undefined {
+    const intStrategy = this.options.numberStrategy === 'int';
+    let value: string | undefined;
+    switch (name) {
+      case 'NaN':
+      case 'Number.NaN':
+        value = intStrategy ? '0' : 'math.NaN()';
+        break;
+      case 'Infinity':
+      case 'Number.POSITIVE_INFINITY':
+        value = intStrategy ? 'math.MaxInt' : 'math.Inf(1)';
+        break;
+      case 'Number.NEGATIVE_INFINITY':
+        value = intStrategy ? 'math.MinInt' : 'math.Inf(-1)';
+        break;
+      default:
+        value = NUMERIC_CONSTANTS[name];
+    }
+    if (value?.includes('math.')) {
+      this.addImport('math');
+    }
+    return value;
+  }
+
+  /**
+   * 整數字面量（含負號）的值，其餘為 undefined
+   */
+  private integerLiteral(node: ir.Expression): number | undefined {
+    if (node instanceof ir.UnaryExpression && node.operator === '-') {
+      const value = this.integerLiteral(node.argument);
+      return value === undefined ? undefined : -value;
+    }
+    return node instanceof ir.Literal && typeof node.value === 'number' && Number.isInteger(node.value) ?
+      node.value :
+      undefined;
+  }
+
+  /**
+   * 與 Go 語義不同的數值運算子，不需降階時回傳 null：
+   *   a ** b  → math.Pow(a, b)
+   *   a % b   → math.Mod(a, b)（float64；Go 的 % 不接受浮點數）
+   *   a & b   → float64(runtime.ToInt32(a) & runtime.ToInt32(b))
+   *   a >>> b → float64(runtime.ToUint32(a) >> (runtime.ToUint32(b) & 31))
+   * 位元運算的運算元依 JS 轉為 32 位元整數，位移量取低 5 位元；int 策略以 int32 / uint32 轉換達到相同的溢位行為。
+   * numeric 為 false 時（enum 等具名整數型別）只降階 Go 沒有的 ** 與 >>>
+   */
+  private generateNumericOperator(op: string, left: ir.Expression, right: ir.Expression, numeric: boolean): string | null {
+    if (op !== '**' && op !== '>>>' && !numeric) return null;
+    const intStrategy = this.options.numberStrategy === 'int';
+    // 運算元皆置於函式呼叫或型別轉換的括號內，不需保留原始的括號
+    const l = left.accept(this);
+    const r = right.accept(this);
+
+    switch (op) {
+      case '**':
+        this.addImport('math');
+        return intStrategy ? `int(math.Pow(float64(${l}), float64(${r})))` : `math.Pow(${l}, ${r})`;
+      case '%':
+        if (intStrategy) return null;
+        this.addImport('math');
+        return `math.Mod(${l}, ${r})`;
+      case '&':
+      case '|':
+      case '^':
+      case '<<':
+      case '>>':
+      case '>>>':
+        break;
+      default:
+        return null;
+    }
+
+    // 兩側皆為字面量時與 Go 的常數運算結果相同者保留原式（enum 的 1 << 2），否則以 JS 的結果折疊
+    const a = this.integerLiteral(left);
+    const b = this.integerLiteral(right);
+    if (a !== undefined && b !== undefined) {
+      const folded = this.foldBitwise(op, a, b);
+      return op !== '>>>' && (a | 0) === a && (b | 0) === b && b >= 0 && b < 32 && this.foldBitwise(op, a, b, true) === folded ?
+        null :
+        String(folded);
+    }
+
+    const shift = op === '<<' || op === '>>' || op === '>>>';
+    const operand = this.int32Operand(left, l, op === '>>>');
+    const count = shift ? this.shiftCount(right, r) : this.int32Operand(right, r, false);
+    // x >>> 0、x | 0 常用於轉為 32 位元整數，只需轉換
+    if (count === '0' && (shift || op === '|' || op === '^')) {
+      return `${this.numberType()}(${operand})`;
+    }
+    return `${this.numberType()}(${operand} ${op === '>>>' ? '>>' : op} ${count})`;
+  }
+
+  /**
+   * 字面量的位元運算；exact 為 true 時以不溢位的數學結果計算（Go 的常數運算）
+   */
+  private foldBitwise(op: string, a: number, b: number, exact: boolean = false): number {
+    switch (op) {
+      case '&': return a & b;
+      case '|': return a | b;
+      case '^': return a ^ b;
+      case '<<': return exact ? a * 2 ** b : a << b;
+      case '>>': return exact ? Math.floor(a / 2 ** b) : a >> b;
+      default: return a >>> b;
+    }
+  }
+
+  /**
+   * 位元運算的運算元轉為 int32（unsigned 時為 uint32）；字面量直接以轉換後的值表示
+   */
+  private int32Operand(node: ir.Expression, code: string, unsigned: boolean): string {
+    const literal = this.integerLiteral(node);
+    if (literal !== undefined) {
+      return String(unsigned ? literal >>> 0 : literal | 0);
+    }
+    if (this.options.numberStrategy === 'int') {
+      return `${unsigned ? 'uint32' : 'int32'}(${code})`;
+    }
+    return `${this.runtimeRef(unsigned ? 'ToUint32' : 'ToInt32')}(${code})`;
+  }
+
+  /**
+   * 位移量只取低 5 位元（1 << 33 為 2）
+   */
+  private shiftCount(node: ir.Expression, code: string): string {
+    const literal = this.integerLiteral(node);
+    if (literal !== undefined) {
+      return String(literal & 31);
+    }
+    return `(${this.int32Operand(node, code, true)} & 31)`;
+  }
+
+  /**
+   * Math / Number 的函式、全域的 parseInt / parseFloat / isNaN / isFinite，
+   * 以及 number 的 toFixed / toString；不屬於這些呼叫時回傳 null
+   */
+  private generateNumericCall(node: ir.CallExpression): string | null {
+    const intStrategy = this.options.numberStrategy === 'int';
+    // 引數只在確定為數值呼叫後產生，否則由一般的呼叫再次產生時會重複提升其中的陳述式
+    const visitArgs = () => node.args.map(arg => arg.accept(this));
+
+    const numberMethod = node.metadata.get('numberMethod') as string | undefined;
+    if (numberMethod && node.callee instanceof ir.MemberExpression) {
+      const args = visitArgs();
+      const value = this.wrapParenthesized(node.callee.object);
+      if (numberMethod === 'toFixed') {
+        return `${this.runtimeRef('ToFixed')}(${value}, ${args[0] ?? '0'})`;
+      }
+      return args.length > 0 ?
+        `${this.runtimeRef('NumberToStringRadix')}(${value}, ${args[0]})` :
+        `${this.runtimeRef('NumberToString')}(${value})`;
+    }
+
+    let owner = '';
+    let name: string;
+    if (node.callee instanceof ir.Identifier) {
+      name = node.callee.name;
+    } else if (node.callee instanceof ir.MemberExpression && !node.callee.computed &&
+               node.callee.object instanceof ir.Identifier && node.callee.property instanceof ir.Identifier &&
+               (node.callee.object.name === 'Math' || node.callee.object.name === 'Number')) {
+      owner = node.callee.object.name;
+      name = node.callee.property.name;
+    } else {
+      return null;
+    }
+
+    if (owner === 'Math') {
+      return this.generateMathCall(node, name, visitArgs());
+    }
+    if (!NUMBER_FUNCTIONS.has(name)) {
+      return null;
+    }
+    const args = visitArgs();
+
+    // 全域函式與 Number 的同名函式（Number.parseInt === parseInt）；int 策略下無法解析的 NaN 轉為 0
+    const parsed = (code: string): string => intStrategy ? `${this.runtimeRef('ToInt')}(${code})` : code;
+    switch (name) {
+      case 'parseInt':
+        return parsed(`${this.runtimeRef('ParseInt')}(${args[0]}, ${args[1] ?? '0'})`);
+      case 'parseFloat':
+        return parsed(`${this.runtimeRef('ParseFloat')}(${args[0]})`);
+      case 'isNaN':
+        if (intStrategy) return 'false';
+        this.addImport('math');
+        return `math.IsNaN(${args[0]})`;
+      case 'isInteger':
+      case 'isSafeInteger':
+      case 'isFinite':
+        // 全域的 isInteger 並不存在
+        if (!owner && name !== 'isFinite') return null;
+        return `${this.runtimeRef(NUMBER_PREDICATES[name])}(${intStrategy ? `float64(${args[0]})` : args[0]})`;
+    }
+    return null;
+  }
+
+  /**
+   * Math 的函式：
+   *   Math.round(x)       → runtime.Round(x)（.5 一律進位，math.Round 遠離 0）
+   *   Math.max(a, b)      → max(a, b)（NaN 與 ±0 的處理與 JS 相同）
+   *   Math.max(...xs)     → runtime.MaxOf(xs...)
+   *   Math.random()       → rand.Float64()
+   *   Math.floor(x) 等    → math.Floor(x)
+   * int 策略下取整函式直接回傳引數，其餘以 float64 計算後轉回 int
+   */
+  private generateMathCall(node: ir.CallExpression, name: string, args: string[]): string | null {
+    const intStrategy = this.options.numberStrategy === 'int';
+    const toFloat = (code: string): string => intStrategy ? `float64(${code})` : code;
+    const fromFloat = (code: string): string => intStrategy ? `int(${code})` : code;
+
+    switch (name) {
+      case 'round':
+      case 'floor':
+      case 'ceil':
+      case 'trunc':
+        if (intStrategy) return args[0];
+        if (name === 'round') return `${this.runtimeRef('Round')}(${args[0]})`;
+        break;
+      case 'sign':
+        return fromFloat(`${this.runtimeRef('Sign')}(${toFloat(args[0])})`);
+      case 'max':
+      case 'min': {
+        if (node.args.some(arg => arg instanceof ir.SpreadElement)) {
+          if (node.args.length > 1) return null;
+          if (intStrategy) {
+            // int 沒有 -Infinity，空切片時 slices.Max 會 panic
+            this.addImport('slices');
+            return `slices.${this.capitalize(name)}(${(node.args[0] as ir.SpreadElement).argument.accept(this)})`;
+          }
+          return `${this.runtimeRef(name === 'max' ? 'MaxOf' : 'MinOf')}(${args[0]})`;
+        }
+        if (args.length === 0) {
+          return this.numericConstant(name === 'max' ? 'Number.NEGATIVE_INFINITY' : 'Infinity')!;
+        }
+        return `${name}(${args.join(', ')})`;
+      }
+      case 'random':
+        this.addImport('math/rand');
+        return fromFloat('rand.Float64()');
+      case 'hypot':
+        // math.Hypot 只接受兩個引數
+        if (args.length !== 2) return null;
+        break;
+      default:
+        if (!MATH_FUNCTIONS.has(name)) return null;
+    }
+
+    this.addImport('math');
+    return fromFloat(`math.${this.capitalize(name)}(${args.map(toFloat).join(', ')})`);
+  }
+
+  // ============= Date =============
+
+  /**
+   * new Date(...) 對應 time.Time：
+   *   new Date()          → time.Now()
+   *   new Date(ms)        → time.UnixMilli(ms)
+   *   new Date(y, m, ...) → time.Date(y, time.Month(m+1), ..., time.Local)
+   *   new Date(str)       → runtime.ParseDate，解析失敗依錯誤回傳慣例處理（無法回傳 error 時為零值）
+   *   new Date(date)      → 直接沿用（time.Time 為值型別）
+   */
+  private generateDateConstructor(node: ir.NewExpression, kind: string): string {
+    this.addImport('time');
+    switch (kind) {
+      case 'millis': {
+        const ms = node.args[0];
+        const integer = ms instanceof ir.Literal && Number.isInteger(ms.value);
+        return `time.UnixMilli(${integer ? ms.accept(this) : `int64(${ms.accept(this)})`})`;
+      }
+      case 'copy':
+        return node.args[0].accept(this);
+      case 'components':
+        return this.generateDateComponents(node.args);
+      case 'string':
+        return this.generateDateParse(node.args[0].accept(this));
+      default:
+        return 'time.Now()';
+    }
+  }
+
+  /**
+   * new Date(year, month, day?, hours?, minutes?, seconds?, ms?) → time.Date(..., time.Local)
+   * 月份自 0 起算；字面量年份 0–99 依 JS 視為 1900–1999，超出範圍的值由 time.Date 進位
+   */
+  private generateDateComponents(args: ir.Expression[]): string {
+    const literal = (i: number): number | undefined => {
+      const arg = args[i];
+      return arg instanceof ir.Literal && typeof arg.value === 'number' && Number.isInteger(arg.value) ?
+        arg.value :
+        undefined;
+    };
+    const component = (i: number, fallback: string): string => {
+      if (!args[i]) return fallback;
+      const code = args[i].accept(this);
+      return literal(i) !== undefined || this.options.numberStrategy === 'int' ? code : `int(${code})`;
+    };
+
+    const yearLiteral = literal(0);
+    const year = yearLiteral !== undefined && yearLiteral >= 0 && yearLiteral <= 99 ?
+      String(1900 + yearLiteral) :
+      component(0, '1970');
+    const monthLiteral = literal(1);
+    const month = monthLiteral !== undefined && monthLiteral >= 0 && monthLiteral < 12 ?
+      `time.${GO_MONTHS[monthLiteral]}` :
+      `time.Month(${component(1, '0')}+1)`;
+    const msLiteral = literal(6);
+    const nanoseconds = msLiteral !== undefined ? String(msLiteral * 1e6) :
+      args[6] ? `${component(6, '0')}*int(time.Millisecond)` : '0';
+
+    const fields = [year, month, component(2, '1'), component(3, '0'), component(4, '0'), component(5, '0'), nanoseconds];
+    return `time.Date(${fields.join(', ')}, time.Local)`;
+  }
+
+  /**
+   * new Date(str)：可回傳 error 的函式內（async 函式，以及 ErrorPropagationPass 改為回傳 error 的函式）
+   * 提升為 ParseDate 與 error 檢查；無法回傳 error 的位置改用 ParseDateOrZero，
+   * 以零值 time.Time 代表 Invalid Date（IRTransformer 對非字面量的字串產生 W4010）
+   */
+  private generateDateParse(str: string): string {
+    const frame = this.currentAsyncFrame();
+    if (frame && this.options.errorHandling !== 'panic') {
+      const name = ++this.awaitCounter === 1 ? 'date' : `date${this.awaitCounter}`;
+      this.pendingStatements.push(
+        `${name}, err := ${this.runtimeRef('ParseDate')}(${str})`,
+        `if err != nil ${this.errorBlock(frame)}`
+      );
+      return name;
+    }
+    return `${this.runtimeRef('ParseDateOrZero')}(${str})`;
+  }
+
+  /**
+   * Date 的方法：
+   *   getTime/valueOf    → UnixMilli()
+   *   toISOString/toJSON → UTC().Format(...)
+   *   getX / getUTCX     → Local() / UTC() 的對應方法（getMonth 自 0 起算）
+   *   setX / setUTCX     → runtime.SetX(&d, ...)，原地修改並回傳新的毫秒數
+   */
+  private generateDateMethod(node: ir.CallExpression, receiver: ir.Expression, method: string): string {
+    const date = receiver.accept(this);
+    const args = node.args.map(arg => arg.accept(this));
+
+    switch (method) {
+      case 'getTime':
+      case 'valueOf':
+        return this.dateNumber(`${date}.UnixMilli()`);
+      case 'toISOString':
+      case 'toJSON':
+        return `${date}.UTC().Format("2006-01-02T15:04:05.000Z")`;
+      case 'getTimezoneOffset':
+        return this.dateNumber(`${this.runtimeRef('TimezoneOffset')}(${date})`, true);
+    }
+
+    if (method.startsWith('set')) {
+      return `${this.runtimeRef(this.capitalize(method))}(&${[date, ...args].join(', ')})`;
+    }
+    const utc = method.startsWith('getUTC');
+    const field = method.slice(utc ? 6 : 3);
+    // 毫秒與時區無關
+    const time = field === 'Milliseconds' ? date : `${date}.${utc ? 'UTC' : 'Local'}()`;
+    // Month() 與 Weekday() 為具名型別，其餘為 int
+    return this.dateNumber(`${time}.${DATE_GETTERS[field]}`, field !== 'Month' && field !== 'Day');
+  }
+
+  /**
+   * Date 透過 valueOf() 運算：兩側皆為 Date 的比較使用 Before/After，相減為毫秒差，
+   * 其餘情況 Date 一側轉為毫秒數
+   */
+  private generateDateArithmetic(node: ir.BinaryExpression, dateOperands: [boolean, boolean]): string {
+    const left = this.wrapParenthesized(node.left);
+    const right = this.wrapParenthesized(node.right);
+
+    if (dateOperands[0] && dateOperands[1]) {
+      switch (node.operator) {
+        case '<': return `${left}.Before(${right})`;
+        case '>': return `${left}.After(${right})`;
+        case '<=': return `!${left}.After(${right})`;
+        case '>=': return `!${left}.Before(${right})`;
+        case '-': return this.dateNumber(`${left}.UnixMilli() - ${right}.UnixMilli()`);
+      }
+    }
+
+    const operand = (code: string, isDate: boolean): string => isDate ? this.dateNumber(`${code}.UnixMilli()`) : code;
+    return `${operand(left, dateOperands[0])} ${node.operator} ${operand(right, dateOperands[1])}`;
+  }
+
+  /**
+   * 將 time 的整數結果轉為 number 對應的 Go 型別；isInt 表示結果已是 int
+   */
+  private dateNumber(code: string, isInt: boolean = false): string {
+    if (this.options.numberStrategy === 'int') {
+      return isInt ? code : `int(${code})`;
+    }
+    return `float64(${code})`;
+  }
+
+  // ============= Map / Set =============
+
+  /**
+   * Map / Set 依 mapStrategy 對應 runtime.OrderedMap / OrderedSet（ordered）或 Go map（native）；
+   * WeakMap / WeakSet 不可走訪，一律為 Go map
+   */
+  private isOrderedCollection(info: CollectionInfo): boolean {
+    return this.options.mapStrategy !== 'native' && !info.weak;
+  }
+
+  /**
+   * Map / Set 的型別：Map<K, V> → *runtime.OrderedMap[K, V] 或 map[K]V，Set<T> → *runtime.OrderedSet[T] 或 map[T]bool
+   */
+  private collectionType(info: CollectionInfo, typeArguments: string[]): string {
+    const [keyType = 'interface{}', valueType = 'interface{}'] = typeArguments;
+    if (info.kind === 'set') {
+      return this.isOrderedCollection(info) ? `*${this.runtimeRef('OrderedSet')}[${keyType}]` : `map[${keyType}]bool`;
+    }
+    return this.isOrderedCollection(info) ?
+      `*${this.runtimeRef('OrderedMap')}[${keyType}, ${valueType}]` :
+      `map[${keyType}]${valueType}`;
+  }
+
+  /**
+   * new Map(...) / new Set(...)：
+   *   new Map()             → runtime.NewOrderedMap[K, V]()            / make(map[K]V)
+   *   new Map([[k, v]])     → runtime.NewOrderedMap[K, V]().Set(k, v)  / map[K]V{k: v}
+   *   new Map(entries)      → runtime.OrderedMapFrom(entries, pair)    / runtime.MapFrom(entries, pair)
+   *   new Set(values)       → runtime.NewOrderedSet[T](values...)      / runtime.SetOf[T](values...)
+   *   new Map(map)          → map.Clone()                              / maps.Clone(map)
+   */
+  private generateCollectionConstructor(node: ir.NewExpression, info: CollectionConstructorInfo): string {
+    const ordered = this.isOrderedCollection(info);
+    const [keyType = 'interface{}', valueType = 'interface{}'] = info.typeArguments.map(t => t.accept(this));
+    const typeArgs = info.kind === 'map' ? `${keyType}, ${valueType}` : keyType;
+    const arg = node.args[0];
+
+    if (info.source === 'copy') {
+      if (ordered) {
+        return `${arg.accept(this)}.Clone()`;
+      }
+      this.addImport('maps');
+      return `maps.Clone(${arg.accept(this)})`;
+    }
+
+    if (info.kind === 'set') {
+      const values = info.source === 'empty' ? '' :
+        info.source === 'literal' ? (arg as ir.ArrayExpression).elements.map(e => e ? e.accept(this) : 'nil').join(', ') :
+        `${arg.accept(this)}...`;
+      if (ordered) {
+        return `${this.runtimeRef('NewOrderedSet')}[${typeArgs}](${values})`;
+      }
+      return values ? `${this.runtimeRef('SetOf')}[${typeArgs}](${values})` : `make(map[${keyType}]bool)`;
+    }
+
+    if (info.source === 'literal') {
+      const pairs = (arg as ir.ArrayExpression).elements.map(e => (e as ir.ArrayExpression).elements.map(x => x!.accept(this)));
+      if (ordered) {
+        return `${this.runtimeRef('NewOrderedMap')}[${typeArgs}]()` + pairs.map(([k, v]) => `.Set(${k}, ${v})`).join('');
+      }
+      return `map[${keyType}]${valueType}{${pairs.map(([k, v]) => `${k}: ${v}`).join(', ')}}`;
+    }
+
+    if (info.source === 'empty') {
+      return ordered ? `${this.runtimeRef('NewOrderedMap')}[${typeArgs}]()` : `make(map[${keyType}]${valueType})`;
+    }
+
+    // [key, value] 元素為 tuple struct，以 pair 函式取出鍵值
+    const entryType = info.entryType ? info.entryType.accept(this) : 'interface{}';
+    const pair = `func(entry ${entryType}) (${keyType}, ${valueType}) { return entry.Item0, entry.Item1 }`;
+    return `${this.runtimeRef(ordered ? 'OrderedMapFrom' : 'MapFrom')}(${arg.accept(this)}, ${pair})`;
+  }
+
+  /**
+   * Map / Set 的方法：ordered 為 OrderedMap / OrderedSet 的同名方法，native 為 Go map 的操作
+   * keys() / values() / entries() 回傳依走訪順序的 slice
+   */
+  private generateCollectionMethod(node: ir.CallExpression, receiver: ir.Expression, info: CollectionMethodInfo): string {
+    const target = receiver.accept(this);
+    const args = node.args.map(arg => arg.accept(this));
+
+    if (info.method === 'keys' || info.method === 'values' || info.method === 'entries') {
+      return this.collectionSlice(target, { ...info, iterate: info.kind === 'set' ? 'values' : info.method });
+    }
+
+    if (this.isOrderedCollection(info)) {
+      if (info.method === 'forEach') {
+        // Map 的回呼只接收值時改用 ForEachValue
+        const name = info.kind === 'map' && info.callbackArity < 2 ? 'ForEachValue' : 'ForEach';
+        return `${target}.${name}(${args[0]})`;
+      }
+      return `${target}.${this.capitalize(info.method)}(${args.join(', ')})`;
+    }
+
+    switch (info.method) {
+      case 'get':
+        return `${target}[${args[0]}]`;
+      case 'set':
+        return `${target}[${args[0]}] = ${args[1]}`;
+      case 'add':
+        return `${target}[${args[0]}] = true`;
+      case 'has':
+        // Go map set 只存放 true
+        return info.kind === 'set' ? `${target}[${args[0]}]` : `${this.runtimeRef('HasKey')}(${target}, ${args[0]})`;
+      case 'delete':
+        return `delete(${target}, ${args[0]})`;
+      case 'clear':
+        return `clear(${target})`;
+      case 'forEach': {
+        const name = info.kind === 'set' ? 'SetForEach' : info.callbackArity < 2 ? 'MapForEachValue' : 'MapForEach';
+        return `${this.runtimeRef(name)}(${target}, ${args[0]})`;
+      }
+      default:
+        return `${target}.${this.capitalize(info.method)}(${args.join(', ')})`;
+    }
+  }
+
+  /**
+   * map.get(key) ?? fallback → GetOr；map.get(key) || 0 等以零值為預設值的寫法即為 Get
+   */
+  private generateCollectionLookup(node: ir.BinaryExpression): string | null {
+    if ((node.operator !== '??' && node.operator !== '||') || !(node.left instanceof ir.CallExpression) ||
+        !(node.left.callee instanceof ir.MemberExpression)) {
+      return null;
+    }
+    const info = node.left.metadata.get('collectionMethod') as CollectionMethodInfo | undefined;
+    if (!info || info.kind !== 'map' || info.method !== 'get') return null;
+
+    if (node.operator === '||') {
+      const zero = node.right instanceof ir.Literal && (node.right.value === 0 || node.right.value === '' || node.right.value === false);
+      return zero ? node.left.accept(this) : null;
+    }
+
+    const target = node.left.callee.object.accept(this);
+    const key = node.left.args[0].accept(this);
+    const fallback = node.right.accept(this);
+    return this.isOrderedCollection(info) ?
+      `${target}.GetOr(${key}, ${fallback})` :
+      `${this.runtimeRef('GetOr')}(${target}, ${key}, ${fallback})`;
+  }
+
+  /**
+   * 依走訪順序取得集合內容的 slice（[...map.keys()]、[...set]）
+   */
+  private collectionSlice(target: string, info: CollectionIterationInfo): string {
+    if (this.isOrderedCollection(info)) {
+      return `${target}.${this.capitalize(info.iterate)}()`;
+    }
+    // Go map set 的值即為 map 的 key
+    const helper = info.kind === 'set' || info.iterate === 'keys' ? 'MapKeys' :
+      info.iterate === 'values' ? 'MapValues' : 'MapEntries';
+    return `${this.runtimeRef(helper)}(${target})`;
+  }
+
+  /**
+   * for...of 走訪 Map / Set：
+   *   ordered → for key, value := range m.All()（Go 1.23 前以 Keys() / Values() 的 slice 走訪）
+   *   native  → for key, value := range m
+   * 本體未使用的變數以 _ 代替
+   */
+  private generateCollectionLoop(node: ir.ForOfStatement, info: CollectionIterationInfo): string {
+    const source = node.right.accept(this);
+
+    // for (const entry of map)：entry 為 runtime.Entry
+    if (info.iterate === 'entries' && !info.bindings) {
+      return `for _, ${node.left.name} := range ${this.collectionSlice(source, info)} ${node.body.accept(this)}`;
+    }
+
+    const [keyName, valueName] = info.iterate === 'entries' ? info.bindings! :
+      info.kind === 'map' && info.iterate === 'keys' ? [node.left.name, undefined] :
+      [undefined, node.left.name];
+    const ordered = this.isOrderedCollection(info);
+    const snapshot = ordered && !this.supportsRangeOverFunc();
+
+    let usesKey = false;
+    let usesValue = false;
+    const body = this.generateLoopBody(node.body, code => {
+      const uses = (name?: string) => !!name && new RegExp(`\\b${name}\\b`).test(code);
+      usesKey = uses(keyName);
+      usesValue = uses(valueName);
+      // Keys() 的 slice 走訪時以 Get 取得值
+      return snapshot && usesKey && usesValue ? [`${valueName} := ${source}.Get(${keyName})`] : [];
+    });
+
+    const range = (vars: string[], collection: string): string => {
+      while (vars.length > 0 && vars[vars.length - 1] === '_') vars.pop();
+      return vars.length > 0 ? `for ${vars.join(', ')} := range ${collection} ${body}` : `for range ${collection} ${body}`;
+    };
+
+    // Set 的值對應 Go map 與 OrderedSet.All() 的第一個變數
+    if (info.kind === 'set') {
+      const value = usesValue ? valueName! : '_';
+      return snapshot ?
+        range(['_', value], `${source}.Values()`) :
+        range([value], ordered ? `${source}.All()` : source);
+    }
+
+    if (snapshot) {
+      return usesKey ?
+        range(['_', keyName!], `${source}.Keys()`) :
+        range(['_', usesValue ? valueName! : '_'], `${source}.Values()`);
+    }
+    return range([usesKey ? keyName! : '_', usesValue ? valueName! : '_'], ordered ? `${source}.All()` : source);
+  }
+
+  // ============= Object =============
+
+  /**
+   * Object 的靜態方法：
+   *   Object.keys(m)        → runtime.SortedKeys(m)（ordered）/ runtime.MapKeys(m)（native）
+   *   Object.keys(s)        → []string{"id", "name"} / runtime.EntryKeys(userEntries(s))
+   *   Object.entries(s)     → userEntries(s)，依宣告順序列出 struct 欄位的產生函式
+   *   Object.assign(m, ...) → runtime.Assign(m, ...)
+   *   Object.assign(s, ...) → 逐一複製欄位的陳述式，值為 s
+   *   Object.fromEntries(…) → map[string]V
+   *   Object.freeze(x)      → x
+   */
+  private generateObjectMethod(node: ir.CallExpression, info: ObjectMethodInfo): string {
+    switch (info.method) {
+      case 'freeze':
+        // Go 無法凍結值，之後修改凍結物件的位置由 IRTransformer 以 W4006 警告
+        return node.args[0].accept(this);
+      case 'fromEntries':
+        return this.generateFromEntries(node, info);
+      case 'assign':
+        return info.shape!.kind === 'map' ? this.generateMapAssign(node, info) : this.generateStructAssign(node, info);
+      default:
+        return this.generateObjectEntries(node.args[0].accept(this), info.method, info.shape!);
+    }
+  }
+
+  /**
+   * Object.keys / values / entries：Go map 於 ordered 策略依鍵排序（Go map 不保留插入順序），native 為 Go map 的走訪順序；
+   * struct 依欄位宣告順序，未設定的可選欄位略過
+   */
+  private generateObjectEntries(target: string, method: string, shape: ObjectShape): string {
+    const part = method === 'keys' ? 'Keys' : method === 'values' ? 'Values' : 'Entries';
+    if (shape.kind === 'map') {
+      return `${this.runtimeRef((this.options.mapStrategy === 'native' ? 'Map' : 'Sorted') + part)}(${target})`;
+    }
+    if (shape.kind !== 'struct') {
+      return target;
+    }
+
+    // 沒有可選欄位時鍵名固定
+    if (method === 'keys' && !shape.fields.some(f => f.optional)) {
+      return `[]string{${shape.fields.map(f => goStringLiteral(f.name)).join(', ')}}`;
+    }
+    const entries = `${this.structEntriesFunction(shape)}(${target})`;
+    return method === 'entries' ? entries : `${this.runtimeRef('Entry' + part)}(${entries})`;
+  }
+
+  /**
+   * faithful 的 localeCompare 共用套件層級的 collate.Collator，不在每次呼叫時重建；回傳函式名稱
+   * Collator 不可並行使用，task / future 策略下可能由多個 goroutine 呼叫，因此以 mutex 保護
+   */
+  private localeCompareFunction(): string {
+    let name = 'localeCompare';
+    for (let i = 2; this.declaredNames.has(name); i++) {
+      name = `localeCompare${i}`;
+    }
+    if (this.objectHelpers.has(name)) {
+      return name;
+    }
+    this.addImport('sync');
+    this.addImport('golang.org/x/text/collate');
+    this.addImport('golang.org/x/text/language');
+
+    this.objectHelpers.set(name,
+      `var (\n` +
+      `\t${name}Collator = collate.New(language.Und)\n` +
+      `\t${name}Mu       sync.Mutex\n` +
+      `)\n\n` +
+      `// ${name} 依 Unicode 排序規則比較字串（String.prototype.localeCompare）\n` +
+      `func ${name}(a, b string) int {\n` +
+      `\t${name}Mu.Lock()\n` +
+      `\tdefer ${name}Mu.Unlock()\n` +
+      `\treturn ${name}Collator.CompareString(a, b)\n` +
+      `}`
+    );
+    return name;
+  }
+
+  /**
+   * 依宣告順序列出 struct 欄位的套件層級函式（不使用 reflect），同一型別共用；回傳函式名稱
+   * 欄位型別皆相同時值的型別即為該型別，否則為 interface{}；名稱與模組的宣告相同時加上序號
+   */
+  private structEntriesFunction(shape: { type: ir.TypeReference; fields: ObjectField[] }): string {
+    const typeName = shape.type.accept(this);
+    const base = `${typeName.charAt(0).toLowerCase()}${typeName.slice(1)}Entries`;
+    let name = base;
+    for (let i = 2; this.declaredNames.has(name); i++) {
+      name = `${base}${i}`;
+    }
+    if (this.objectHelpers.has(name)) {
+      return name;
+    }
+
+    const fieldTypes = shape.fields.map(f => f.type.accept(this));
+    const valueType = fieldTypes.length > 0 && fieldTypes.every(t => t === fieldTypes[0]) ? fieldTypes[0] : 'interface{}';
+    const entry = `${this.runtimeRef('Entry')}[string, ${valueType}]`;
+    const optional = shape.fields.some(f => f.optional);
+    const pair = (f: ObjectField) => `Key: ${goStringLiteral(f.name)}, Value: ${f.optional ? '*' : ''}v.${this.capitalize(f.name)}`;
+
+    let code = `// ${name} 依宣告順序列出 ${typeName} 的欄位（Object.keys / values / entries）` +
+      `${optional ? '，未設定的可選欄位略過' : ''}\n`;
+    code += `func ${name}(v ${typeName}) []${entry} {\n`;
+    if (!optional) {
+      code += `\treturn []${entry}{\n`;
+      code += shape.fields.map(f => `\t\t{${pair(f)}},\n`).join('');
+      code += '\t}\n';
+    } else {
+      code += `\tentries := make([]${entry}, 0, ${shape.fields.length})\n`;
+      for (const f of shape.fields) {
+        const append = `entries = append(entries, ${entry}{${pair(f)}})`;
+        code += f.optional ?
+          `\tif v.${this.capitalize(f.name)} != nil {\n\t\t${append}\n\t}\n` :
+          `\t${append}\n`;
+      }
+      code += '\treturn entries\n';
+    }
+    code += '}';
+
+    this.objectHelpers.set(name, code);
+    return name;
+  }
+
+  /**
+   * Object.assign 的目標為 Go map：runtime.Assign(target, sources...)，目標為 {} 時為新的 map；
+   * 物件字面量的來源轉為同型別的 map 字面量
+   */
+  private generateMapAssign(node: ir.CallExpression, info: ObjectMethodInfo): string {
+    const mapType = (info.shape as { type: ir.IRType }).type.accept(this);
+    const target = info.fresh ? `make(${mapType})` : node.args[0].accept(this);
+    const sources = node.args.slice(1).map(arg => arg instanceof ir.ObjectExpression ?
+      `${mapType}{${arg.properties.map(p => this.visitProperty(p)).join(', ')}}` :
+      arg.accept(this));
+    return `${this.runtimeRef('Assign')}(${[target, ...sources].join(', ')})`;
+  }
+
+  /**
+   * Object.assign 的目標為 struct：逐一複製同名欄位的陳述式提升至目前陳述式之前，運算式的值為目標。
+   * 可選欄位為指標，來源未設定的可選欄位不複製；目標為 {} 時以第一個來源的複本作為目標（declared 為宣告的變數名稱）。
+   * 套件層級的 var merged = func() Config { ... }() 於函式字面量中複製
+   */
+  private generateStructAssign(node: ir.CallExpression, info: ObjectMethodInfo, declared?: string): string {
+    // 套件層級不能有陳述式，複製欄位的陳述式包入立即呼叫的函式字面量
+    if (this.asyncFrames.length === 0) {
+      const typeName = (info.shape as { type: ir.IRType }).type.accept(this);
+      return this.inFunction(null, this.contextName, () => {
+        this.increaseIndent();
+        const target = this.generateStructAssign(node, info);
+        const lines = [...this.pendingStatements, `return ${target}`].map(line => `${this.indent()}${line}\n`).join('');
+        this.decreaseIndent();
+        return `func() ${typeName} {\n${lines}${this.indent()}}()`;
+      });
+    }
+
+    const fields = new Map((info.shape as { fields: ObjectField[] }).fields.map(f => [f.name, f]));
+    let args = node.args.slice(1);
+    let sources = info.sources!;
+
+    let target: string;
+    if (info.fresh) {
+      target = declared ?? (++this.awaitCounter === 1 ? 'merged' : `merged${this.awaitCounter}`);
+      this.pendingStatements.push(`${target} := ${args[0].accept(this)}`);
+      args = args.slice(1);
+      sources = sources.slice(1);
+    } else {
+      target = node.args[0].accept(this);
+    }
+
+    args.forEach((arg, i) => {
+      const source = sources[i];
+      if (arg instanceof ir.ObjectExpression) {
+        for (const prop of arg.properties) {
+          const name = prop.key instanceof ir.Identifier ? prop.key.name :
+            prop.key instanceof ir.Literal ? String(prop.key.value) : undefined;
+          const field = name !== undefined ? fields.get(name) : undefined;
+          if (!field) continue;
+          const value = prop.value.accept(this);
+          // 字面量需明確指定型別參數（16 於 *float64 欄位）
+          const typeArgs = prop.value instanceof ir.Literal ? `[${field.type.accept(this)}]` : '';
+          const wrapped = field.optional && value !== 'nil' ? `${this.runtimeRef('Ptr')}${typeArgs}(${value})` : value;
+          this.pendingStatements.push(`${target}.${this.capitalize(field.name)} = ${wrapped}`);
+        }
+        return;
+      }
+      if (source.kind !== 'struct') return;
+
+      // 來源以暫存變數求值一次
+      let value = arg.accept(this);
+      if (!(arg instanceof ir.Identifier)) {
+        const temp = ++this.awaitCounter === 1 ? 'source' : `source${this.awaitCounter}`;
+        this.pendingStatements.push(`${temp} := ${value}`);
+        value = temp;
+      }
+      for (const field of source.fields) {
+        const targetField = fields.get(field.name);
+        if (!targetField) continue;
+        const name = this.capitalize(field.name);
+        if (!field.optional) {
+          const copied = targetField.optional ? `${this.runtimeRef('Ptr')}(${value}.${name})` : `${value}.${name}`;
+          this.pendingStatements.push(`${target}.${name} = ${copied}`);
+          continue;
+        }
+        const copied = targetField.optional ? `${value}.${name}` : `*${value}.${name}`;
+        this.pendingStatements.push(
+          `if ${value}.${name} != nil {\n${this.indent()}\t${target}.${name} = ${copied}\n${this.indent()}}`
+        );
+      }
+    });
+    return target;
+  }
+
+  /**
+   * Object.fromEntries：
+   *   [[k, v], ...]        → map[string]V{k: v, ...}
+   *   Object.entries(x)    → runtime.FromEntries(entries)
+   *   map                  → runtime.FromEntries(map.Entries())（ordered）/ maps.Clone(map)（native）
+   *   [key, value] 陣列    → runtime.MapFrom(pairs, pair)
+   */
+  private generateFromEntries(node: ir.CallExpression, info: ObjectMethodInfo): string {
+    const arg = node.args[0];
+    const keyType = info.keyType!.accept(this);
+    const valueType = info.valueType!.accept(this);
+
+    switch (info.entriesSource) {
+      case 'literal': {
+        const pairs = (arg as ir.ArrayExpression).elements.map(e => (e as ir.ArrayExpression).elements.map(x => x!.accept(this)));
+        return `map[${keyType}]${valueType}{${pairs.map(([k, v]) => `${k}: ${v}`).join(', ')}}`;
+      }
+      case 'entries':
+        return `${this.runtimeRef('FromEntries')}(${arg.accept(this)})`;
+      case 'map':
+        if (this.options.mapStrategy === 'native') {
+          this.addImport('maps');
+          return `maps.Clone(${arg.accept(this)})`;
+        }
+        return `${this.runtimeRef('FromEntries')}(${arg.accept(this)}.Entries())`;
+      default: {
+        // [key, value] 元素為 tuple struct，以 pair 函式取出鍵值
+        const entryType = info.entryType ? info.entryType.accept(this) : 'interface{}';
+        const pair = `func(entry ${entryType}) (${keyType}, ${valueType}) { return entry.Item0, entry.Item1 }`;
+        return `${this.runtimeRef('MapFrom')}(${arg.accept(this)}, ${pair})`;
+      }
+    }
+  }
+
+  /**
+   * for (const [key, value] of Object.entries(x))：走訪 entries 的 slice，本體開頭取出 Key / Value；
+   * native 策略的 Go map 直接走訪 map。本體未使用的變數省略
+   */
+  private generateEntriesLoop(node: ir.ForOfStatement, bindings: (string | undefined)[]): string {
+    const [keyName, valueName] = bindings;
+    const info = node.right.metadata.get('objectMethod') as ObjectMethodInfo | undefined;
+    const direct = info?.shape?.kind === 'map' && this.options.mapStrategy === 'native';
+
+    let usesKey = false;
+    let usesValue = false;
+    const body = this.generateLoopBody(node.body, code => {
+      const uses = (name?: string) => !!name && new RegExp(`\\b${name}\\b`).test(code);
+      usesKey = uses(keyName);
+      usesValue = uses(valueName);
+      if (direct) return [];
+      return [
+        ...(usesKey ? [`${keyName} := ${node.left.name}.Key`] : []),
+        ...(usesValue ? [`${valueName} := ${node.left.name}.Value`] : [])
+      ];
+    });
+
+    if (direct) {
+      const vars = [usesKey ? keyName! : '_', usesValue ? valueName! : '_'];
+      while (vars.length > 0 && vars[vars.length - 1] === '_') vars.pop();
+      const source = (node.right as ir.CallExpression).args[0].accept(this);
+      return vars.length > 0 ? `for ${vars.join(', ')} := range ${source} ${body}` : `for range ${source} ${body}`;
+    }
+    const source = node.right.accept(this);
+    return usesKey || usesValue ? `for _, ${node.left.name} := range ${source} ${body}` : `for range ${source} ${body}`;
+  }
+
+  // ============= Optional Parameters =============
+
+  /**
+   * 參數是否帶有「是否傳入」的資訊：預設值參數，以及 pointer 空值策略下的可選參數
+   * （zero 策略下的可選參數沿用零值表示未傳入）
+   */
+  private carriesPresence(param: ir.Parameter): boolean {
+    if (param.rest) return false;
+    return !!param.defaultValue || (param.optional && this.options.nullabilityStrategy === 'pointer');
+  }
+
+  /**
+   * 結尾的可選參數達 optionalParamsStructThreshold 個時，改以 options struct 傳入
+   */
+  private usesOptionsStruct(params: ir.Parameter[]): boolean {
+    const threshold = this.options.optionalParamsStructThreshold ?? 4;
+    const first = params.findIndex(p => this.carriesPresence(p));
+    if (threshold <= 0 || first < 0) return false;
+    const trailing = params.slice(first);
+    return trailing.length >= threshold && trailing.every(p => this.carriesPresence(p));
+  }
+
+  private optionsStructName(name: string, owner?: string): string {
+    return `${owner ? this.capitalize(owner) : ''}${this.capitalize(name)}Options`;
+  }
+
+  private parameterType(param: ir.Parameter): string {
+    if (param.type) return param.type.accept(this) || 'interface{}';
+    if (param.defaultValue instanceof ir.Literal) {
+      switch (typeof param.defaultValue.value) {
+        case 'string': return 'string';
+        case 'boolean': return 'bool';
+        case 'number': return this.options.numberStrategy === 'int' ? 'int' : 'float64';
+      }
+    }
+    return 'interface{}';
+  }
+
+  /**
+   * 產生參數列；可選 / 預設值參數依 optionalParamStrategy 以 *T 或 runtime.OptionalValue[T] 傳入，
+   * 預設值於本體開頭套用，因此明確傳入的 0、"" 或 false 不會被預設值覆蓋：
+   *
+   *   func retry(maxRetriesArg *int) {
+   *       maxRetries := 3
+   *       if maxRetriesArg != nil {
+   *           maxRetries = *maxRetriesArg
+   *       }
+   */
+  private generateParameters(params: ir.Parameter[], name: string, owner?: string): GeneratedParameters {
+    const last = params[params.length - 1];
+    if (last && this.lowersOptionsObject(last)) {
+      const result = this.generateParameters(params.slice(0, -1), name, owner);
+      const options = this.generateOptionsParameter(last, name, owner);
+      result.params.push(options.param);
+      result.prelude.push(...options.prelude);
+      result.types += options.types;
+      return result;
+    }
+
+    const result: GeneratedParameters = { params: [], prelude: [], types: '' };
+
+    if (this.usesOptionsStruct(params)) {
+      const structName = this.optionsStructName(name, owner);
+      const fields: string[] = [];
+      for (const param of params) {
+        if (!this.carriesPresence(param)) {
+          result.params.push(this.visitParameter(param));
+          continue;
+        }
+        const type = this.parameterType(param);
+        const field = `opts.${this.capitalize(param.name)}`;
+        fields.push(`\t${this.capitalize(param.name)} *${type}`);
+        if (param.defaultValue) {
+          result.prelude.push(...this.applyDefault(param, type, field));
+        } else {
+          result.prelude.push(`${param.name} := ${field}`);
+        }
+      }
+      result.params.push(`opts ${structName}`);
+      result.types = `type ${structName} struct {\n${fields.join('\n')}\n}\n\n`;
+      return result;
+    }
+
+    return this.generatePresenceParameters(params, result);
+  }
+
+  /**
+   * 逐一產生參數，可選 / 預設值參數以 *T 或 runtime.OptionalValue[T] 傳入；
+   * 函式運算式與建構函式不併入 options struct，直接使用此方法
+   */
+  private generatePresenceParameters(
+    params: ir.Parameter[],
+    result: GeneratedParameters = { params: [], prelude: [], types: '' }
+  ): GeneratedParameters {
+    for (const param of params) {
+      if (!this.carriesPresence(param)) {
+        result.params.push(this.visitParameter(param));
+        continue;
+      }
+
+      const type = this.parameterType(param);
+      if (this.options.optionalParamStrategy === 'optional') {
+        const arg = `${param.name}Arg`;
+        result.params.push(`${arg} ${this.runtimeRef('OptionalValue')}[${type}]`);
+        if (param.defaultValue) {
+          result.prelude.push(
+            `${param.name}, ok := ${arg}.Get()`,
+            'if !ok {',
+            `\t${param.name} = ${param.defaultValue.accept(this)}`,
+            '}'
+          );
+        } else {
+          result.prelude.push(`${param.name} := ${arg}.Ptr()`);
+        }
+      } else if (param.defaultValue) {
+        result.params.push(`${param.name}Arg *${type}`);
+        result.prelude.push(...this.applyDefault(param, type, `${param.name}Arg`));
+      } else {
+        result.params.push(`${param.name} *${type}`);
+      }
+    }
+    return result;
+  }
+
+  /**
+   * 指標為 nil 時使用預設值；非字面量的預設值只在未傳入時求值，與 JS 相同
+   */
+  private applyDefault(param: ir.Parameter, type: string, source: string): string[] {
+    const defaultValue = param.defaultValue!.accept(this);
+    if (param.defaultValue instanceof ir.Literal) {
+      // 字面量的預設型別與參數型別不同時（float64、具名型別）需明確宣告
+      const value = param.defaultValue.value;
+      const literalType = typeof value === 'string' ? 'string' :
+        typeof value === 'boolean' ? 'bool' :
+        Number.isInteger(value) ? 'int' : 'float64';
+      const init = type === literalType || type === 'interface{}' ?
+        `${param.name} := ${defaultValue}` :
+        `var ${param.name} ${type} = ${defaultValue}`;
+      return [init, `if ${source} != nil {`, `\t${param.name} = *${source}`, '}'];
+    }
+    return [
+      `var ${param.name} ${type}`,
+      `if ${source} != nil {`,
+      `\t${param.name} = *${source}`,
+      '} else {',
+      `\t${param.name} = ${defaultValue}`,
+      '}'
+    ];
+  }
+
+  /**
+   * 在函式本體開頭插入 prelude 陳述式
+   */
+  private withPrelude(body: string, prelude: string[]): string {
+    if (prelude.length === 0 || !body.startsWith('{\n')) return body;
+    this.increaseIndent();
+    const lines = prelude.map(line => `${this.indent()}${line}\n`).join('');
+    this.decreaseIndent();
+    return `{\n${lines}${body.slice(2)}`;
+  }
+
+  /**
+   * 呼叫端引數：省略的可選參數傳入 nil / 空的 OptionalValue，傳入的值以 runtime.Ptr 等包裝
+   */
+  private generateArguments(node: ir.CallExpression | ir.NewExpression): string[] {
+    const info = node.metadata.get('optionalArgs') as OptionalArgsInfo | undefined;
+    if (!info || node.args.some(arg => arg instanceof ir.SpreadElement)) {
+      return node.args.map(arg => arg.accept(this));
+    }
+
+    let parameters = info.parameters;
+    const optionsParam = parameters[parameters.length - 1];
+    const lowersOptions = !info.inline && !!optionsParam && this.lowersOptionsObject(optionsParam);
+    if (lowersOptions) {
+      parameters = parameters.slice(0, -1);
+    }
+
+    const struct = !info.inline && this.usesOptionsStruct(parameters);
+    const result: string[] = [];
+    const fields: string[] = [];
+    parameters.forEach((param, i) => {
+      if (param.rest) {
+        result.push(...node.args.slice(i).map(arg => arg.accept(this)));
+        return;
+      }
+      const arg = node.args[i];
+      if (!this.carriesPresence(param)) {
+        if (arg) result.push(arg.accept(this));
+        return;
+      }
+
+      const absent = !arg || (arg instanceof ir.Identifier && arg.name === 'undefined');
+      const type = this.parameterType(param);
+      if (struct) {
+        if (!absent) {
+          fields.push(`${this.capitalize(param.name)}: ${this.presentArgument(arg, type, info.passThrough[i], 'pointer')}`);
+        }
+        return;
+      }
+
+      const strategy = this.options.optionalParamStrategy || 'pointer';
+      if (absent) {
+        result.push(strategy === 'optional' ? `${this.runtimeRef('NewEmptyOptional')}[${type}]()` : 'nil');
+      } else {
+        result.push(this.presentArgument(arg, type, info.passThrough[i], strategy));
+      }
+    });
+
+    if (struct) {
+      result.push(`${this.optionsStructName(info.name, info.owner)}{${fields.join(', ')}}`);
+    }
+    if (lowersOptions) {
+      result.push(...this.generateOptionsArgument(node.args[parameters.length], optionsParam, info.name, info.owner));
+    }
+    return result;
+  }
+
+  // ============= Options Objects =============
+
+  /**
+   * optionsObjectStrategy 開啟時，最後一個 options 物件參數改為 functional options 或參數 struct
+   */
+  private lowersOptionsObject(param: ir.Parameter): boolean {
+    const strategy = this.options.optionsObjectStrategy;
+    return !!param.metadata.get('optionsObject') && (strategy === 'struct' || strategy === 'functional');
+  }
+
+  /**
+   * options 物件對應的 struct；具名的 interface 沿用其名稱，型別字面量產生 XxxOptions
+   */
+  private optionsObjectType(param: ir.Parameter, name: string, owner?: string): { typeName: string; decl: string } {
+    if (param.type instanceof ir.TypeReference) {
+      return { typeName: param.type.accept(this), decl: '' };
+    }
+
+    const info = param.metadata.get('optionsObject') as OptionsObjectInfo;
+    const typeName = this.optionsStructName(name, owner);
+    const fields = info.properties.map(prop => {
+      const type = prop.type?.accept(this) || 'interface{}';
+      return `\t${this.capitalize(prop.name)} ${prop.optional ? '*' : ''}${type}`;
+    });
+    return { typeName, decl: `type ${typeName} struct {\n${fields.join('\n')}\n}\n\n` };
+  }
+
+  /**
+   * functional options 的型別名稱：RequestOptions → RequestOption
+   */
+  private optionFuncName(typeName: string): string {
+    return typeName.endsWith('Options') ? typeName.slice(0, -1) : `${typeName}Option`;
+  }
+
+  /**
+   * WithTimeout 等設定函式的名稱，由 OptionSetterPass 決定（不同 options 型別的同名屬性為 WithRequestTimeout）
+   */
+  private optionSetterName(info: OptionsObjectInfo, property: string): string {
+    return info.setters?.[property] || `With${this.capitalize(property)}`;
+  }
+
+  /**
+   * options 物件參數：
+   *   struct:     func fetch(url string, options RequestOptions)
+   *   functional: func fetch(url string, opts ...RequestOption)，每個屬性產生 WithXxx 設定函式
+   * 本體開頭套用預設值，並取出解構參數綁定的區域變數
+   */
+  private generateOptionsParameter(
+    param: ir.Parameter,
+    name: string,
+    owner?: string
+  ): { param: string; prelude: string[]; types: string } {
+    const info = param.metadata.get('optionsObject') as OptionsObjectInfo;
+    const { typeName, decl } = this.optionsObjectType(param, name, owner);
+    const prelude: string[] = [];
+    let types = decl;
+    let goParam: string;
+
+    if (this.options.optionsObjectStrategy === 'functional') {
+      const optionFunc = this.optionFuncName(typeName);
+      const variadic = param.name === 'opts' ? 'optFns' : 'opts';
+      goParam = `${variadic} ...${optionFunc}`;
+      prelude.push(
+        `${param.name} := ${typeName}{}`,
+        `for _, opt := range ${variadic} {`,
+        `\topt(&${param.name})`,
+        '}'
+      );
+      types += this.generateOptionSetters(typeName, info);
+    } else {
+      goParam = `${param.name} ${typeName}`;
+    }
+
+    // 預設值記錄於函式文件
+    const defaults = info.properties.filter(prop => prop.optional && prop.defaultValue);
+    if (defaults.length > 0) {
+      const list = defaults.map(prop => `${this.capitalize(prop.name)} = ${prop.defaultValue!.accept(this)}`).join(', ');
+      types += `// ${param.name} 未設定的欄位使用預設值：${list}\n`;
+    }
+
+    const bound = new Map((info.bindings || []).map(b => [b.property, b.name]));
+    for (const prop of info.properties) {
+      const field = `${param.name}.${this.capitalize(prop.name)}`;
+      const local = bound.get(prop.name);
+      const type = prop.type?.accept(this) || 'interface{}';
+      if (local && prop.optional && prop.defaultValue) {
+        prelude.push(...this.applyDefault(new ir.Parameter(local, prop.type, true, prop.defaultValue), type, field));
+      } else if (local) {
+        prelude.push(`${local} := ${field}`);
+      } else if (prop.optional && prop.defaultValue) {
+        prelude.push(
+          `if ${field} == nil {`,
+          `\t${field} = ${this.pointerTo(prop.defaultValue, type)}`,
+          '}'
+        );
+      }
+    }
+    return { param: goParam, prelude, types };
+  }
+
+  /**
+   * options 型別的 functional option 與各屬性的 WithXxx 設定函式，
+   * 僅由 OptionSetterPass 標記 declaresSetters 的宣告產生（每個型別只產生一次）
+   */
+  private generateOptionSetters(typeName: string, info: OptionsObjectInfo): string {
+    if (info.declaresSetters === false) return '';
+
+    const optionFunc = this.optionFuncName(typeName);
+    let result = `// ${optionFunc} 設定 ${typeName}\n`;
+    result += `type ${optionFunc} func(*${typeName})\n\n`;
+    for (const prop of info.properties) {
+      const setter = this.optionSetterName(info, prop.name);
+      const field = this.capitalize(prop.name);
+      const type = prop.type?.accept(this) || 'interface{}';
+      result += `// ${setter} 設定 ${typeName}.${field}\n`;
+      result += `func ${setter}(v ${type}) ${optionFunc} {\n`;
+      result += `\treturn func(o *${typeName}) {\n`;
+      result += `\t\to.${field} = ${prop.optional ? '&v' : 'v'}\n`;
+      result += '\t}\n';
+      result += '}\n\n';
+    }
+    return result;
+  }
+
+  /**
+   * 呼叫端的 options 物件：物件字面量展開為 struct 字面量或 WithXxx(...) 引數，
+   * 其他運算式整體傳入
+   */
+  private generateOptionsArgument(arg: ir.Expression | undefined, param: ir.Parameter, name: string, owner?: string): string[] {
+    const info = param.metadata.get('optionsObject') as OptionsObjectInfo;
+    const { typeName } = this.optionsObjectType(param, name, owner);
+    const functional = this.options.optionsObjectStrategy === 'functional';
+
+    if (!arg || (arg instanceof ir.Identifier && arg.name === 'undefined')) {
+      return functional ? [] : [`${typeName}{}`];
+    }
+
+    const props = arg instanceof ir.ObjectExpression ? arg.properties : null;
+    const literal = props && props.every(p => !p.computed && (p.key instanceof ir.Identifier || p.key instanceof ir.Literal));
+    if (!props || !literal) {
+      const code = arg.accept(this);
+      return functional ? [`func(o *${typeName}) { *o = ${code} }`] : [code];
+    }
+
+    const values = props.map(p => {
+      const key = p.key instanceof ir.Identifier ? p.key.name : String((p.key as ir.Literal).value);
+      const prop = info.properties.find(candidate => candidate.name === key);
+      return { key, prop, value: p.value };
+    });
+
+    if (functional) {
+      return values.map(({ key, value }) => `${this.optionSetterName(info, key)}(${value.accept(this)})`);
+    }
+
+    const fields = values.map(({ key, prop, value }) => {
+      const type = prop?.type?.accept(this) || 'interface{}';
+      const code = prop?.optional ? this.pointerTo(value, type) : value.accept(this);
+      return `${this.capitalize(key)}: ${code}`;
+    });
+    return [`${typeName}{${fields.join(', ')}}`];
+  }
+
+  /**
+   * 以 runtime.Ptr 取得值的指標；字面量需明確指定型別參數
+   */
+  private pointerTo(value: ir.Expression, type: string): string {
+    const typeArgs = value instanceof ir.Literal ? `[${type}]` : '';
+    return `${this.runtimeRef('Ptr')}${typeArgs}(${value.accept(this)})`;
+  }
+
+  private presentArgument(arg: ir.Expression, type: string, passThrough: boolean, strategy: 'pointer' | 'optional'): string {
+    const code = arg.accept(this);
+    if (passThrough) {
+      // 引數本身可能為 undefined，已是 *T
+      return strategy === 'optional' ? `${this.runtimeRef('NewOptionalFromPtr')}(${code})` : code;
+    }
+    // 字面量需明確指定型別參數，否則 3 會被推斷為 int
+    const typeArgs = arg instanceof ir.Literal ? `[${type}]` : '';
+    return strategy === 'optional' ?
+      `${this.runtimeRef('NewOptional')}${typeArgs}(${code})` :
+      `${this.runtimeRef('Ptr')}${typeArgs}(${code})`;
+  }
+
+  // ============= Generators =============
+
+  /**
+   * Go 1.23 起以 range-over-func 迭代器表示 generator，之前的版本以 producer goroutine + channel 表示
+   */
+  private generatorKind(info: GeneratorInfo): GeneratorKind {
+    if (!this.supportsRangeOverFunc()) return 'stream';
+    return info.async ? 'seq2' : 'seq';
+  }
+
+  private generatorValueType(info: GeneratorInfo): string {
+    return (info.yieldType && info.yieldType.accept(this)) || 'interface{}';
+  }
+
+  /**
+   * function* → iter.Seq[T]、async function* → iter.Seq2[T, error]，或 *runtime.Stream[T]
+   */
+  private generatorReturnType(info: GeneratorInfo): string {
+    const valueType = this.generatorValueType(info);
+    switch (this.generatorKind(info)) {
+      case 'seq':
+        this.addImport('iter');
+        return `iter.Seq[${valueType}]`;
+      case 'seq2':
+        this.addImport('iter');
+        return `iter.Seq2[${valueType}, error]`;
+      default:
+        return `*${this.runtimeRef('Stream')}[${valueType}]`;
+    }
+  }
+
+  private isGeneratorFrame(frame: AsyncFrame): boolean {
+    return frame.kind === 'seq' || frame.kind === 'seq2' || frame.kind === 'stream';
+  }
+
+  /**
+   * generator 函式本體：
+   *   iter:   return func(yield func(T, error) bool) {...}
+   *   stream: return runtime.Generate(ctx, func(ctx context.Context, yield func(T) bool) error {...})
+   * yield 回傳 false（消費端提前結束或 ctx 取消）時 generator 即返回
+   */
+  private generateGeneratorBody(
+    body: ir.BlockStatement,
+    info: GeneratorInfo,
+    contextName: string,
+    prelude: string[] = []
+  ): string {
+    const kind = this.generatorKind(info);
+    const frame: AsyncFrame = { kind, resultType: this.generatorValueType(info) };
+
+    let result = '{\n';
+    this.increaseIndent();
+    for (const line of prelude) {
+      result += `${this.indent()}${line}\n`;
+    }
+
+    let producer: string;
+    if (kind === 'stream') {
+      this.addImport('context');
+      const parent = contextName || this.currentContext();
+      const fn = `func(ctx context.Context, yield func(${frame.resultType}) bool) error`;
+      producer = `${this.runtimeRef('Generate')}(${parent}, ${fn} ${this.generateFrameBlock(body, frame, 'ctx')})`;
+    } else {
+      const yieldParams = kind === 'seq2' ? `${frame.resultType}, error` : frame.resultType;
+      producer = `func(yield func(${yieldParams}) bool) ${this.generateFrameBlock(body, frame, contextName)}`;
+    }
+    result += `${this.indent()}return ${producer}\n`;
+
+    this.decreaseIndent();
+    result += `${this.indent()}}`;
+    return result;
+  }
+
+  visitYieldExpression(node: ir.YieldExpression): string {
+    const frame = this.currentAsyncFrame();
+    const value = node.argument ?
+      node.argument.accept(this) :
+      frame ? this.zeroValue(frame.resultType) : 'nil';
+    if (!frame || !this.isGeneratorFrame(frame)) {
+      return `yield(${value})`;
+    }
+
+    const stop = frame.kind === 'stream' ? 'return nil' : 'return';
+    const indent = this.indent();
+
+    // yield* 逐一轉交另一個 generator 或陣列的值
+    if (node.delegate) {
+      const source = node.metadata.get('iterator') as 'sync' | 'async' | undefined;
+      if (frame.kind === 'stream' && source) {
+        return `if err := ${value}.Each(yield); err != nil {\n${indent}\treturn err\n${indent}}`;
+      }
+      if (source === 'async') {
+        return `for v, err := range ${value} {\n${indent}\tif !yield(v, err) || err != nil {\n${indent}\t\treturn\n${indent}\t}\n${indent}}`;
+      }
+      const args = frame.kind === 'seq2' ? 'v, nil' : 'v';
+      const range = source ? `v := range ${value}` : `_, v := range ${value}`;
+      return `for ${range} {\n${indent}\tif !yield(${args}) {\n${indent}\t\t${stop}\n${indent}\t}\n${indent}}`;
+    }
+
+    const args = frame.kind === 'seq2' ? `${value}, nil` : value;
+    return `if !yield(${args}) {\n${indent}\t${stop}\n${indent}}`;
+  }
+
+  /**
+   * for...of / for await...of 走訪 generator：
+   *   iter:   for x, err := range gen(ctx) { if err != nil {...}; ... }
+   *   stream: stream := gen(ctx); for stream.Next() { x := stream.Value(); ... }; stream.Close()
+   * 提前 break 時 iter 由 yield 回傳 false 結束 generator，stream 由迴圈後的 Close 取消 producer；
+   * 本體中離開函式的 return / throw 之前亦先 Close（不使用 defer，外層迴圈中不會累積至函式結束）
+   */
+  private generateIteratorLoop(node: ir.ForOfStatement, isAsync: boolean): string {
+    const source = node.right.accept(this);
+    const name = node.left.name;
+    const frame = this.currentAsyncFrame();
+    const usesName = (code: string) => new RegExp(`\\b${name}\\b`).test(code);
+
+    if (this.generatorKind({ async: isAsync }) !== 'stream') {
+      const body = this.generateLoopBody(node.body, () => {
+        if (!isAsync) return [];
+        return [`if err != nil ${frame ? this.errorBlock(frame) : '{\n' + this.indent() + '\tpanic(err)\n' + this.indent() + '}'}`];
+      });
+      const binding = usesName(body) ? name : '_';
+      return isAsync ?
+        `for ${binding}, err := range ${source} ${body}` :
+        `for ${binding} := range ${source} ${body}`;
+    }
+
+    const stream = node.right instanceof ir.Identifier ? source :
+      ++this.awaitCounter === 1 ? 'stream' : `stream${this.awaitCounter}`;
+    this.openStreams.push(stream);
+    const body = this.generateLoopBody(node.body, code =>
+      usesName(code) ? [`${name} := ${stream}.Value()`] : []);
+    this.openStreams.pop();
+
+    const indent = this.indent();
+    let result = stream === source ? '' : `${stream} := ${source}\n${indent}`;
+    result += `for ${stream}.Next() ${body}`;
+    if (isAsync && frame) {
+      result += `\n${indent}if err := ${stream}.Close(); err != nil ${this.errorBlock(frame)}`;
+    } else {
+      result += `\n${indent}${stream}.Close()`;
+    }
+    return result;
+  }
+
+  /**
+   * 離開函式的陳述式之前，由內而外 Close 目前走訪中的 stream
+   */
+  private closeStreams(code: string): string {
+    if (this.openStreams.length === 0) return code;
+    const closes = [...this.openStreams].reverse().map(stream => `${stream}.Close()`);
+    return [...closes, code].join(`\n${this.indent()}`);
+  }
+
+  /**
+   * 迴圈本體；prelude 依本體程式碼產生置於最前的陳述式
+   */
+  private generateLoopBody(body: ir.Statement, prelude: (code: string) => string[]): string {
+    const statements = body instanceof ir.BlockStatement ? body.statements : [body];
+    this.increaseIndent();
+    let code = '';
+    for (const stmt of statements) {
+      const stmtCode = this.emitStatement(stmt);
+      if (stmtCode) {
+        code += `${this.indent()}${stmtCode}\n`;
+      }
+    }
+    const lines = prelude(code).map(line => `${this.indent()}${line}\n`).join('');
+    this.decreaseIndent();
+    return `{\n${lines}${code}${this.indent()}}`;
+  }
+
+  // ============= Timers =============
+
+  /**
+   * 計時器 API 依 timerStrategy 降階：
+   *   eventloop: runtime.SetTimeout / SetInterval / ClearTimer / QueueMicrotask（單執行緒事件迴圈）
+   *   goroutine: time.AfterFunc / runtime.NewIntervalTimer / t.Stop() / go fn()
+   */
+  private generateTimerCall(node: ir.CallExpression): string | null {
+    if (!(node.callee instanceof ir.Identifier) || node.args.length === 0) return null;
+    const goroutine = this.options.timerStrategy === 'goroutine';
+
+    switch (node.callee.name) {
+      case 'setTimeout':
+      case 'setInterval': {
+        const callback = this.generateTimerCallback(node.args[0], node.args.slice(2));
+        const delay = this.millisecondsToDuration(node.args[1] || new ir.Literal(0, '0'));
+        if (node.callee.name === 'setTimeout') {
+          // 延遲為 0 時 millisecondsToDuration 不會匯入 time，但 time.AfterFunc 仍需要
+          if (goroutine) this.addImport('time');
+          return goroutine ?
+            `time.AfterFunc(${delay}, ${callback})` :
+            `${this.runtimeRef('SetTimeout')}(${callback}, ${delay})`;
+        }
+        return goroutine ?
+          `${this.runtimeRef('NewIntervalTimer')}(${delay}, ${callback})` :
+          `${this.runtimeRef('SetInterval')}(${callback}, ${delay})`;
+      }
+      case 'clearTimeout':
+      case 'clearInterval': {
+        const timer = node.args[0].accept(this);
+        return goroutine ? `${timer}.Stop()` : `${this.runtimeRef('ClearTimer')}(${timer})`;
+      }
+      case 'queueMicrotask': {
+        const callback = this.generateTimerCallback(node.args[0], []);
+        return goroutine ? `go ${callback}()` : `${this.runtimeRef('QueueMicrotask')}(${callback})`;
+      }
+      default:
+        return null;
+    }
+  }
+
+  /**
+   * 計時器回呼轉為 func()；回呼的回傳值被忽略，額外參數於呼叫時傳入
+   */
+  private generateTimerCallback(callback: ir.Expression, args: ir.Expression[]): string {
+    if ((callback instanceof ir.ArrowFunctionExpression || callback instanceof ir.FunctionExpression) &&
+        !callback.isAsync && callback.parameters.length === 0) {
+      const body = callback.body instanceof ir.BlockStatement ?
+        callback.body :
+        new ir.BlockStatement([new ir.ExpressionStatement(callback.body)]);
+      return `func() ${this.inFunction(null, this.contextName, () => this.visitBlockStatement(body))}`;
+    }
+
+    const fn = callback.accept(this);
+    if (args.length === 0 && callback instanceof ir.Identifier) {
+      return fn;
+    }
+    const call = `${fn}(${args.map(arg => arg.accept(this)).join(', ')})`;
+    return `func() {\n${this.indent()}\t${call}\n${this.indent()}}`;
+  }
+
+  // ============= Async =============
+
+  /**
+   * 依 asyncStrategy 產生 async 函式的 Go 回傳型別
+   * sync/errgroup: (T, error)；future: *runtime.Future[T]
+   */
+  private asyncReturnType(resultType: string): string {
+    if (this.options.asyncStrategy === 'future') {
+      return `*${this.runtimeRef('Future')}[${resultType || 'struct{}'}]`;
+    }
+    return this.errorReturnType(resultType);
+  }
+
+  private errorReturnType(resultType: string): string {
+    return resultType ? `(${resultType}, error)` : 'error';
+  }
+
+  /**
+   * ErrorPropagationPass 標記 errorReturn 的同步函式改為回傳 (T, error)，本體以 sync 狀態產生；
+   * returnType 為原本的 Go 回傳型別，void 沒有結果
+   */
+  private errorReturnFrame(node: ir.FunctionDeclaration | ir.MethodMember, returnType: string): AsyncFrame | null {
+    if (!node.metadata.get('errorReturn')) return null;
+    const isVoid = !node.returnType ||
+      (node.returnType instanceof ir.PrimitiveType && (node.returnType.kind === 'void' || node.returnType.kind === 'never'));
+    return { kind: 'sync', resultType: isVoid ? '' : returnType };
+  }
+
+  /**
+   * 回傳 error 的 void 函式於結尾補上 return nil
+   */
+  private withErrorReturn(block: string, body: ir.BlockStatement, frame: AsyncFrame | null): string {
+    const last = body.statements[body.statements.length - 1];
+    if (!frame || frame.resultType || last instanceof ir.ReturnStatement || last instanceof ir.ThrowStatement) {
+      return block;
+    }
+    const close = block.lastIndexOf('\n');
+    this.increaseIndent();
+    const line = `${this.indent()}return nil`;
+    this.decreaseIndent();
+    return `${block.slice(0, close)}\n${line}${block.slice(close)}`;
+  }
+
+  /**
+   * async 函式的 Go 結果型別：Promise<T> 取 T，void 為空字串
+   */
+  private asyncResultType(type?: ir.IRType): string {
+    if (!type) return '';
+    if (type instanceof ir.TypeReference && type.name === 'Promise') {
+      return type.typeArguments && type.typeArguments.length === 1 ?
+        this.asyncResultType(type.typeArguments[0]) :
+        '';
+    }
+    if (type instanceof ir.PrimitiveType && (type.kind === 'void' || type.kind === 'never')) {
+      return '';
+    }
+    return type.accept(this);
+  }
+
+  /**
+   * 產生 async 函式本體
+   * future 策略以 runtime.NewFuture 包裹，其餘策略直接回傳 (T, error)
+   */
+  private generateAsyncBody(body: ir.BlockStatement, resultType: string, prelude: string[] = []): string {
+    if (this.options.asyncStrategy !== 'future') {
+      return this.generateFrameBlock(body, { kind: 'sync', resultType }, 'ctx', prelude);
+    }
+
+    let result = '{\n';
+    this.increaseIndent();
+    for (const line of prelude) {
+      result += `${this.indent()}${line}\n`;
+    }
+    const closure = this.generateFrameBlock(body, { kind: 'future', resultType }, 'ctx');
+    result += `${this.indent()}return ${this.runtimeRef('NewFuture')}(func() (${resultType || 'struct{}'}, error) ${closure})\n`;
+    this.decreaseIndent();
+    result += `${this.indent()}}`;
+    return result;
+  }
+
+  private generateAsyncFunctionExpression(
+    parameters: ir.Parameter[],
+    returnType: ir.IRType | undefined,
+    body: ir.BlockStatement | ir.Expression
+  ): string {
+    this.needsContext = true;
+    this.addImport('context');
+    const generated = this.generatePresenceParameters(parameters);
+    const params = ['ctx context.Context', ...generated.params].join(', ');
+    const resultType = this.asyncResultType(returnType);
+    const block = body instanceof ir.BlockStatement ? body : new ir.BlockStatement([new ir.ReturnStatement(body)]);
+    return `func(${params}) ${this.asyncReturnType(resultType)} ${this.generateAsyncBody(block, resultType, generated.prelude)}`;
+  }
+
+  /**
+   * 於指定的 async 狀態下產生區塊；未以 return/throw 結尾時補上隱含的 return
+   */
+  private generateFrameBlock(
+    body: ir.BlockStatement,
+    frame: AsyncFrame,
+    contextName: string,
+    prelude: string[] = []
+  ): string {
+    return this.inFunction(frame, contextName, () => {
+      let result = '{\n';
+      this.increaseIndent();
+      for (const line of prelude) {
+        result += `${this.indent()}${line}\n`;
+      }
+      for (const stmt of body.statements) {
+        const stmtCode = this.emitStatement(stmt);
+        if (stmtCode) {
+          result += `${this.indent()}${stmtCode}\n`;
+        }
+      }
+      const last = body.statements[body.statements.length - 1];
+      const terminated = last instanceof ir.ReturnStatement || last instanceof ir.ThrowStatement;
+      const generator = this.isGeneratorFrame(frame);
+      if (!terminated && (frame.kind === 'task' || frame.kind === 'stream' || (!frame.resultType && !generator))) {
+        result += `${this.indent()}${this.frameReturn(frame)}\n`;
+      }
+      this.decreaseIndent();
+      result += `${this.indent()}}`;
+      return result;
+    });
+  }
+
+  /**
+   * 進入函式本體：設定 async 狀態與可用的 ctx 名稱，結束後還原
+   */
+  private inFunction<T>(frame: AsyncFrame | null, contextName: string, fn: () => T): T {
+    const savedContext = this.contextName;
+    const savedPending = this.pendingStatements;
+    if (this.asyncFrames.length === 0) {
+      this.awaitCounter = 0;
+    }
+    const savedStreams = this.openStreams;
+    const savedCancels = this.openCancels;
+    this.asyncFrames.push(frame);
+    this.contextName = contextName;
+    this.pendingStatements = [];
+    this.openStreams = [];
+    this.openCancels = [];
+
+    const result = fn();
+
+    this.asyncFrames.pop();
+    this.contextName = savedContext;
+    this.pendingStatements = savedPending;
+    this.openStreams = savedStreams;
+    this.openCancels = savedCancels;
+    return result;
+  }
+
+  private currentAsyncFrame(): AsyncFrame | null {
+    return this.asyncFrames.length > 0 ? this.asyncFrames[this.asyncFrames.length - 1] : null;
+  }
+
+  /**
+   * 目前可用的 ctx；模組層級初始化為進入點，以 context.Background() 建立，
+   * 尚未傳入 ctx 的函式內（例如匯出給外部的回呼）以 context.TODO() 代替
+   */
+  private currentContext(): string {
+    if (this.contextName) return this.contextName;
+    this.addImport('context');
+    return this.asyncFrames.length === 0 ? 'context.Background()' : 'context.TODO()';
+  }
+
+  /**
+   * 函式本體可用的 ctx 名稱：由 ContextThreadingPass 加上 ctx 參數的函式，以及建立根 ctx 的 main
+   */
+  private functionContext(node: ir.IRNode): string {
+    return node.metadata.get('contextParam') || node.metadata.get('contextEntry') ? 'ctx' : '';
+  }
+
+  /**
+   * const controller = new AbortController() →
+   *   controllerCtx, controllerCancel := context.WithCancel(ctx)
+   * 併入 setTimeout 中止時改用 context.WithTimeout；
+   * 迴圈中的 controller（scoped）由 AbortControllerLowering 於離開區塊的路徑插入 cancel，不使用 defer
+   */
+  private generateAbortController(name: string, timeout?: ir.Expression, scoped?: boolean): string {
+    this.addImport('context');
+    const parent = this.currentContext();
+    const create = timeout ?
+      `context.WithTimeout(${parent}, ${this.millisecondsToDuration(timeout)})` :
+      `context.WithCancel(${parent})`;
+    const code = `${name}Ctx, ${name}Cancel := ${create}`;
+    if (scoped) {
+      this.openCancels.push(`${name}Cancel`);
+      return code;
     }
+    return `${code}\n${this.indent()}defer ${name}Cancel()`;
   }
 
-  visitAssignmentExpression(node: ir.AssignmentExpression): string {
-    const left = node.left.accept(this);
-    const right = node.right.accept(this);
+  /**
+   * AbortSignal 上的呼叫：
+   *   AbortSignal.timeout(ms)              → 提升的 context.WithTimeout
+   *   signal.throwIfAborted()              → if err := signal.Err(); err != nil {...}
+   *   signal.addEventListener('abort', fn) → context.AfterFunc(signal, fn)
+   */
+  private generateAbortSignalCall(node: ir.CallExpression): string | null {
+    if (!(node.callee instanceof ir.MemberExpression) || !(node.callee.property instanceof ir.Identifier)) {
+      return null;
+    }
+    const member = node.callee;
+    const method = (member.property as ir.Identifier).name;
 
-    return `${left} ${node.operator} ${right}`;
+    if (member.object instanceof ir.Identifier && member.object.name === 'AbortSignal' &&
+        method === 'timeout' && node.args.length === 1 && this.asyncFrames.length > 0) {
+      this.addImport('context');
+      const name = ++this.awaitCounter === 1 ? 'timeout' : `timeout${this.awaitCounter}`;
+      const duration = this.millisecondsToDuration(node.args[0]);
+      this.pendingStatements.push(
+        `${name}Ctx, ${name}Cancel := context.WithTimeout(${this.currentContext()}, ${duration})`,
+        `defer ${name}Cancel()`
+      );
+      return `${name}Ctx`;
+    }
+
+    if (!member.metadata.get('abortSignal')) {
+      return null;
+    }
+    const signal = member.object.accept(this);
+
+    const frame = this.currentAsyncFrame();
+    if (method === 'throwIfAborted' && frame) {
+      this.pendingStatements.push(`if err := ${signal}.Err(); err != nil ${this.errorBlock(frame)}`);
+      return '';
+    }
+
+    if (method === 'addEventListener' && node.args.length === 2 &&
+        node.args[0] instanceof ir.Literal && node.args[0].value === 'abort') {
+      this.addImport('context');
+      return `context.AfterFunc(${signal}, ${node.args[1].accept(this)})`;
+    }
+
+    return null;
   }
 
-  visitConditionalExpression(node: ir.ConditionalExpression): string {
-    const test = node.test.accept(this);
-    const consequent = node.consequent.accept(this);
-    const alternate = node.alternate.accept(this);
+  /**
+   * JS 的毫秒數 → time.Duration
+   */
+  private millisecondsToDuration(ms: ir.Expression): string {
+    if (ms instanceof ir.Literal && ms.value === 0) {
+      return '0';
+    }
+    this.addImport('time');
+    if (ms instanceof ir.Literal && typeof ms.value === 'number') {
+      return `${ms.value}*time.Millisecond`;
+    }
+    return `time.Duration(${ms.accept(this)})*time.Millisecond`;
+  }
 
-    // Go 沒有三元運算子，使用 IIFE
-    return `func() interface{} { if ${test} { return ${consequent} }; return ${alternate} }()`;
+  /**
+   * 產生陳述式，並將其中巢狀 await 提升出的陳述式置於其前
+   */
+  private emitStatement(stmt: ir.Statement, generate: () => string = () => stmt.accept(this)): string {
+    const saved = this.pendingStatements;
+    this.pendingStatements = [];
+    const code = generate();
+    const hoisted = this.pendingStatements;
+    this.pendingStatements = saved;
+    return [...hoisted, code].filter(Boolean).join(`\n${this.indent()}`);
   }
 
-  visitAwaitExpression(node: ir.AwaitExpression): string {
-    // await 轉換為同步呼叫 + error check
-    const arg = node.argument.accept(this);
+  /**
+   * 呼叫 ErrorPropagationPass 標記的函式：可回傳 error 時提升為 result, err := f(x) 與 error 檢查，
+   * 否則（main、閉包、套件層級）以 runtime.Must 取值，失敗時 panic
+   */
+  private generateErrorCall(node: ir.CallExpression, info: ErrorCallInfo): string {
+    const call = this.generateCall(node);
+    const frame = this.currentAsyncFrame();
+    if (info.void) {
+      this.pendingStatements.push(`if err := ${call}; err != nil ${frame ? this.errorBlock(frame) : this.panicBlock()}`);
+      return '';
+    }
+    if (!frame) {
+      return `${this.runtimeRef('Must')}(${call})`;
+    }
 
-    // 假設 await 的表達式返回 (value, error)
-    return arg; // 呼叫方處理 error
+    const callee = node.callee instanceof ir.MemberExpression ? node.callee.property : node.callee;
+    const base = `${callee instanceof ir.Identifier ? callee.name : 'call'}Result`;
+    const name = ++this.awaitCounter === 1 ? base : `${base}${this.awaitCounter}`;
+    this.pendingStatements.push(
+      `${name}, err := ${call}`,
+      `if err != nil ${this.errorBlock(frame)}`
+    );
+    return name;
+  }
+
+  /**
+   * sync 狀態下 return 的值本身回傳 (T, error) 時直接回傳該呼叫：
+   * new Date(str) 為 runtime.ParseDate(str)，以及結果型別相同的 errorCall
+   */
+  private errorResultCall(argument: ir.Expression, frame: AsyncFrame): string | null {
+    if (frame.kind !== 'sync' || !frame.resultType || this.options.errorHandling === 'panic') return null;
+    if (argument instanceof ir.NewExpression && argument.metadata.get('dateConstructor') === 'string' &&
+        frame.resultType === 'time.Time') {
+      return `${this.runtimeRef('ParseDate')}(${argument.args[0].accept(this)})`;
+    }
+    const info = argument instanceof ir.CallExpression ?
+      argument.metadata.get('errorCall') as ErrorCallInfo | undefined :
+      undefined;
+    if (info && !info.void && info.returnType?.accept(this) === frame.resultType) {
+      return this.generateCall(argument as ir.CallExpression);
+    }
+    return null;
+  }
+
+  private panicBlock(): string {
+    this.increaseIndent();
+    const body = `${this.indent()}panic(err)`;
+    this.decreaseIndent();
+    return `{\n${body}\n${this.indent()}}`;
+  }
+
+  /**
+   * 於目前 async 狀態下回傳值
+   */
+  private frameReturn(frame: AsyncFrame, value?: string): string {
+    switch (frame.kind) {
+      case 'seq':
+      case 'seq2':
+        return 'return';
+      case 'stream':
+        return 'return nil';
+      case 'task':
+        return value !== undefined && frame.target ?
+          `${frame.target} = ${value}\n${this.indent()}return nil` :
+          'return nil';
+      case 'future':
+        return `return ${value ?? 'struct{}{}'}, nil`;
+      default:
+        return frame.resultType ? `return ${value ?? this.zeroValue(frame.resultType)}, nil` : 'return nil';
+    }
+  }
+
+  /**
+   * 於目前 async 狀態下回傳 error
+   */
+  private frameError(frame: AsyncFrame, err: string): string {
+    switch (frame.kind) {
+      case 'seq':
+        return `panic(${err})`;
+      case 'seq2':
+        return `yield(${this.zeroValue(frame.resultType)}, ${err})\n${this.indent()}return`;
+      case 'stream':
+        return `return ${err}`;
+      case 'task':
+        return `return ${err}`;
+      case 'future':
+        return `return ${this.zeroValue(frame.resultType || 'struct{}')}, ${err}`;
+      default:
+        return frame.resultType ? `return ${this.zeroValue(frame.resultType)}, ${err}` : `return ${err}`;
+    }
+  }
+
+  private errorBlock(frame: AsyncFrame): string {
+    this.increaseIndent();
+    const cancels = [...this.openCancels].reverse().map(cancel => `${this.indent()}${cancel}()\n`).join('');
+    const body = `${cancels}${this.indent()}${this.frameError(frame, 'err')}`;
+    this.decreaseIndent();
+    return `{\n${body}\n${this.indent()}}`;
+  }
+
+  /**
+   * Go 型別的零值表達式
+   */
+  private zeroValue(goType: string): string {
+    if (goType === 'string') return '""';
+    if (goType === 'time.Time') return 'time.Time{}';
+    if (goType === 'bool') return 'false';
+    if (goType === 'struct{}') return 'struct{}{}';
+    if (/^(u?int(8|16|32|64)?|float(32|64)|byte|rune|uintptr)$/.test(goType)) return '0';
+    if (/^(\*|\[\]|map\[|func\(|chan |<-chan |interface\{|error$|any$)/.test(goType)) return 'nil';
+    return `*new(${goType})`;
+  }
+
+  /**
+   * 可於 Go 端等待的表達式：future 策略下所有 Promise 皆為 *runtime.Future，
+   * 其餘策略僅 async 函式呼叫回傳 (T, error)
+   */
+  private isGoAwaitable(node: ir.Expression): boolean {
+    if (this.options.asyncStrategy === 'future') return true;
+    return node instanceof ir.CallExpression &&
+      (!!node.metadata.get('asyncCall') || this.getTaskCombinator(node) !== null);
+  }
+
+  private isVoidAwait(node: ir.AwaitExpression): boolean {
+    const awaitedType = node.metadata.get('awaitedType') as ir.IRType | undefined;
+    return awaitedType instanceof ir.PrimitiveType && awaitedType.kind === 'void';
+  }
+
+  /**
+   * 等待 promise 的 Go 呼叫，結果為 (T, error) 或 error
+   */
+  private awaitCall(node: ir.Expression): string {
+    if (this.options.asyncStrategy !== 'future') {
+      return node.accept(this);
+    }
+    // 直接等待的組合子不需包成 Future
+    const taskCombinator = this.getTaskCombinator(node);
+    const lowered = taskCombinator && this.generateTaskCombinator(taskCombinator.call, taskCombinator.info);
+    return lowered || `${node.accept(this)}.Await()`;
+  }
+
+  /**
+   * await 降階為呼叫 + error 檢查；binding 為空時丟棄結果
+   */
+  private generateAwaitStatement(node: ir.AwaitExpression, frame: AsyncFrame, binding?: string): string {
+    const combinator = this.getErrgroupCombinator(node.argument);
+    if (combinator) {
+      const lowered = this.generateErrgroup(combinator.call, combinator.info, frame, binding);
+      if (lowered) return lowered;
+    }
+
+    if (!this.isGoAwaitable(node.argument)) {
+      const value = node.argument.accept(this);
+      return binding ? `${binding} := ${value}` : value;
+    }
+
+    const call = this.awaitCall(node.argument);
+    if (this.isVoidAwait(node)) {
+      const init = this.options.asyncStrategy === 'future' ? `_, err := ${call}` : `err := ${call}`;
+      return `if ${init}; err != nil ${this.errorBlock(frame)}`;
+    }
+    if (!binding) {
+      return `if _, err := ${call}; err != nil ${this.errorBlock(frame)}`;
+    }
+    return `${binding}, err := ${call}\n${this.indent()}if err != nil ${this.errorBlock(frame)}`;
+  }
+
+  /**
+   * async 函式內的 return；return 的值為 Promise 時等待其結果（Promise 攤平）
+   */
+  private generateAsyncReturn(argument: ir.Expression | undefined, frame: AsyncFrame): string {
+    // generator 的回傳值不會被 for...of 觀察到
+    if (!argument || this.isGeneratorFrame(frame)) {
+      return this.frameReturn(frame);
+    }
+    const errorResult = this.errorResultCall(argument, frame);
+    if (errorResult) {
+      return `return ${errorResult}`;
+    }
+
+    let promise: ir.Expression | null = null;
+    if (argument instanceof ir.AwaitExpression) {
+      promise = argument.argument;
+    } else if (argument.metadata.get('promiseValue')) {
+      promise = argument;
+    }
+    if (!promise) {
+      return this.frameReturn(frame, argument.accept(this));
+    }
+
+    const combinator = this.getErrgroupCombinator(promise);
+    if (combinator) {
+      const lowered = this.generateErrgroup(combinator.call, combinator.info, frame, 'results');
+      if (lowered) {
+        return `${lowered}\n${this.indent()}${this.frameReturn(frame, 'results')}`;
+      }
+    }
+
+    if (!this.isGoAwaitable(promise)) {
+      return this.frameReturn(frame, promise.accept(this));
+    }
+
+    const call = this.awaitCall(promise);
+    if (frame.kind !== 'task') {
+      return `return ${call}`;
+    }
+    if (!frame.target) {
+      return frame.resultType ? `_, err := ${call}\n${this.indent()}return err` : `return ${call}`;
+    }
+    return `result, err := ${call}\n${this.indent()}if err != nil ${this.errorBlock(frame)}\n` +
+      `${this.indent()}${frame.target} = result\n${this.indent()}return nil`;
+  }
+
+  /**
+   * future 策略：p.then(fn) → runtime.Then(p, fn)、p.catch(fn) → p.Catch(fn)、p.finally(fn) → p.Finally(fn)
+   */
+  private generatePromiseMethod(node: ir.CallExpression, callee: ir.MemberExpression, info: PromiseMethodInfo): string {
+    const receiver = callee.object.accept(this);
+    const valueType = this.asyncResultType(info.valueType) || 'struct{}';
+    const callback = node.args[0];
+
+    switch (info.method) {
+      case 'then': {
+        const resultType = this.asyncResultType(info.resultType) || 'struct{}';
+        return `${this.runtimeRef('Then')}(${receiver}, ${this.generatePromiseCallback(callback, valueType, resultType)})`;
+      }
+      case 'catch':
+        return `${receiver}.Catch(${this.generatePromiseCallback(callback, 'error', valueType)})`;
+      default:
+        return `${receiver}.Finally(${this.generateFinallyCallback(callback)})`;
+    }
+  }
+
+  /**
+   * then/catch 的回呼轉為 func(v T) (U, error)；回呼回傳 Promise 時等待其結果
+   */
+  private generatePromiseCallback(callback: ir.Expression | undefined, paramType: string, resultType: string): string {
+    if (callback instanceof ir.ArrowFunctionExpression || callback instanceof ir.FunctionExpression) {
+      const param = callback.parameters.length > 0 ? callback.parameters[0].name : '_';
+      const body = callback.body instanceof ir.BlockStatement ?
+        callback.body :
+        new ir.BlockStatement([new ir.ReturnStatement(callback.body)]);
+      const frame: AsyncFrame = { kind: 'future', resultType: resultType === 'struct{}' ? '' : resultType };
+      return `func(${param} ${paramType}) (${resultType}, error) ${this.generateFrameBlock(body, frame, this.contextName)}`;
+    }
+
+    // 具名函式：以其同步結果完成
+    const fn = callback ? callback.accept(this) : `func(${paramType}) ${resultType}`;
+    return `func(value ${paramType}) (${resultType}, error) {\n` +
+      `${this.indent()}\treturn ${fn}(value), nil\n${this.indent()}}`;
+  }
+
+  private generateFinallyCallback(callback: ir.Expression | undefined): string {
+    if (callback instanceof ir.ArrowFunctionExpression || callback instanceof ir.FunctionExpression) {
+      const body = callback.body instanceof ir.BlockStatement ?
+        callback.body :
+        new ir.BlockStatement([new ir.ExpressionStatement(callback.body)]);
+      return `func() ${this.inFunction(null, this.contextName, () => this.visitBlockStatement(body))}`;
+    }
+    return callback ? callback.accept(this) : 'func() {}';
+  }
+
+  /**
+   * future 策略：Promise.all/allSettled/race/any/resolve/reject → runtime 組合子
+   * 運算元為 async 呼叫時以結構化組合子包成 Future，使結果確定後能經由 ctx 取消其餘呼叫
+   */
+  private generateFutureCombinator(node: ir.CallExpression, info: PromiseCombinatorInfo): string | null {
+    const valueType = info.valueType ? this.asyncResultType(info.valueType) || 'struct{}' : null;
+    const source = node.args[0];
+
+    if (this.getTaskCombinator(node)) {
+      this.increaseIndent();
+      const lowered = this.generateTaskCombinator(node, info);
+      const body = `${this.indent()}return ${lowered}\n`;
+      this.decreaseIndent();
+      if (lowered) {
+        const resultType = this.taskCombinatorResultType(info.method, valueType!);
+        return `${this.runtimeRef('NewFuture')}(func() (${resultType}, error) {\n${body}${this.indent()}})`;
+      }
+    }
+
+    switch (info.method) {
+      case 'all':
+      case 'allSettled':
+      case 'race':
+      case 'any': {
+        if (!valueType || !source) return null;
+        const name = { all: 'All', allSettled: 'AllSettled', race: 'Race', any: 'Any' }[info.method];
+        if (source instanceof ir.ArrayExpression) {
+          const futures = source.elements.map(e => e ? e.accept(this) : 'nil');
+          const typeArgs = futures.length === 0 ? `[${valueType}]` : '';
+          return `${this.runtimeRef(name)}${typeArgs}(${futures.join(', ')})`;
+        }
+        return `${this.runtimeRef(name)}(${source.accept(this)}...)`;
+      }
+      case 'resolve':
+        return `${this.runtimeRef('Resolve')}(${source ? source.accept(this) : 'struct{}{}'})`;
+      case 'reject':
+        return `${this.runtimeRef('Reject')}[${valueType || 'interface{}'}](${source ? source.accept(this) : 'nil'})`;
+      default:
+        return null;
+    }
+  }
+
+  /**
+   * 可降階為 runtime.AllTasks/AllSettledTasks/RaceTasks/AnyTasks 的組合子呼叫：
+   * 運算元為 async 呼叫的陣列字面量，或 async 函式中的 xs.map(cb)
+   */
+  private getTaskCombinator(node: ir.Expression): { call: ir.CallExpression; info: PromiseCombinatorInfo } | null {
+    if (!(node instanceof ir.CallExpression)) return null;
+    const info = node.metadata.get('promiseCombinator') as PromiseCombinatorInfo | undefined;
+    if (!info || !info.valueType || !TASK_COMBINATORS[info.method]) return null;
+
+    const source = node.args[0];
+    if (this.getMapCallback(source)) {
+      // 工作清單以迴圈建立並提升至陳述式之前
+      return this.asyncFrames.length > 0 ? { call: node, info } : null;
+    }
+    if (source instanceof ir.ArrayExpression &&
+        source.elements.every(e => e && !(e instanceof ir.SpreadElement) && this.isGoAwaitable(e))) {
+      return { call: node, info };
+    }
+    return null;
+  }
+
+  /**
+   * xs.map(cb) 的回呼（至少一個參數）
+   */
+  private getMapCallback(node: ir.Expression | undefined): ir.ArrowFunctionExpression | ir.FunctionExpression | null {
+    if (node instanceof ir.CallExpression && node.callee instanceof ir.MemberExpression &&
+        node.callee.property instanceof ir.Identifier && node.callee.property.name === 'map' &&
+        (node.args[0] instanceof ir.ArrowFunctionExpression || node.args[0] instanceof ir.FunctionExpression) &&
+        node.args[0].parameters.length > 0) {
+      return node.args[0];
+    }
+    return null;
+  }
+
+  /**
+   * Promise.race([a(), b()]) → runtime.RaceTasks(ctx, func(ctx context.Context) (T, error) {...}, ...)
+   * 每個工作收到衍生的 ctx，結果確定後（race 的第一個結果、all 的第一個錯誤、any 的第一個成功）即取消其餘工作
+   */
+  private generateTaskCombinator(call: ir.CallExpression, info: PromiseCombinatorInfo): string | null {
+    const source = call.args[0];
+    const valueType = this.asyncResultType(info.valueType) || 'struct{}';
+    const name = this.runtimeRef(TASK_COMBINATORS[info.method]);
+    const outerContext = this.currentContext();
+    this.addImport('context');
+
+    const callback = this.getMapCallback(source);
+    if (callback && source instanceof ir.CallExpression && source.callee instanceof ir.MemberExpression) {
+      const tasks = this.generateTaskList(source.callee.object.accept(this), callback, valueType);
+      return `${name}(${outerContext}, ${tasks}...)`;
+    }
+
+    if (!(source instanceof ir.ArrayExpression)) return null;
+    const tasks = (source.elements as ir.Expression[]).map(element =>
+      this.generateTaskClosure(valueType, () => this.awaitCall(element)));
+    const typeArgs = tasks.length === 0 ? `[${valueType}]` : '';
+    return `${name}${typeArgs}(${[outerContext, ...tasks].join(', ')})`;
+  }
+
+  /**
+   * xs.map(cb) 的工作清單：
+   *   tasks := make([]runtime.Task[T], len(xs))
+   *   for i, x := range xs { tasks[i] = func(ctx context.Context) (T, error) {...} }
+   */
+  private generateTaskList(collection: string, callback: ir.ArrowFunctionExpression | ir.FunctionExpression, valueType: string): string {
+    const name = ++this.awaitCounter === 1 ? 'tasks' : `tasks${this.awaitCounter}`;
+    const index = callback.parameters.length > 1 ? callback.parameters[1].name : 'i';
+    const element = callback.parameters[0].name;
+    const indent = this.indent();
+
+    this.increaseIndent();
+    // 區塊本體的回呼直接作為工作閉包的本體，不再包一層立即呼叫的閉包
+    const closure = callback.body instanceof ir.BlockStatement ?
+      `func(ctx context.Context) (${valueType}, error) ` +
+        this.generateFrameBlock(callback.body, { kind: 'future', resultType: valueType === 'struct{}' ? '' : valueType }, 'ctx') :
+      this.generateTaskClosure(valueType, () => this.generateTaskCall(callback, valueType));
+    this.decreaseIndent();
+
+    const usedElement = new RegExp(`\\b${element}\\b`).test(closure) ? element : '_';
+    let code = `${name} := make([]${this.runtimeRef('Task')}[${valueType}], len(${collection}))\n`;
+    code += `${indent}for ${index}, ${usedElement} := range ${collection} {\n`;
+    if (!this.hasPerIterationLoopVars()) {
+      const captured = [index, usedElement].filter(v => v !== '_');
+      code += `${indent}\t${captured.join(', ')} := ${captured.join(', ')}\n`;
+    }
+    code += `${indent}\t${name}[${index}] = ${closure}\n`;
+    code += `${indent}}`;
+    this.pendingStatements.push(code);
+    return name;
+  }
+
+  /**
+   * 單一工作的閉包：func(ctx context.Context) (T, error) { return <call> }
+   * 閉包內以其 ctx 參數呼叫 async 函式
+   */
+  private generateTaskClosure(valueType: string, generateCall: () => string): string {
+    const savedContext = this.contextName;
+    this.contextName = 'ctx';
+    this.increaseIndent();
+    let code = generateCall();
+    this.decreaseIndent();
+    this.contextName = savedContext;
+
+    // sync/errgroup 策略下 void 的 async 呼叫只回傳 error
+    if (valueType === 'struct{}' && this.options.asyncStrategy !== 'future') {
+      code = `struct{}{}, ${code}`;
+    }
+    return `func(ctx context.Context) (${valueType}, error) {\n${this.indent()}\treturn ${code}\n${this.indent()}}`;
+  }
+
+  private taskCombinatorResultType(method: string, valueType: string): string {
+    if (method === 'all') return `[]${valueType}`;
+    if (method === 'allSettled') return `[]${this.runtimeRef('SettledResult')}[${valueType}]`;
+    return valueType;
+  }
+
+  /**
+   * errgroup 策略下可降階的 Promise.all/allSettled 呼叫
+   */
+  private getErrgroupCombinator(node: ir.Expression): { call: ir.CallExpression; info: PromiseCombinatorInfo } | null {
+    if (this.options.asyncStrategy !== 'errgroup' || !(node instanceof ir.CallExpression)) return null;
+    const info = node.metadata.get('promiseCombinator') as PromiseCombinatorInfo | undefined;
+    if (!info || (info.method !== 'all' && info.method !== 'allSettled')) return null;
+    return { call: node, info };
+  }
+
+  /**
+   * 將彼此獨立的 await 降階為 errgroup：
+   *   g, gctx := errgroup.WithContext(ctx)
+   *   results := make([]T, len(urls))
+   *   for i, url := range urls { g.Go(func() error { ... }) }
+   *   if err := g.Wait(); err != nil { ... }
+   * 支援 Promise.all(xs.map(fn)) 與 Promise.all([a(), b()])，其餘形式回傳 null
+   */
+  private generateErrgroup(
+    call: ir.CallExpression,
+    info: PromiseCombinatorInfo,
+    frame: AsyncFrame,
+    binding?: string
+  ): string | null {
+    const source = call.args[0];
+    if (!info.valueType || !source) return null;
+    const valueType = this.asyncResultType(info.valueType);
+    const allSettled = info.method === 'allSettled';
+    if (binding && !valueType && !allSettled) return null;
+
+    // 每個工作項目的 Go 呼叫（回傳 (T, error) 或 error）
+    let loop: { collection: string; element: string; index: string } | null = null;
+    let tasks: ir.Expression[];
+    if (source instanceof ir.CallExpression && source.callee instanceof ir.MemberExpression &&
+        source.callee.property instanceof ir.Identifier && source.callee.property.name === 'map' &&
+        (source.args[0] instanceof ir.ArrowFunctionExpression || source.args[0] instanceof ir.FunctionExpression) &&
+        source.args[0].parameters.length > 0) {
+      const callback = source.args[0];
+      loop = {
+        collection: source.callee.object.accept(this),
+        element: callback.parameters[0].name,
+        index: callback.parameters.length > 1 ? callback.parameters[1].name : 'i'
+      };
+      tasks = [callback];
+    } else if (source instanceof ir.ArrayExpression && source.elements.length > 0 &&
+               source.elements.every(e => e && !(e instanceof ir.SpreadElement) && this.isGoAwaitable(e))) {
+      tasks = source.elements as ir.Expression[];
+    } else {
+      return null;
+    }
+
+    const elementType = allSettled ?
+      `${this.runtimeRef('SettledResult')}[${valueType || 'struct{}'}]` :
+      valueType;
+    const outerContext = this.currentContext();
+    const savedContext = this.contextName;
+    this.contextName = 'gctx';
+    if (loop) this.increaseIndent();
+
+    const bodies = tasks.map((task, i) => {
+      const index = loop ? loop.index : String(i);
+      this.increaseIndent();
+      const code = task instanceof ir.ArrowFunctionExpression || task instanceof ir.FunctionExpression ?
+        this.generateTaskCall(task, valueType) :
+        task.accept(this);
+      this.decreaseIndent();
+      return this.generateTaskBody(code, binding ? `${binding}[${index}]` : null, valueType, allSettled);
+    });
+
+    if (loop) this.decreaseIndent();
+    this.contextName = savedContext;
+
+    this.addImport('golang.org/x/sync/errgroup');
+    const indent = this.indent();
+    const usesContext = bodies.some(body => /\bgctx\b/.test(body));
+    let result = usesContext ?
+      `g, gctx := errgroup.WithContext(${outerContext})\n` :
+      `var g errgroup.Group\n`;
+
+    if (binding) {
+      const length = loop ? `len(${loop.collection})` : String(tasks.length);
+      result += `${indent}${binding} := make([]${elementType}, ${length})\n`;
+    }
+
+    if (loop) {
+      const body = bodies[0];
+      const index = new RegExp(`\\b${loop.index}\\b`).test(body) ? loop.index : '_';
+      const element = new RegExp(`\\b${loop.element}\\b`).test(body) ? loop.element : '_';
+      result += `${indent}for ${index}, ${element} := range ${loop.collection} {\n`;
+      if (!this.hasPerIterationLoopVars()) {
+        const captured = [index, element].filter(v => v !== '_');
+        if (captured.length > 0) {
+          result += `${indent}\t${captured.join(', ')} := ${captured.join(', ')}\n`;
+        }
+      }
+      result += `${indent}\tg.Go(func() error ${body})\n`;
+      result += `${indent}}\n`;
+    } else {
+      for (const body of bodies) {
+        result += `${indent}g.Go(func() error ${body})\n`;
+      }
+    }
+
+    // allSettled 的工作不會失敗，僅需等待全部完成
+    result += allSettled ?
+      `${indent}g.Wait()` :
+      `${indent}if err := g.Wait(); err != nil ${this.errorBlock(frame)}`;
+    return result;
+  }
+
+  /**
+   * errgroup 工作項目的回呼轉為 Go 呼叫；回呼本體非單一 async 呼叫時以立即呼叫的閉包表示
+   */
+  private generateTaskCall(callback: ir.ArrowFunctionExpression | ir.FunctionExpression, valueType: string): string {
+    const body = callback.body;
+    if (!(body instanceof ir.BlockStatement) && this.isGoAwaitable(body)) {
+      return this.awaitCall(body);
+    }
+
+    const block = body instanceof ir.BlockStatement ? body : new ir.BlockStatement([new ir.ReturnStatement(body)]);
+    const returnType = valueType ? `(${valueType}, error)` : 'error';
+    return `func() ${returnType} ${this.generateFrameBlock(block, { kind: 'sync', resultType: valueType }, this.contextName)}()`;
+  }
+
+  /**
+   * g.Go 閉包本體：等待結果並寫入 results[i]
+   */
+  private generateTaskBody(code: string, target: string | null, valueType: string, allSettled: boolean): string {
+    const lines: string[] = [];
+    if (allSettled) {
+      const settle = this.runtimeRef('Settle');
+      if (!valueType) {
+        lines.push(target ? `${target} = ${settle}(struct{}{}, ${code})` : `_ = ${code}`);
+      } else if (target) {
+        lines.push(`value, err := ${code}`, `${target} = ${settle}(value, err)`);
+      } else {
+        lines.push(`_, _ = ${code}`);
+      }
+      lines.push('return nil');
+    } else if (!valueType) {
+      lines.push(`return ${code}`);
+    } else if (!target) {
+      lines.push(`_, err := ${code}`, 'return err');
+    } else {
+      lines.push(`result, err := ${code}`, 'if err != nil {', '\treturn err', '}', `${target} = result`, 'return nil');
+    }
+
+    this.increaseIndent();
+    const body = lines.map(line => `${this.indent()}${line}\n`).join('');
+    this.decreaseIndent();
+    return `{\n${body}${this.indent()}}`;
+  }
+
+  /**
+   * Go 1.22 起 for 迴圈變數於每次迭代重新宣告，閉包不需複製
+   */
+  private hasPerIterationLoopVars(): boolean {
+    const match = /^1\.(\d+)/.exec(this.options.goVersion || '1.22');
+    return !match || Number(match[1]) >= 22;
+  }
+
+  /**
+   * Go 1.23 起支援 range-over-func（iter.Seq）
+   */
+  private supportsRangeOverFunc(): boolean {
+    const match = /^1\.(\d+)/.exec(this.options.goVersion || '1.22');
+    return !match || Number(match[1]) >= 23;
+  }
+
+  /**
+   * 引用 runtime 套件中的識別字
+   */
+  private runtimeRef(name: string): string {
+    this.needsRuntime = true;
+    this.addImport(this.options.runtimeImportPath || 'ts2go/runtime');
+    return `runtime.${name}`;
   }
 
   visitSpreadElement(node: ir.SpreadElement): string {
+    const collection = node.metadata.get('collection') as CollectionIterationInfo | undefined;
+    if (collection) {
+      return `${this.collectionSlice(node.argument.accept(this), collection)}...`;
+    }
     return `${node.argument.accept(this)}...`;
   }
 
   visitTemplateLiteral(node: ir.TemplateLiteral): string {
     // Always use fmt.Sprintf for template literals to ensure consistency
-    this.addImport('fmt');
-
     // 構建 fmt.Sprintf 格式字串
     let format = '';
     const args: string[] = [];
 
     for (let i = 0; i < node.quasis.length; i++) {
-      format += node.quasis[i];
+      format += escapeFormatString(node.quasis[i]);
       if (i < node.expressions.length) {
         const expr = node.expressions[i];
         let arg = expr.accept(this);
@@ -2251,9 +6312,10 @@ export class GoCodeGenerator implements ir.IRVisitor<string> {
     }
 
     if (args.length === 0) {
-      return `"${format}"`;
+      return goStringLiteral(node.quasis.join(''));
     }
 
-    return `fmt.Sprintf("${format}", ${args.join(', ')})`;
+    this.addImport('fmt');
+    return `fmt.Sprintf(${goStringLiteral(format)}, ${args.join(', ')})`;
   }
 }
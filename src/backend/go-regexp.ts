@@ -0,0 +1,255 @@
+/**
+ * JavaScript 正規表達式轉換為 Go regexp（RE2）語法
+ * RE2 保證線性時間比對，不支援 lookaround 與 backreference；
+ * 無法表達的構造回報為 RegExpIssue，由 IRTransformer 轉為指向原始位置的診斷
+ */
+
+export interface RegExpIssue {
+  /** 於 pattern 中的位置（UTF-16 offset），旗標接在 pattern 與結尾的 / 之後 */
+  offset: number;
+  length: number;
+  message: string;
+  hint: string;
+}
+
+export interface GoRegExp {
+  /** Go regexp 語法的 pattern，i/m/s 旗標轉為開頭的 (?ims) */
+  source: string;
+  /** g 旗標：replace / match 作用於所有符合處 */
+  global: boolean;
+  issues: RegExpIssue[];
+}
+
+/** RE2 的重複次數上限 */
+const MAX_REPEAT = 1000;
+
+/** 語義與 Go 相同、可直接沿用的字母跳脫（\b 於字元類別中為 backspace，另行處理） */
+const LETTER_ESCAPES = new Set(['d', 'D', 'w', 'W', 'b', 'B', 'f', 'n', 'r', 't', 'v']);
+
+/**
+ * JS 的 \s：\t-\r（含 \v）、空白、U+00A0、U+1680、U+2000-U+200A、U+2028、U+2029、U+202F、U+205F、U+3000 與 U+FEFF。
+ * Go 的 \s 只有 [\t\n\f\r ]，因此 \s 與 \S 皆展開為明確的範圍
+ */
+const WHITESPACE: [number, number][] = [
+  [0x9, 0xd], [0x20, 0x20], [0xa0, 0xa0], [0x1680, 0x1680], [0x2000, 0x200a],
+  [0x2028, 0x2029], [0x202f, 0x202f], [0x205f, 0x205f], [0x3000, 0x3000], [0xfeff, 0xfeff]
+];
+const WHITESPACE_CLASS = classRanges(WHITESPACE);
+const NON_WHITESPACE_CLASS = classRanges(complementRanges(WHITESPACE));
+
+/** \p{Script=Greek}、\p{gc=Lu} 的屬性名稱，RE2 只接受值本身 */
+const PROPERTY_PREFIX = /^(Script|sc|General_Category|gc)=/;
+
+const BACKREFERENCE_HINT = 'Capture the group and compare it in code, or restructure the pattern';
+
+/**
+ * 轉換 pattern 與旗標；issues 非空時 source 仍盡量保留原意，但 Go 編譯時可能失敗
+ */
+export function translateRegExp(pattern: string, flags: string): GoRegExp {
+  const issues: RegExpIssue[] = [];
+  const report = (offset: number, length: number, message: string, hint: string): void => {
+    issues.push({ offset, length, message, hint });
+  };
+
+  let source = '';
+  let inClass = false;
+
+  for (let i = 0; i < pattern.length; i++) {
+    const c = pattern[i];
+
+    if (c === '\\') {
+      const [text, length] = translateEscape(pattern, i, inClass, report);
+      source += text;
+      i += length - 1;
+      continue;
+    }
+
+    if (inClass) {
+      if (c === ']') {
+        inClass = false;
+      }
+      // RE2 的字元類別中 [ 可能開始 [:alpha:]，跳脫以保持字面意義
+      source += c === '[' ? '\\[' : c;
+      continue;
+    }
+
+    if (c === '[') {
+      // JS 的 [^] 符合任意字元（含換行），[] 不符合任何字元；RE2 不接受空類別
+      if (pattern.startsWith('[^]', i)) {
+        source += '[\\x{0}-\\x{10FFFF}]';
+        i += 2;
+        continue;
+      }
+      if (pattern.startsWith('[]', i)) {
+        source += '[^\\x{0}-\\x{10FFFF}]';
+        i += 1;
+        continue;
+      }
+      inClass = true;
+      source += c;
+      if (pattern[i + 1] === '^') {
+        source += '^';
+        i++;
+      }
+      continue;
+    }
+
+    if (c === '(') {
+      if (pattern.startsWith('(?=', i) || pattern.startsWith('(?!', i)) {
+        report(i, 3, 'Lookahead assertions are not supported by Go regexp (RE2)',
+          'Match the following text in a capture group, or check the condition in code');
+      } else if (pattern.startsWith('(?<=', i) || pattern.startsWith('(?<!', i)) {
+        report(i, 4, 'Lookbehind assertions are not supported by Go regexp (RE2)',
+          'Match the preceding text in a capture group, or check the condition in code');
+      } else if (pattern.startsWith('(?<', i)) {
+        // 具名群組：(?P<name>) 於所有 Go 版本皆可用
+        source += '(?P<';
+        i += 2;
+        continue;
+      }
+      source += c;
+      continue;
+    }
+
+    if (c === '{') {
+      const repeat = /^\{(\d+)(?:,(\d*))?\}/.exec(pattern.slice(i));
+      if (repeat) {
+        const count = Math.max(Number(repeat[1]), Number(repeat[2] || 0));
+        if (count > MAX_REPEAT) {
+          report(i, repeat[0].length, `Repetition count ${count} exceeds the Go regexp (RE2) limit of ${MAX_REPEAT}`,
+            'Split the repetition into nested groups, or validate the length in code');
+        }
+      }
+    }
+
+    source += c;
+  }
+
+  let global = false;
+  let prefix = '';
+  for (let k = 0; k < flags.length; k++) {
+    const flag = flags[k];
+    if (flag === 'g') {
+      global = true;
+    } else if (flag === 'i' || flag === 'm' || flag === 's') {
+      prefix += flag;
+    } else if (flag === 'y') {
+      report(pattern.length + 1 + k, 1, 'The sticky flag (y) is not supported by Go regexp (RE2)',
+        'Anchor the pattern with ^ and match against the remaining input');
+    }
+    // u、v、d 不影響 Go 的比對（RE2 一律以 Unicode code point 比對）
+  }
+
+  return {
+    source: prefix ? `(?${prefix})${source}` : source,
+    global,
+    issues
+  };
+}
+
+/**
+ * 轉換 pattern[i] 開始的跳脫序列，回傳 Go 語法與消耗的長度
+ */
+function translateEscape(
+  pattern: string,
+  i: number,
+  inClass: boolean,
+  report: (offset: number, length: number, message: string, hint: string) => void
+): [string, number] {
+  const rest = pattern.slice(i + 1);
+  const c = rest[0];
+  if (c === undefined) return ['\\\\', 1];
+
+  // \1 … \99：字元類別外為反向參照，類別內為八進位跳脫
+  if (/[1-9]/.test(c)) {
+    if (!inClass) {
+      const digits = /^\d+/.exec(rest)![0];
+      report(i, 1 + digits.length, `Backreference \\${digits} is not supported by Go regexp (RE2)`, BACKREFERENCE_HINT);
+      return ['\\' + digits, 1 + digits.length];
+    }
+    const octal = /^[0-7]{1,3}/.exec(rest);
+    return octal ? [codePoint(parseInt(octal[0], 8)), 1 + octal[0].length] : [c, 2];
+  }
+
+  if (c === 'k' && rest[1] === '<') {
+    const end = rest.indexOf('>');
+    const length = end < 0 ? 2 : end + 2;
+    report(i, length, `Named backreference ${pattern.slice(i, i + length)} is not supported by Go regexp (RE2)`,
+      BACKREFERENCE_HINT);
+    return [pattern.slice(i, i + length), length];
+  }
+
+  if (c === '0' && !/^0\d/.test(rest)) return ['\\x00', 2];
+
+  if (c === 'u') {
+    const braced = /^u\{([0-9a-fA-F]+)\}/.exec(rest);
+    if (braced) return [codePoint(parseInt(braced[1], 16)), 1 + braced[0].length];
+
+    const unit = /^u([0-9a-fA-F]{4})/.exec(rest);
+    if (unit) {
+      const high = parseInt(unit[1], 16);
+      // 以兩個跳脫表示的 surrogate pair 合併為單一 code point
+      const low = /^\\u([dD][c-fC-F][0-9a-fA-F]{2})/.exec(rest.slice(5));
+      if (high >= 0xd800 && high <= 0xdbff && low) {
+        const cp = (high - 0xd800) * 0x400 + (parseInt(low[1], 16) - 0xdc00) + 0x10000;
+        return [codePoint(cp), 12];
+      }
+      return [codePoint(high), 6];
+    }
+    return ['u', 2];
+  }
+
+  if (c === 'x' && /^x[0-9a-fA-F]{2}/.test(rest)) return ['\\' + rest.slice(0, 3), 4];
+
+  if (c === 'c' && /^c[a-zA-Z]/.test(rest)) return [codePoint(rest.charCodeAt(1) % 32), 3];
+
+  if ((c === 'p' || c === 'P') && rest[1] === '{') {
+    const end = rest.indexOf('}');
+    if (end > 0) {
+      const name = rest.slice(2, end).replace(PROPERTY_PREFIX, '');
+      return [`\\${c}{${name}}`, end + 2];
+    }
+  }
+
+  // 字元類別中的 \b 為 backspace
+  if (c === 'b' && inClass) return ['\\x08', 2];
+
+  // \S 以補集的範圍表示，於字元類別中（[\S,]）亦可使用
+  if (c === 's' || c === 'S') {
+    const ranges = c === 's' ? WHITESPACE_CLASS : NON_WHITESPACE_CLASS;
+    return [inClass ? ranges : `[${ranges}]`, 2];
+  }
+
+  if (LETTER_ESCAPES.has(c)) return ['\\' + c, 2];
+
+  // 其餘字母與非 ASCII 字元的跳脫在 JS 中代表字元本身；Go 只接受跳脫 ASCII 標點
+  if (/[a-zA-Z]/.test(c) || c.charCodeAt(0) >= 0x80) return [c, 2];
+  if (c === '/') return ['/', 2];
+
+  return ['\\' + c, 2];
+}
+
+/**
+ * 字元類別內的範圍（不含外層的 [ ]）
+ */
+function classRanges(ranges: [number, number][]): string {
+  return ranges.map(([from, to]) => from === to ? codePoint(from) : `${codePoint(from)}-${codePoint(to)}`).join('');
+}
+
+/**
+ * 排序且不重疊的範圍於 U+0000-U+10FFFF 中的補集
+ */
+function complementRanges(ranges: [number, number][]): [number, number][] {
+  const result: [number, number][] = [];
+  let next = 0;
+  for (const [from, to] of ranges) {
+    if (from > next) result.push([next, from - 1]);
+    next = to + 1;
+  }
+  if (next <= 0x10ffff) result.push([next, 0x10ffff]);
+  return result;
+}
+
+function codePoint(cp: number): string {
+  return `\\x{${cp.toString(16).toUpperCase()}}`;
+}
@@ -0,0 +1,110 @@
+/**
+ * Go 字串字面量編碼
+ * 將 JS 字串值（UTF-16）編碼為語義相同的 Go 字串字面量（UTF-8）
+ */
+
+/**
+ * 需要跳脫的字元：控制字元、格式字元、行/段落分隔符
+ */
+const NEEDS_ESCAPE = /[\p{Cc}\p{Cf}\p{Zl}\p{Zp}]/u;
+
+const SIMPLE_ESCAPES: Record<number, string> = {
+  0x07: '\\a',
+  0x08: '\\b',
+  0x09: '\\t',
+  0x0a: '\\n',
+  0x0b: '\\v',
+  0x0c: '\\f',
+  0x0d: '\\r',
+  0x22: '\\"',
+  0x5c: '\\\\'
+};
+
+export interface GoStringOptions {
+  /** 含 `"` 或 `\` 且可安全表示時改用 raw string (`...`)，預設 true */
+  allowRaw?: boolean;
+}
+
+/**
+ * 編碼為 Go 字串字面量
+ * 孤立的 UTF-16 surrogate 無法以 UTF-8 表示，編碼為 U+FFFD（與 Node 輸出 UTF-8 時一致）
+ */
+export function goStringLiteral(value: string, options: GoStringOptions = {}): string {
+  const allowRaw = options.allowRaw !== false;
+
+  if (allowRaw && canUseRawString(value)) {
+    return '`' + value + '`';
+  }
+
+  let result = '"';
+  for (const cp of codePoints(value)) {
+    result += escapeCodePoint(cp);
+  }
+  return result + '"';
+}
+
+/**
+ * 跳脫 fmt 格式字串中的 %
+ */
+export function escapeFormatString(value: string): string {
+  return value.replace(/%/g, '%%');
+}
+
+/**
+ * 以 raw string 表示較易讀且語義不變時回傳 true
+ * raw string 不能包含 backtick，且 Go 會移除其中的 \r
+ */
+function canUseRawString(value: string): boolean {
+  if (!value.includes('"') && !value.includes('\\')) return false;
+  if (value.includes('`') || value.includes('\n')) return false;
+
+  for (const cp of codePoints(value)) {
+    if (isLoneSurrogate(cp) || NEEDS_ESCAPE.test(String.fromCodePoint(cp))) {
+      return false;
+    }
+  }
+  return true;
+}
+
+function escapeCodePoint(cp: number): string {
+  const simple = SIMPLE_ESCAPES[cp];
+  if (simple) return simple;
+
+  if (isLoneSurrogate(cp)) return '\\uFFFD';
+
+  if (cp < 0x80) {
+    return cp < 0x20 || cp === 0x7f ? `\\x${hex(cp, 2)}` : String.fromCharCode(cp);
+  }
+
+  if (NEEDS_ESCAPE.test(String.fromCodePoint(cp))) {
+    return cp > 0xffff ? `\\U${hex(cp, 8)}` : `\\u${hex(cp, 4)}`;
+  }
+
+  return String.fromCodePoint(cp);
+}
+
+/**
+ * 依 code point 走訪，孤立的 surrogate 以其 code unit 回傳
+ */
+function* codePoints(value: string): Generator<number> {
+  for (let i = 0; i < value.length; i++) {
+    const unit = value.charCodeAt(i);
+    if (unit >= 0xd800 && unit <= 0xdbff && i + 1 < value.length) {
+      const next = value.charCodeAt(i + 1);
+      if (next >= 0xdc00 && next <= 0xdfff) {
+        yield (unit - 0xd800) * 0x400 + (next - 0xdc00) + 0x10000;
+        i++;
+        continue;
+      }
+    }
+    yield unit;
+  }
+}
+
+function isLoneSurrogate(cp: number): boolean {
+  return cp >= 0xd800 && cp <= 0xdfff;
+}
+
+function hex(value: number, width: number): string {
+  return value.toString(16).toUpperCase().padStart(width, '0');
+}
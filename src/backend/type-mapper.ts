@@ -194,6 +194,12 @@ export class TypeMapper {
    * 映射 Intersection 型別
    */
   private mapIntersectionType(type: ir.IntersectionType): string {
+    // Branded type 取其基本型別
+    const brandBase = getBrandBaseType(type);
+    if (brandBase) {
+      return this.mapPrimitiveType(brandBase);
+    }
+
     // Intersection 通過 struct embedding 實現
     // 返回 placeholder
     return 'interface{}';
@@ -220,19 +226,25 @@ export class TypeMapper {
         return '[]interface{}';
 
       case 'Map':
-        if (type.typeArguments && type.typeArguments.length >= 2) {
-          const keyType = this.mapType(type.typeArguments[0]);
-          const valueType = this.mapType(type.typeArguments[1]);
-          return `map[${keyType}]${valueType}`;
-        }
-        return 'map[string]interface{}';
+      case 'ReadonlyMap': {
+        // ordered 策略保留插入順序，native 為 Go map（走訪順序不固定）
+        const [keyType, valueType] = type.typeArguments && type.typeArguments.length >= 2 ?
+          [this.mapType(type.typeArguments[0]), this.mapType(type.typeArguments[1])] :
+          ['interface{}', 'interface{}'];
+        return this.context.options.mapStrategy === 'native' ?
+          `map[${keyType}]${valueType}` :
+          `*runtime.OrderedMap[${keyType}, ${valueType}]`;
+      }
 
       case 'Set':
-        if (type.typeArguments && type.typeArguments.length > 0) {
-          const elementType = this.mapType(type.typeArguments[0]);
-          return `map[${elementType}]bool`;
-        }
-        return 'map[interface{}]bool';
+      case 'ReadonlySet': {
+        const elementType = type.typeArguments && type.typeArguments.length > 0 ?
+          this.mapType(type.typeArguments[0]) :
+          'interface{}';
+        return this.context.options.mapStrategy === 'native' ?
+          `map[${elementType}]bool` :
+          `*runtime.OrderedSet[${elementType}]`;
+      }
 
       case 'Record':
         if (type.typeArguments && type.typeArguments.length >= 2) {
@@ -247,7 +259,8 @@ export class TypeMapper {
       case 'Readonly':
       case 'Pick':
       case 'Omit':
-        // Utility types - 在編譯時處理，這裡返回 placeholder
+        // Utility types 由 TypeSimplificationPass 具體化為具名 struct（引用隨之改名）；
+        // 仍保留原名者無法具體化（來源為型別參數或其他檔案的型別），沿用來源型別
         if (type.typeArguments && type.typeArguments.length > 0) {
           return this.mapType(type.typeArguments[0]);
         }
@@ -327,6 +340,66 @@ export class TypeMapper {
   }
 }
 
+/**
+ * 會被具體化為 struct 的 utility types
+ */
+export type UtilityTypeKind = 'Partial' | 'Required' | 'Readonly' | 'Pick' | 'Omit';
+
+export const UTILITY_TYPE_KINDS: ReadonlySet<string> = new Set(['Partial', 'Required', 'Readonly', 'Pick', 'Omit']);
+
+/**
+ * 產生 utility type 具體化後的 struct 名稱
+ * 例如: Partial<User> → UserPartial, Pick<User, 'id' | 'name'> → UserPickIdName
+ */
+export function getUtilityTypeName(kind: UtilityTypeKind, source: string, keys: string[] = []): string {
+  const suffix = keys.map(k => k.charAt(0).toUpperCase() + k.slice(1)).join('');
+  switch (kind) {
+    case 'Pick':
+      return `${source}Pick${suffix}`;
+    case 'Omit':
+      return `${source}Without${suffix}`;
+    default:
+      return `${source}${kind}`;
+  }
+}
+
+/**
+ * 取出字串字面量型別（或其 union）中的鍵名
+ */
+export function getLiteralKeys(type: ir.IRType): string[] {
+  if (type instanceof ir.LiteralType && typeof type.value === 'string') {
+    return [type.value];
+  }
+  if (type instanceof ir.UnionType) {
+    return type.types.flatMap(t => getLiteralKeys(t));
+  }
+  return [];
+}
+
+/**
+ * 辨識 branded type（基本型別 & 僅含標記欄位的物件），例如
+ * string & { __brand: 'UserId' } 或 string & { [brand]: true }（brand 為 unique symbol），回傳其基本型別
+ */
+export function getBrandBaseType(type: ir.IntersectionType): ir.PrimitiveType | null {
+  const primitives = type.types.filter((t): t is ir.PrimitiveType => t instanceof ir.PrimitiveType);
+  const markers = type.types.filter(t => !(t instanceof ir.PrimitiveType));
+
+  if (primitives.length !== 1 || markers.length === 0) {
+    return null;
+  }
+  if (!['string', 'number', 'boolean'].includes(primitives[0].kind)) {
+    return null;
+  }
+
+  // 標記欄位以底線開頭，例如 __brand、_tag；unique symbol 為鍵的欄位於轉換時略去，只留下個數
+  const isMarker = (t: ir.IRType) => t instanceof ir.ObjectType &&
+    !t.indexSignature &&
+    t.properties.length + (t.metadata.get('uniqueSymbolKeys') ?? 0) > 0 &&
+    t.properties.every(p => p.name.startsWith('_'));
+
+  return markers.every(isMarker) ? primitives[0] : null;
+}
+
 /**
  * 特殊型別處理器
  */
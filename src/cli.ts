@@ -13,9 +13,30 @@ import { glob } from 'glob';
 import { Compiler } from './compiler/compiler';
 import { CompilerOptions, defaultOptions, loadOptionsFromFile } from './config/options';
 import { generateRuntime } from './runtime/runtime-generator';
+import { CompilationResult, GoProject } from './compiler/result';
 
 const program = new Command();
 
+function printWarnings(result: CompilationResult): void {
+  result.warnings?.forEach(warning => {
+    const where = warning.location ? ` (${warning.location.toString()})` : '';
+    console.warn(chalk.yellow(`  ${warning.code}: ${warning.message}${where}`));
+    if (warning.hint) {
+      console.warn(chalk.gray(`    hint: ${warning.hint}`));
+    }
+  });
+}
+
+function printErrors(result: CompilationResult): void {
+  result.errors?.forEach(err => {
+    const where = err.location ? ` (${err.location.toString()})` : '';
+    console.error(chalk.red(`  ${err.code}: ${err.message}${where}`));
+    if (err.hint) {
+      console.error(chalk.gray(`    hint: ${err.hint}`));
+    }
+  });
+}
+
 program
   .name('ts2go')
   .description('TypeScript to Go transpiler with semantic preservation')
@@ -33,10 +54,18 @@ program
   .option('--union-strategy <strategy>', 'Union type mapping strategy (tagged|interface|any)', 'tagged')
   .option('--nullability-strategy <strategy>', 'Nullability strategy (pointer|zero|sqlNull)', 'pointer')
   .option('--async-strategy <strategy>', 'Async/await handling strategy (sync|future|errgroup)', 'sync')
+  .option('--timer-strategy <strategy>', 'Timer handling strategy (eventloop|goroutine)', 'eventloop')
+  .option('--optional-param-strategy <strategy>', 'Optional parameter representation (pointer|optional)', 'pointer')
+  .option('--options-object-strategy <strategy>', 'Options object parameter lowering (none|struct|functional)', 'none')
+  .option('--generic-method-naming <pattern>', 'Name pattern of hoisted generic methods ({Method}, {Class})')
+  .option('--string-fidelity <mode>', 'String method semantics (fast|faithful)')
+  .option('--map-strategy <strategy>', 'Map/Set representation (ordered|native)')
   .option('--go-version <version>', 'Target Go version', '1.22')
   .option('--no-runtime', 'Do not generate runtime helpers')
+  .option('--runtime-import <path>', 'Import path of the generated runtime package')
   .option('--source-map', 'Generate source maps')
   .option('--strict', 'Enable strict mode')
+  .option('--lint-numeric-sort', 'Warn when number arrays are sorted without a comparator')
   .option('--verbose', 'Verbose output')
   .action(async (input: string, options: any) => {
     try {
@@ -60,10 +89,18 @@ program
         unionStrategy: options.unionStrategy || config.unionStrategy,
         nullabilityStrategy: options.nullabilityStrategy || config.nullabilityStrategy,
         asyncStrategy: options.asyncStrategy || config.asyncStrategy,
+        timerStrategy: options.timerStrategy || config.timerStrategy,
+        optionalParamStrategy: options.optionalParamStrategy || config.optionalParamStrategy,
+        optionsObjectStrategy: options.optionsObjectStrategy || config.optionsObjectStrategy,
+        genericMethodNaming: options.genericMethodNaming || config.genericMethodNaming,
+        stringFidelity: options.stringFidelity || config.stringFidelity,
+        mapStrategy: options.mapStrategy || config.mapStrategy,
         goVersion: options.goVersion || config.goVersion,
         generateRuntime: options.runtime !== false,
+        runtimeImportPath: options.runtimeImport || config.runtimeImportPath,
         sourceMap: options.sourceMap || config.sourceMap,
         strict: options.strict || config.strict,
+        lintNumericSort: options.lintNumericSort || config.lintNumericSort,
         verbose: options.verbose || config.verbose
       } as CompilerOptions;
 
@@ -83,6 +120,7 @@ program
         // Single file compilation
         console.log(chalk.gray(`Compiling ${input}...`));
         const result = await compiler.compileFile(input);
+        printWarnings(result);
 
         if (result.success) {
           const outputPath = path.join(
@@ -107,23 +145,28 @@ program
           }
         } else {
           console.error(chalk.red('✗ Compilation failed:'));
-          result.errors?.forEach(err => {
-            console.error(chalk.red(`  ${err.message}`));
-          });
+          printErrors(result);
           process.exit(1);
         }
       } else {
         // Directory compilation
         console.log(chalk.gray(`Compiling project ${input}...`));
         const result = await compiler.compileProject(input);
+        printWarnings(result);
 
         if (result.success) {
+          // 依原目錄結構寫出 .go 檔，並寫出 go.mod（module 路徑與 runtime 的 import 路徑一致）
+          const project = result.output as GoProject;
+          for (const [file, code] of project.files) {
+            const outputPath = path.join(options.output, path.relative(input, file).replace(/\.ts$/, '.go'));
+            fs.mkdirSync(path.dirname(outputPath), { recursive: true });
+            fs.writeFileSync(outputPath, code);
+          }
+          fs.writeFileSync(path.join(options.output, 'go.mod'), project.goMod);
           console.log(chalk.green(`✓ Compiled project to ${options.output}`));
         } else {
           console.error(chalk.red('✗ Compilation failed:'));
-          result.errors?.forEach(err => {
-            console.error(chalk.red(`  ${err.message}`));
-          });
+          printErrors(result);
           process.exit(1);
         }
       }
@@ -192,6 +235,7 @@ program
           console.log(chalk.gray('Recompiling...'));
 
           const result = await compiler.compileFile(filePath);
+          printWarnings(result);
 
           if (result.success) {
             const outputPath = path.join(
@@ -202,9 +246,7 @@ program
             console.log(chalk.green(`✓ Compiled to ${outputPath}`));
           } else {
             console.error(chalk.red('✗ Compilation failed:'));
-            result.errors?.forEach(err => {
-              console.error(chalk.red(`  ${err.message}`));
-            });
+            printErrors(result);
           }
         } catch (error: any) {
           console.error(chalk.red(`✗ Error: ${error.message}`));
@@ -256,11 +298,18 @@ program
       unionStrategy: 'tagged',
       nullabilityStrategy: 'pointer',
       asyncStrategy: 'sync',
+      timerStrategy: 'eventloop',
+      optionalParamStrategy: 'pointer',
+      optionsObjectStrategy: 'none',
+      genericMethodNaming: '{Method}{Class}',
+      stringFidelity: 'fast',
+      mapStrategy: 'ordered',
       errorHandling: 'return',
       goVersion: '1.22',
       generateRuntime: true,
       sourceMap: true,
       strict: true,
+      lintNumericSort: false,
       optimizationLevel: 1
     };
 
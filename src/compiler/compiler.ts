@@ -3,7 +3,7 @@
  */
 
 import * as ts from 'typescript';
-import { Module } from '../ir/nodes';
+import { Module, FunctionDeclaration } from '../ir/nodes';
 import { CompilerOptions } from '../config/options';
 import { TypeScriptParser } from '../frontend/parser';
 import { IRTransformer } from '../ir/transformer';
@@ -11,11 +11,21 @@ import { GoCodeGenerator } from '../backend/go-generator';
 import { CompilationResult, CompilationError, GoProject, CompilationStatistics } from './result';
 import { IROptimizer } from '../optimizer/optimizer';
 
+/**
+ * 產生的程式碼可能引用的第三方 module
+ * localeCompare 與 normalize 使用 golang.org/x/text，errgroup 策略使用 golang.org/x/sync
+ */
+const GO_MODULE_REQUIREMENTS: Record<string, string> = {
+  'golang.org/x/sync': 'v0.10.0',
+  'golang.org/x/text': 'v0.21.0'
+};
+
 export class Compiler {
   private parser: TypeScriptParser;
   private transformer: IRTransformer;
   private generator: GoCodeGenerator;
   private optimizer: IROptimizer;
+  private diagnostics: CompilationError[] = [];
 
   constructor(private options: CompilerOptions) {
     this.parser = new TypeScriptParser(options);
@@ -28,18 +38,25 @@ export class Compiler {
    * 編譯單一 TypeScript 檔案
    */
   async compileFile(filePath: string): Promise<CompilationResult> {
+    this.diagnostics = [];
     try {
       // 階段 1: 解析 TypeScript AST
       const tsAst = await this.parser.parseFile(filePath);
 
       // 階段 2: 轉換為 IR
       const irModule = await this.transformer.transform(tsAst);
+      this.diagnostics.push(...this.transformer.getDiagnostics());
+      if (this.collectErrors().length > 0) {
+        return this.failWithDiagnostics();
+      }
+      this.checkEventLoopEntry([irModule]);
 
       // 階段 3: IR 優化與正規化
       const optimizedIR = await this.optimizeIR(irModule);
 
       // 階段 4: 產生 Go 程式碼
       const generated = this.generator.generate(optimizedIR);
+      this.checkModuleRequirements(generated.imports);
 
       return {
         success: true,
@@ -61,17 +78,24 @@ export class Compiler {
    * 編譯整個 TypeScript 專案
    */
   async compileProject(projectPath: string): Promise<CompilationResult> {
+    this.diagnostics = [];
     try {
       // 階段 1: 分析專案結構
       const project = await this.parser.analyzeProject(projectPath);
 
-      // 階段 2: 批次轉換所有模組
-      const modules: Module[] = [];
+      // 階段 2: 批次轉換所有模組（共用專案程式，跨檔案的呼叫亦可解析簽名）
+      const transformed: Module[] = [];
       for (const file of project.files) {
-        const tsAst = await this.parser.parseFile(file);
-        const irModule = await this.transformer.transform(tsAst);
-        modules.push(irModule);
+        const tsAst = this.parser.getSourceFile(file);
+        transformed.push(await this.transformer.transform(tsAst));
+        this.diagnostics.push(...this.transformer.getDiagnostics());
+      }
+      // 優化以整個專案為單位，ctx 的傳遞可跨越檔案
+      const modules = this.optimizer.optimizeProject(transformed);
+      if (this.collectErrors().length > 0) {
+        return this.failWithDiagnostics();
       }
+      this.checkEventLoopEntry(modules);
 
       // 階段 3: 解析模組相依性
       const resolvedModules = await this.resolveModuleDependencies(modules);
@@ -85,7 +109,7 @@ export class Compiler {
       });
 
       const goProject: GoProject = {
-        goMod: 'module generated\n\ngo 1.21',
+        goMod: this.generateGoMod(goFiles.flatMap(file => file.imports)),
         files: filesMap
       };
 
@@ -111,6 +135,71 @@ export class Compiler {
     return this.optimizer.optimize(module);
   }
 
+  /**
+   * 計時器排入 runtime.DefaultLoop，只有 main 以 defer runtime.RunEventLoop() 執行事件迴圈時才會觸發。
+   * 任一模組使用計時器時，定義 main 的模組亦標記 eventLoop（即使它本身未使用計時器）；
+   * 沒有 main 的程式（例如被其他 Go 程式引用的 library）產生 W4007，由呼叫端自行執行 runtime.RunEventLoop()
+   */
+  private checkEventLoopEntry(modules: Module[]): void {
+    if (this.options.timerStrategy === 'goroutine' || !modules.some(m => m.metadata.get('eventLoop'))) {
+      return;
+    }
+
+    const entries = modules.filter(m =>
+      m.statements.some(s => s instanceof FunctionDeclaration && s.name === 'main'));
+    for (const entry of entries) {
+      entry.metadata.set('eventLoop', true);
+    }
+    if (entries.length === 0) {
+      this.diagnostics.push({
+        code: 'W4007',
+        message: 'Timers are scheduled on runtime.DefaultLoop, but there is no main function to run the event loop; they never fire unless the caller runs it',
+        location: modules.find(m => m.metadata.get('eventLoop'))?.location,
+        severity: 'warning',
+        hint: 'Call runtime.RunEventLoop() after using this package, or set timerStrategy to "goroutine"'
+      });
+    }
+  }
+
+  /**
+   * 專案的 go.mod；匯入第三方套件時加入對應 module 的 require（go.sum 由 go mod tidy 產生）
+   */
+  private generateGoMod(imports: string[]): string {
+    const requires = this.requiredModules(imports).map(mod => `require ${mod} ${GO_MODULE_REQUIREMENTS[mod]}\n`);
+    return `module ${this.goModulePath()}\n\ngo ${this.options.goVersion || '1.22'}\n${requires.length > 0 ? '\n' + requires.join('') : ''}`;
+  }
+
+  /**
+   * go.mod 的 module 路徑
+   * runtime 寫入輸出目錄的 runtime/，因此由 runtimeImportPath 去掉結尾的 /runtime 而得（預設 ts2go），
+   * 產生的 import "ts2go/runtime" 才能在此 module 內解析
+   */
+  private goModulePath(): string {
+    const runtimeImportPath = this.options.runtimeImportPath || 'ts2go/runtime';
+    return runtimeImportPath.endsWith('/runtime') ?
+      runtimeImportPath.slice(0, -'/runtime'.length) :
+      'generated';
+  }
+
+  /**
+   * 單一檔案編譯不產生 go.mod，引用第三方套件時提醒需自行加入相依
+   */
+  private checkModuleRequirements(imports: string[]): void {
+    for (const mod of this.requiredModules(imports)) {
+      this.diagnostics.push({
+        code: 'W4009',
+        message: `The generated code imports ${mod}, which is not in the standard library`,
+        severity: 'warning',
+        hint: `Run 'go get ${mod}@${GO_MODULE_REQUIREMENTS[mod]}' in the module that builds this file`
+      });
+    }
+  }
+
+  private requiredModules(imports: string[]): string[] {
+    return Object.keys(GO_MODULE_REQUIREMENTS).filter(mod =>
+      imports.some(pkg => pkg === mod || pkg.startsWith(mod + '/')));
+  }
+
   /**
    * 解析模組相依性
    */
@@ -123,8 +212,22 @@ export class Compiler {
   }
 
   private collectWarnings(): CompilationError[] {
-    // TODO: 收集來自各階段的警告
-    return [];
+    return this.diagnostics.filter(d => d.severity !== 'error');
+  }
+
+  private collectErrors(): CompilationError[] {
+    return this.diagnostics.filter(d => d.severity === 'error');
+  }
+
+  /**
+   * 轉換階段回報錯誤（例如 RE2 無法表達的正規表達式）時，不產生程式碼
+   */
+  private failWithDiagnostics(): CompilationResult {
+    return {
+      success: false,
+      errors: this.collectErrors(),
+      warnings: this.collectWarnings()
+    };
   }
 
   private collectStatistics(): CompilationStatistics {
@@ -45,6 +45,53 @@ export interface CompilerOptions {
    */
   asyncStrategy?: 'sync' | 'future' | 'errgroup';
 
+  /**
+   * setTimeout/setInterval/queueMicrotask 處理策略
+   * eventloop: runtime 的單執行緒事件迴圈，保留 microtask 先於 macrotask 的順序
+   * goroutine: time.AfterFunc 與 goroutine，較輕量但回呼可能並行執行
+   */
+  timerStrategy?: 'eventloop' | 'goroutine';
+
+  /**
+   * 可選 / 預設值參數的表示方式，兩者皆能區分「未傳入」與傳入零值
+   * pointer: *T，未傳入時為 nil
+   * optional: runtime.OptionalValue[T]
+   */
+  optionalParamStrategy?: 'pointer' | 'optional';
+
+  /**
+   * 結尾的可選參數達此數量時，改以產生的 XxxOptions struct 傳入（0 表示停用）
+   */
+  optionalParamsStructThreshold?: number;
+
+  /**
+   * 最後一個參數為 options 物件（fetch(url, { method, timeout })）時的降階方式
+   * none: 沿用 interface 對應的 struct 指標
+   * struct: 以值傳入的參數 struct，未設定的欄位於函式開頭套用預設值
+   * functional: functional options（opts ...RequestOption 與 WithTimeout(v) 等設定函式）
+   */
+  optionsObjectStrategy?: 'none' | 'struct' | 'functional';
+
+  /**
+   * 泛型方法（Go 方法不能有型別參數）提升為函式時的命名樣板
+   * {Method}、{Class} 代換為方法與類別名稱，預設 '{Method}{Class}'（Box.map → MapBox）
+   */
+  genericMethodNaming?: string;
+
+  /**
+   * 字串方法的對應方式
+   * fast: len() 與位元組 / rune 索引，使用 strings 套件，非 ASCII 文字的長度與位置與 JS 不同
+   * faithful: runtime helper 以 UTF-16 code unit 計算長度與索引，與 TypeScript 結果一致
+   */
+  stringFidelity?: 'fast' | 'faithful';
+
+  /**
+   * Map / Set 的對應方式
+   * ordered: runtime.OrderedMap / OrderedSet，依插入順序走訪，與 TypeScript 結果一致
+   * native: Go 的 map[K]V 與 map[T]bool，走訪順序不固定
+   */
+  mapStrategy?: 'ordered' | 'native';
+
   // === 輸出控制 ===
   /**
    * Go 版本目標
@@ -56,6 +103,12 @@ export interface CompilerOptions {
    */
   generateRuntime?: boolean;
 
+  /**
+   * 產生的程式碼引用 runtime 套件時的 import 路徑
+   * 例如 "example.com/app/runtime"，預設 "ts2go/runtime"
+   */
+  runtimeImportPath?: string;
+
   /**
    * 是否使用指標接收者
    */
@@ -82,6 +135,11 @@ export interface CompilerOptions {
    */
   allowAny?: boolean;
 
+  /**
+   * 數字陣列呼叫不帶比較函式的 sort() 時發出警告（JS 以字串排序，[10, 9, 1] → [1, 10, 9]）
+   */
+  lintNumericSort?: boolean;
+
   // === 優化選項 ===
   /**
    * 優化等級
@@ -159,12 +217,21 @@ export const defaultOptions: Partial<CompilerOptions> = {
   unionStrategy: 'tagged',
   nullabilityStrategy: 'pointer',
   asyncStrategy: 'sync',
+  timerStrategy: 'eventloop',
+  optionalParamStrategy: 'pointer',
+  optionalParamsStructThreshold: 4,
+  optionsObjectStrategy: 'none',
+  genericMethodNaming: '{Method}{Class}',
+  stringFidelity: 'fast',
+  mapStrategy: 'ordered',
   goVersion: '1.22',
   generateRuntime: true,
+  runtimeImportPath: 'ts2go/runtime',
   usePointerReceivers: true,
   embedInterfaces: true,
   errorHandling: 'return',
   strict: true,
+  lintNumericSort: false,
   allowAny: false,
   optimizationLevel: 0,
   removeUnusedCode: false,
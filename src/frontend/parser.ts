@@ -118,6 +118,17 @@ export class TypeScriptParser {
     };
   }
 
+  /**
+   * 取得專案程式中的原始檔，使跨檔案的符號與簽名由同一個 TypeChecker 解析
+   */
+  getSourceFile(filePath: string): ts.SourceFile {
+    const sourceFile = this.program?.getSourceFile(path.resolve(filePath));
+    if (!sourceFile) {
+      throw new Error(`File not found in program: ${filePath}`);
+    }
+    return sourceFile;
+  }
+
   /**
    * 取得 TypeChecker 實例
    */
@@ -142,14 +153,20 @@ export class TypeScriptParser {
    * 將 TypeScript AST 位置轉換為我們的 SourceLocation
    */
   getSourceLocation(node: ts.Node): SourceLocation {
-    const sourceFile = node.getSourceFile();
-    const start = sourceFile.getLineAndCharacterOfPosition(node.getStart());
-    const end = sourceFile.getLineAndCharacterOfPosition(node.getEnd());
+    return this.getRangeLocation(node.getSourceFile(), node.getStart(), node.getEnd());
+  }
+
+  /**
+   * 取得檔案中一段範圍的源碼位置（例如正規表達式字面量中的某個構造）
+   */
+  getRangeLocation(sourceFile: ts.SourceFile, startOffset: number, endOffset: number): SourceLocation {
+    const start = sourceFile.getLineAndCharacterOfPosition(startOffset);
+    const end = sourceFile.getLineAndCharacterOfPosition(endOffset);
 
     return new SourceLocation(
       sourceFile.fileName,
-      new Position(start.line + 1, start.character + 1, node.getStart()),
-      new Position(end.line + 1, end.character + 1, node.getEnd())
+      new Position(start.line + 1, start.character + 1, startOffset),
+      new Position(end.line + 1, end.character + 1, endOffset)
     );
   }
 
@@ -463,6 +463,7 @@ export class ForOfStatement extends Statement {
     public left: VariableDeclaration,
     public right: Expression,
     public body: Statement,
+    public isAwait: boolean = false,
     location?: SourceLocation
   ) {
     super(location);
@@ -801,6 +802,20 @@ export class AwaitExpression extends Expression {
   }
 }
 
+export class YieldExpression extends Expression {
+  constructor(
+    public argument?: Expression,
+    public delegate: boolean = false,
+    location?: SourceLocation
+  ) {
+    super(location);
+  }
+
+  accept<T>(visitor: IRVisitor<T>): T {
+    return visitor.visitYieldExpression(this);
+  }
+}
+
 export class SpreadElement extends Expression {
   constructor(
     public argument: Expression,
@@ -965,6 +980,7 @@ export interface IRVisitor<T> {
   visitAssignmentExpression(node: AssignmentExpression): T;
   visitConditionalExpression(node: ConditionalExpression): T;
   visitAwaitExpression(node: AwaitExpression): T;
+  visitYieldExpression(node: YieldExpression): T;
   visitSpreadElement(node: SpreadElement): T;
   visitTemplateLiteral(node: TemplateLiteral): T;
 
@@ -6,11 +6,82 @@ import * as ts from 'typescript';
 import * as ir from './nodes';
 import { CompilerOptions } from '../config/options';
 import { TypeScriptParser } from '../frontend/parser';
+import { SourceLocation } from './location';
+import { CompilationError } from '../compiler/result';
+import { UTILITY_TYPE_KINDS, getBrandBaseType } from '../backend/type-mapper';
+import { translateRegExp } from '../backend/go-regexp';
+
+/** 多載的實作與簽名 */
+interface OverloadSet {
+  implementation: ts.FunctionDeclaration | ts.MethodDeclaration;
+  signatures: (ts.FunctionDeclaration | ts.MethodDeclaration)[];
+}
+
+/** Object 靜態方法引數的形狀，見 IRTransformer.getObjectShape */
+type ObjectShape = { kind: 'map' | 'struct' | 'literal'; [key: string]: unknown };
+
+const PROMISE_COMBINATORS = ['all', 'allSettled', 'race', 'any', 'resolve', 'reject'];
+/** generator 函式回傳的迭代器型別 */
+const SYNC_ITERATOR_TYPES = ['Generator', 'IterableIterator'];
+const ASYNC_ITERATOR_TYPES = ['AsyncGenerator', 'AsyncIterable', 'AsyncIterableIterator'];
+/** 排程於事件迴圈的計時器 API */
+const TIMER_FUNCTIONS = ['setTimeout', 'setInterval', 'queueMicrotask'];
+/** 接收者經 TypeChecker 確認為陣列時降階的 Array.prototype 方法 */
+const ARRAY_METHODS = new Set([
+  'map', 'filter', 'reduce', 'find', 'findIndex', 'some', 'every', 'includes', 'indexOf', 'lastIndexOf',
+  'slice', 'splice', 'concat', 'join', 'flat', 'flatMap', 'reverse', 'fill', 'at',
+  'sort', 'toSorted', 'toReversed'
+]);
+/** 接收者經 TypeChecker 確認為字串時，依 stringFidelity 降階的 String.prototype 方法 */
+const STRING_METHODS = new Set([
+  'charAt', 'charCodeAt', 'codePointAt', 'slice', 'substring', 'padStart', 'padEnd', 'indexOf',
+  'includes', 'startsWith', 'endsWith', 'split', 'replace', 'replaceAll', 'match', 'matchAll',
+  'trim', 'trimStart', 'trimEnd', 'toUpperCase', 'toLowerCase', 'localeCompare', 'normalize', 'repeat'
+]);
+const DATE_FIELDS = ['FullYear', 'Month', 'Date', 'Hours', 'Minutes', 'Seconds', 'Milliseconds'];
+const DATE_METHODS = new Set([
+  'getTime', 'valueOf', 'toISOString', 'toJSON', 'getTimezoneOffset', 'setTime', 'getDay', 'getUTCDay',
+  ...DATE_FIELDS.flatMap(field => [`get${field}`, `getUTC${field}`, `set${field}`, `setUTC${field}`])
+]);
+/** 運算元為 Date 時以 valueOf()（毫秒數）運算的運算子；+ 會轉為字串串接，不在此列 */
+const DATE_VALUE_OPERATORS = new Set(['-', '*', '/', '%', '<', '>', '<=', '>=']);
+const NUMBER_METHODS = new Set(['toFixed', 'toString']);
+/** 與 Go 語義不同（或 Go 沒有）的數值運算子，運算元皆為 number 時由 GoCodeGenerator 降階 */
+const NUMERIC_OPERATORS = new Set(['%', '**', '&', '|', '^', '<<', '>>', '>>>']);
+/** 以第一個型別引數作為 key 的容器型別 */
+const MAP_KEY_TYPES = new Set(['Record', 'Map', 'ReadonlyMap', 'WeakMap', 'Set', 'ReadonlySet', 'WeakSet']);
+/** Map / Set 型別對應的集合種類；Weak 版本不可走訪，一律對應 Go map */
+const COLLECTION_TYPES = new Map<string, 'map' | 'set'>([
+  ['Map', 'map'], ['ReadonlyMap', 'map'], ['WeakMap', 'map'],
+  ['Set', 'set'], ['ReadonlySet', 'set'], ['WeakSet', 'set']
+]);
+const COLLECTION_METHODS = new Set(['get', 'set', 'add', 'has', 'delete', 'clear', 'forEach', 'keys', 'values', 'entries']);
+/** 依引數為 Go map（Record、index signature）或 struct 降階的 Object 靜態方法 */
+const OBJECT_METHODS = new Set(['keys', 'values', 'entries', 'assign', 'freeze', 'fromEntries']);
+/** 原地修改陣列的方法；凍結的陣列呼叫時產生 W4006 */
+const MUTATING_ARRAY_METHODS = new Set(['push', 'pop', 'shift', 'unshift', 'splice', 'sort', 'reverse', 'fill', 'copyWithin']);
+const EQUALITY_OPERATORS = new Set([
+  ts.SyntaxKind.EqualsEqualsEqualsToken,
+  ts.SyntaxKind.ExclamationEqualsEqualsToken,
+  ts.SyntaxKind.EqualsEqualsToken,
+  ts.SyntaxKind.ExclamationEqualsToken
+]);
 
 export class IRTransformer {
   private parser: TypeScriptParser;
   private currentModule?: ir.Module;
   private typeChecker?: ts.TypeChecker;
+  private diagnostics: CompilationError[] = [];
+  /** 行內 keyof 產生的字串列舉型別 */
+  private synthesizedTypes = new Map<string, ir.TypeAliasDeclaration>();
+  /** 併入 Promise.all/allSettled 呼叫的 Promise 陣列（引用處 → 初始值） */
+  private inlinedPromiseArrays = new Map<ts.Node, ts.Expression>();
+  /** 提升為套件層級 regexp 變數的正規表達式（/pattern/flags → 變數名稱與 Go 語法） */
+  private hoistedRegExps = new Map<string, { name: string; source: string }>();
+  /** 模組頂層宣告（首字大寫後的名稱 → 宣告），行內 keyof 產生的列舉名稱需避開 */
+  private topLevelDeclarations = new Map<string, ts.Node>();
+  /** 型別別名是否為 branded type（isBrandAlias 的快取） */
+  private brandAliases = new Map<ts.TypeAliasDeclaration, boolean>();
 
   constructor(
     private options: CompilerOptions,
@@ -24,6 +95,12 @@ export class IRTransformer {
    */
   async transform(sourceFile: ts.SourceFile): Promise<ir.Module> {
     this.typeChecker = this.parser.getTypeChecker();
+    this.diagnostics = [];
+    this.synthesizedTypes.clear();
+    this.inlinedPromiseArrays.clear();
+    this.hoistedRegExps.clear();
+    this.brandAliases.clear();
+    this.collectTopLevelDeclarations(sourceFile);
 
     const module = new ir.Module(
       this.getModuleName(sourceFile),
@@ -52,6 +129,10 @@ export class IRTransformer {
         module.statements.push(stmt);
       }
     });
+    module.statements.push(...this.synthesizedTypes.values());
+    if (this.hoistedRegExps.size > 0) {
+      module.metadata.set('regexps', [...this.hoistedRegExps.values()]);
+    }
 
     // 處理 exports
     const exports = this.parser.getExports(sourceFile);
@@ -65,6 +146,13 @@ export class IRTransformer {
     return module;
   }
 
+  /**
+   * 取得最近一次 transform 產生的診斷
+   */
+  getDiagnostics(): CompilationError[] {
+    return this.diagnostics;
+  }
+
   /**
    * 轉換陳述式
    */
@@ -92,6 +180,8 @@ export class IRTransformer {
         return this.transformForStatement(node as ts.ForStatement);
       case ts.SyntaxKind.ForOfStatement:
         return this.transformForOfStatement(node as ts.ForOfStatement);
+      case ts.SyntaxKind.ForInStatement:
+        return this.transformForInStatement(node as ts.ForInStatement);
       case ts.SyntaxKind.ReturnStatement:
         return this.transformReturnStatement(node as ts.ReturnStatement);
       case ts.SyntaxKind.ThrowStatement:
@@ -110,7 +200,12 @@ export class IRTransformer {
   /**
    * 轉換變數陳述式
    */
-  private transformVariableStatement(node: ts.VariableStatement): ir.Statement {
+  private transformVariableStatement(node: ts.VariableStatement): ir.Statement | null {
+    // declare const brand: unique symbol 等環境宣告沒有執行期的值
+    if (node.modifiers?.some(m => m.kind === ts.SyntaxKind.DeclareKeyword)) {
+      return null;
+    }
+
     const declarations: ir.Statement[] = [];
 
     for (const decl of node.declarationList.declarations) {
@@ -143,17 +238,19 @@ export class IRTransformer {
     const parameters = node.parameters.map(p => this.transformParameter(p));
     const returnType = node.type ? this.transformTypeNode(node.type) : undefined;
     const typeParameters = node.typeParameters?.map(tp => this.transformTypeParameter(tp));
-    const body = node.body ? this.transformBlock(node.body) : undefined;
+    const overloads = this.getOverloadSet(node);
+    const body = node.body ? this.transformBlock(node.body) :
+      overloads ? this.createOverloadBody(node, overloads) : undefined;
 
-    return new ir.FunctionDeclaration(
-      node.name.text,
+    return this.annotateGenerator(node, new ir.FunctionDeclaration(
+      this.getOverloadedName(node) || node.name.text,
       parameters,
       returnType,
       body,
       typeParameters,
-      this.getModifiers(node),
+      this.getOverloadModifiers(node, overloads),
       this.parser.getSourceLocation(node)
-    );
+    ));
   }
 
   /**
@@ -181,9 +278,10 @@ export class IRTransformer {
 
         if (hasVisibilityModifier && ts.isIdentifier(param.name)) {
           // Create a PropertyMember for this parameter property
+          // 未標註型別的預設值參數（public retries = 3）沿用 checker 推斷的型別
           const propMember = new ir.PropertyMember(
             param.name.text,
-            param.type ? this.transformTypeNode(param.type) : undefined,
+            param.type ? this.transformTypeNode(param.type) : param.initializer ? this.transformParameter(param).type : undefined,
             undefined, // no initializer for parameter properties
             this.getModifiers(param),
             this.parser.getSourceLocation(param)
@@ -278,15 +376,17 @@ export class IRTransformer {
       const name = this.getPropertyName(node.name);
       if (!name) return null;
 
-      return new ir.MethodMember(
-        name,
+      const overloads = this.getOverloadSet(node);
+      return this.annotateGenerator(node, new ir.MethodMember(
+        this.getOverloadedName(node) || name,
         node.parameters.map(p => this.transformParameter(p)),
         node.type ? this.transformTypeNode(node.type) : undefined,
-        node.body ? this.transformBlock(node.body) : undefined,
+        node.body ? this.transformBlock(node.body) :
+          overloads ? this.createOverloadBody(node, overloads) : undefined,
         node.typeParameters?.map(tp => this.transformTypeParameter(tp)),
-        this.getModifiers(node),
+        this.getOverloadModifiers(node, overloads),
         this.parser.getSourceLocation(node)
-      );
+      ));
     }
 
     if (ts.isConstructorDeclaration(node)) {
@@ -421,10 +521,20 @@ export class IRTransformer {
   /**
    * 轉換型別別名宣告
    */
-  private transformTypeAliasDeclaration(node: ts.TypeAliasDeclaration): ir.TypeAliasDeclaration {
+  private transformTypeAliasDeclaration(node: ts.TypeAliasDeclaration): ir.TypeAliasDeclaration | null {
+    // 泛型的計算型別 (type IsString<T> = T extends ...) 於各具體實例化處解析，不產生宣告
+    if (node.typeParameters && node.typeParameters.length > 0 && this.isComputedTypeNode(node.type)) {
+      return null;
+    }
+
+    // type UserKeys = keyof User 直接以別名作為字串列舉
+    const type = this.isKeyOfNode(node.type) ?
+      this.resolveTypeNode(node.type, false) :
+      this.transformTypeNode(node.type);
+
     return new ir.TypeAliasDeclaration(
       node.name.text,
-      this.transformTypeNode(node.type),
+      type,
       node.typeParameters?.map(tp => this.transformTypeParameter(tp)),
       this.getModifiers(node),
       this.parser.getSourceLocation(node)
@@ -522,6 +632,11 @@ export class IRTransformer {
       case ts.SyntaxKind.TypeReference:
         const typeRef = node as ts.TypeReferenceNode;
         const typeName = this.getEntityName(typeRef.typeName);
+        // 以具體型別參數實例化計算型別別名，例如 IsString<'a'>
+        // Utility types 保留給 TypeSimplificationPass 具體化
+        if (typeRef.typeArguments && !UTILITY_TYPE_KINDS.has(typeName) && this.referencesComputedAlias(typeRef)) {
+          return this.resolveTypeNode(typeRef);
+        }
         return new ir.TypeReference(
           typeName,
           typeRef.typeArguments?.map(t => this.transformTypeNode(t)),
@@ -546,6 +661,25 @@ export class IRTransformer {
         const typeLiteral = node as ts.TypeLiteralNode;
         return this.transformTypeLiteral(typeLiteral);
 
+      case ts.SyntaxKind.TypeOperator:
+        const typeOperator = node as ts.TypeOperatorNode;
+        // readonly T[] 在 Go 中沒有對應，取其內部型別
+        if (typeOperator.operator === ts.SyntaxKind.ReadonlyKeyword) {
+          return this.transformTypeNode(typeOperator.type);
+        }
+        return this.resolveTypeNode(node);
+
+      case ts.SyntaxKind.ParenthesizedType:
+        return this.transformTypeNode((node as ts.ParenthesizedTypeNode).type);
+
+      // 計算型別交由 TypeChecker 解析
+      case ts.SyntaxKind.IndexedAccessType:
+      case ts.SyntaxKind.ConditionalType:
+      case ts.SyntaxKind.MappedType:
+      case ts.SyntaxKind.TemplateLiteralType:
+      case ts.SyntaxKind.TypeQuery:
+        return this.resolveTypeNode(node);
+
       default:
         return new ir.PrimitiveType('any', this.parser.getSourceLocation(node));
     }
@@ -578,6 +712,7 @@ export class IRTransformer {
   private transformTypeLiteral(node: ts.TypeLiteralNode): ir.ObjectType {
     const properties: ir.PropertySignature[] = [];
     let indexSignature: ir.IndexSignature | undefined;
+    let uniqueSymbolKeys = 0;
 
     for (const member of node.members) {
       if (ts.isPropertySignature(member)) {
@@ -590,6 +725,8 @@ export class IRTransformer {
             !!member.modifiers?.some(m => m.kind === ts.SyntaxKind.ReadonlyKeyword),
             this.parser.getSourceLocation(member)
           ));
+        } else if (this.isUniqueSymbolKey(member.name)) {
+          uniqueSymbolKeys++;
         }
       } else if (ts.isIndexSignatureDeclaration(member)) {
         const param = member.parameters[0];
@@ -605,11 +742,245 @@ export class IRTransformer {
       }
     }
 
-    return new ir.ObjectType(
+    const objectType = new ir.ObjectType(
       properties,
       indexSignature,
       this.parser.getSourceLocation(node)
     );
+    // 以 unique symbol 為鍵的欄位於 Go 沒有對應而略去，只記錄個數供 branded type 辨識（{ [brand]: true }）
+    if (uniqueSymbolKeys > 0) {
+      objectType.metadata.set('uniqueSymbolKeys', uniqueSymbolKeys);
+    }
+    return objectType;
+  }
+
+  /**
+   * 判斷屬性名稱是否為 unique symbol 型別的計算屬性 [brand]
+   */
+  private isUniqueSymbolKey(name: ts.PropertyName): boolean {
+    if (!this.typeChecker || !ts.isComputedPropertyName(name)) return false;
+    return !!(this.typeChecker.getTypeAtLocation(name.expression).flags & ts.TypeFlags.UniqueESSymbol);
+  }
+
+  /**
+   * 判斷型別節點是否需要 TypeChecker 計算
+   */
+  private isComputedTypeNode(node: ts.TypeNode): boolean {
+    return ts.isConditionalTypeNode(node) ||
+      ts.isMappedTypeNode(node) ||
+      ts.isIndexedAccessTypeNode(node) ||
+      ts.isTemplateLiteralTypeNode(node) ||
+      this.isKeyOfNode(node);
+  }
+
+  private isKeyOfNode(node: ts.TypeNode): node is ts.TypeOperatorNode {
+    return ts.isTypeOperatorNode(node) && node.operator === ts.SyntaxKind.KeyOfKeyword;
+  }
+
+  /**
+   * 判斷型別引用是否指向計算型別的別名
+   */
+  private referencesComputedAlias(node: ts.TypeReferenceNode): boolean {
+    if (!this.typeChecker) return false;
+    let symbol = this.typeChecker.getSymbolAtLocation(node.typeName);
+    if (symbol && symbol.flags & ts.SymbolFlags.Alias) {
+      symbol = this.typeChecker.getAliasedSymbol(symbol);
+    }
+    const decl = symbol?.declarations?.find(ts.isTypeAliasDeclaration);
+    return !!decl && this.isComputedTypeNode(decl.type);
+  }
+
+  /**
+   * 以 TypeChecker 解析計算型別並降階為 IR 型別
+   * 僅在仍依賴型別參數（泛型殘留）時產生診斷
+   */
+  private resolveTypeNode(node: ts.TypeNode, inline: boolean = true): ir.IRType {
+    const location = this.parser.getSourceLocation(node);
+    if (!this.typeChecker) {
+      return new ir.PrimitiveType('any', location);
+    }
+
+    const type = this.typeChecker.getTypeFromTypeNode(node);
+    const resolved = this.typeToIR(type, location);
+
+    if (!resolved) {
+      this.diagnostics.push({
+        code: 'W4001',
+        message: `Cannot resolve generic type '${node.getText()}' to a concrete Go type`,
+        location,
+        severity: 'warning',
+        hint: 'Use the type with concrete type arguments, or annotate an explicit type'
+      });
+      // keyof T 的鍵必為字串（或數字/symbol），其餘退化為 interface{}
+      return this.isKeyOfNode(node) ?
+        new ir.PrimitiveType('string', location) :
+        new ir.PrimitiveType('any', location);
+    }
+
+    // 行內的 keyof User 具體化為具名字串列舉 UserKey；模組已宣告 type UserKey = keyof User 時直接引用，
+    // 名稱被其他宣告佔用時加上序號
+    if (inline && this.isKeyOfNode(node) && resolved instanceof ir.UnionType &&
+        ts.isTypeReferenceNode(node.type)) {
+      const target = this.getEntityName(node.type.typeName);
+      let name = `${target}Key`;
+      for (let i = 2; this.topLevelDeclarations.has(name); i++) {
+        const declared = this.topLevelDeclarations.get(name)!;
+        if (ts.isTypeAliasDeclaration(declared) && !declared.typeParameters && this.isKeyOfNode(declared.type) &&
+            ts.isTypeReferenceNode(declared.type.type) && this.getEntityName(declared.type.type.typeName) === target) {
+          return new ir.TypeReference(name, undefined, location);
+        }
+        name = `${target}Key${i}`;
+      }
+      if (!this.synthesizedTypes.has(name)) {
+        this.synthesizedTypes.set(name, new ir.TypeAliasDeclaration(name, resolved, undefined, [], location));
+      }
+      return new ir.TypeReference(name, undefined, location);
+    }
+
+    return resolved;
+  }
+
+  /**
+   * 收集模組頂層宣告的名稱；Go 匯出時首字大寫，因此以首字大寫的名稱比對
+   */
+  private collectTopLevelDeclarations(sourceFile: ts.SourceFile): void {
+    this.topLevelDeclarations.clear();
+    const add = (name: string, node: ts.Node) => {
+      const key = name.charAt(0).toUpperCase() + name.slice(1);
+      if (!this.topLevelDeclarations.has(key)) {
+        this.topLevelDeclarations.set(key, node);
+      }
+    };
+    for (const stmt of sourceFile.statements) {
+      if ((ts.isTypeAliasDeclaration(stmt) || ts.isInterfaceDeclaration(stmt) || ts.isClassDeclaration(stmt) ||
+          ts.isEnumDeclaration(stmt) || ts.isFunctionDeclaration(stmt)) && stmt.name) {
+        add(stmt.name.text, stmt);
+      } else if (ts.isVariableStatement(stmt)) {
+        for (const decl of stmt.declarationList.declarations) {
+          if (ts.isIdentifier(decl.name)) add(decl.name.text, decl);
+        }
+      }
+    }
+  }
+
+  /**
+   * 將 TypeChecker 的型別轉換為 IR 型別
+   * 遇到未實例化的型別參數時回傳 null
+   */
+  private typeToIR(type: ts.Type, location?: SourceLocation, depth: number = 0): ir.IRType | null {
+    const checker = this.typeChecker!;
+    const flags = type.flags;
+
+    if (depth > 8) return new ir.PrimitiveType('any', location);
+
+    if (flags & ts.TypeFlags.Instantiable) return null;
+    if (flags & ts.TypeFlags.Any) return new ir.PrimitiveType('any', location);
+    if (flags & ts.TypeFlags.Unknown) return new ir.PrimitiveType('unknown', location);
+    if (flags & ts.TypeFlags.Never) return new ir.PrimitiveType('never', location);
+    if (flags & (ts.TypeFlags.Void | ts.TypeFlags.Undefined)) return new ir.PrimitiveType('void', location);
+    if (flags & ts.TypeFlags.Null) return new ir.LiteralType(null, location);
+    if (flags & ts.TypeFlags.Boolean) return new ir.PrimitiveType('boolean', location);
+    if (flags & ts.TypeFlags.EnumLike) {
+      const enumType = flags & ts.TypeFlags.Union ? type : checker.getBaseTypeOfLiteralType(type);
+      if (enumType.symbol) {
+        return new ir.TypeReference(enumType.symbol.name, undefined, location);
+      }
+    }
+    if (type.isStringLiteral()) return new ir.LiteralType(type.value, location);
+    if (type.isNumberLiteral()) return new ir.LiteralType(type.value, location);
+    if (flags & ts.TypeFlags.BooleanLiteral) {
+      return new ir.LiteralType((type as any).intrinsicName === 'true', location);
+    }
+    // 非泛型的 template literal 型別 (`/api/${string}`) 即為字串
+    if (flags & (ts.TypeFlags.String | ts.TypeFlags.TemplateLiteral | ts.TypeFlags.StringMapping)) {
+      return new ir.PrimitiveType('string', location);
+    }
+    if (flags & ts.TypeFlags.Number) return new ir.PrimitiveType('number', location);
+
+    if (type.isUnion()) {
+      const types: ir.IRType[] = [];
+      for (const t of type.types) {
+        const member = this.typeToIR(t, location, depth + 1);
+        if (!member) return null;
+        types.push(member);
+      }
+      return types.length === 1 ? types[0] : new ir.UnionType(types, location);
+    }
+
+    if (type.isIntersection()) {
+      const types: ir.IRType[] = [];
+      for (const t of type.types) {
+        const member = this.typeToIR(t, location, depth + 1);
+        if (!member) return null;
+        types.push(member);
+      }
+      return new ir.IntersectionType(types, location);
+    }
+
+    if (flags & ts.TypeFlags.Object) {
+      const isReference = ((type as ts.ObjectType).objectFlags & ts.ObjectFlags.Reference) !== 0;
+
+      if (isReference && (type.symbol?.name === 'Array' || type.symbol?.name === 'ReadonlyArray')) {
+        const element = this.typeToIR(checker.getTypeArguments(type as ts.TypeReference)[0], location, depth + 1);
+        return element ? new ir.ArrayType(element, location) : null;
+      }
+
+      if (isReference && ((type as ts.TypeReference).target.objectFlags & ts.ObjectFlags.Tuple) !== 0) {
+        const elements: ir.IRType[] = [];
+        for (const t of checker.getTypeArguments(type as ts.TypeReference)) {
+          const element = this.typeToIR(t, location, depth + 1);
+          if (!element) return null;
+          elements.push(element);
+        }
+        return new ir.TupleType(elements, location);
+      }
+
+      // 具名型別（interface、class、別名）以引用表示
+      const named = type.aliasSymbol || type.symbol;
+      if (named && !named.name.startsWith('__')) {
+        const args = type.aliasSymbol ? type.aliasTypeArguments :
+          (isReference ? checker.getTypeArguments(type as ts.TypeReference) : undefined);
+        const irArgs: ir.IRType[] = [];
+        for (const t of args || []) {
+          const arg = this.typeToIR(t, location, depth + 1);
+          if (!arg) return null;
+          irArgs.push(arg);
+        }
+        return new ir.TypeReference(named.name, irArgs.length > 0 ? irArgs : undefined, location);
+      }
+
+      // 匿名物件型別（例如 mapped type 的結果）展開為屬性
+      const signatures = type.getCallSignatures();
+      if (signatures.length > 0) {
+        const signature = signatures[0];
+        const params: ir.Parameter[] = [];
+        for (const param of signature.getParameters()) {
+          const paramType = this.typeToIR(checker.getTypeOfSymbol(param), location, depth + 1);
+          if (!paramType) return null;
+          params.push(new ir.Parameter(param.name, paramType));
+        }
+        const returnType = this.typeToIR(signature.getReturnType(), location, depth + 1);
+        return returnType ? new ir.FunctionType(params, returnType, undefined, false, location) : null;
+      }
+
+      const properties: ir.PropertySignature[] = [];
+      for (const prop of checker.getPropertiesOfType(type)) {
+        const propType = this.typeToIR(checker.getTypeOfSymbol(prop), location, depth + 1);
+        if (!propType) return null;
+        const decl = prop.declarations?.[0];
+        const readonly = !!decl && (ts.getCombinedModifierFlags(decl) & ts.ModifierFlags.Readonly) !== 0;
+        properties.push(new ir.PropertySignature(
+          prop.name,
+          propType,
+          (prop.flags & ts.SymbolFlags.Optional) !== 0,
+          readonly,
+          location
+        ));
+      }
+      return new ir.ObjectType(properties, undefined, location);
+    }
+
+    return new ir.PrimitiveType('any', location);
   }
 
   /**
@@ -645,6 +1016,12 @@ export class IRTransformer {
           this.parser.getSourceLocation(node)
         );
 
+      case ts.SyntaxKind.RegularExpressionLiteral: {
+        const regex = node as ts.RegularExpressionLiteral;
+        const slash = regex.text.lastIndexOf('/');
+        return this.hoistRegExp(regex.text.slice(1, slash), regex.text.slice(slash + 1), regex, regex.getStart() + 1);
+      }
+
       case ts.SyntaxKind.TrueKeyword:
         return new ir.Literal(true, 'true', this.parser.getSourceLocation(node));
 
@@ -696,49 +1073,272 @@ export class IRTransformer {
         return this.transformFunctionExpression(node as ts.FunctionExpression);
 
       case ts.SyntaxKind.AwaitExpression:
-        const awaitExpr = node as ts.AwaitExpression;
-        return new ir.AwaitExpression(
-          this.transformExpression(awaitExpr.expression),
+        return this.transformAwaitExpression(node as ts.AwaitExpression);
+
+      case ts.SyntaxKind.YieldExpression: {
+        const yieldExpr = node as ts.YieldExpression;
+        const yieldIR = new ir.YieldExpression(
+          yieldExpr.expression ? this.transformExpression(yieldExpr.expression) : undefined,
+          !!yieldExpr.asteriskToken,
           this.parser.getSourceLocation(node)
         );
+        // yield* 轉交的來源：generator 或陣列
+        const iterator = yieldExpr.asteriskToken && yieldExpr.expression ?
+          this.getIteratorKind(yieldExpr.expression) : undefined;
+        if (iterator) {
+          yieldIR.metadata.set('iterator', iterator);
+        }
+        return yieldIR;
+      }
 
       case ts.SyntaxKind.SpreadElement:
-        const spreadElement = node as ts.SpreadElement;
-        return new ir.SpreadElement(
-          this.transformExpression(spreadElement.expression),
-          this.parser.getSourceLocation(node)
-        );
+        return this.transformSpreadElement((node as ts.SpreadElement).expression, node);
 
       case ts.SyntaxKind.TemplateExpression:
         return this.transformTemplateExpression(node as ts.TemplateExpression);
 
+      case ts.SyntaxKind.NoSubstitutionTemplateLiteral:
+        const noSubTemplate = node as ts.NoSubstitutionTemplateLiteral;
+        return new ir.Literal(
+          noSubTemplate.text,
+          noSubTemplate.getText(),
+          this.parser.getSourceLocation(node)
+        );
+
+      case ts.SyntaxKind.ParenthesizedExpression:
+        const inner = this.transformExpression((node as ts.ParenthesizedExpression).expression);
+        inner.metadata.set('parenthesized', true);
+        return inner;
+
+      case ts.SyntaxKind.AsExpression:
+      case ts.SyntaxKind.TypeAssertionExpression:
+        return this.transformTypeAssertion(node as ts.AsExpression | ts.TypeAssertion);
+
       default:
         // 預設返回 identifier
         return new ir.Identifier('unknown', this.parser.getSourceLocation(node));
     }
   }
 
+  /**
+   * 轉換型別斷言
+   * 斷言為 branded type 時產生型別轉換 UserId(x)，其餘斷言於 Go 中無對應而略去
+   */
+  private transformTypeAssertion(node: ts.AsExpression | ts.TypeAssertion): ir.Expression {
+    const expression = this.transformExpression(node.expression);
+
+    if (ts.isTypeReferenceNode(node.type) && this.isBrandAlias(node.type)) {
+      const call = new ir.CallExpression(
+        new ir.Identifier(this.getEntityName(node.type.typeName), this.parser.getSourceLocation(node.type)),
+        [expression],
+        undefined,
+        this.parser.getSourceLocation(node)
+      );
+      call.metadata.set('typeConversion', this.transformTypeNode(node.type));
+      return call;
+    }
+
+    return expression;
+  }
+
+  /**
+   * 判斷型別引用是否指向 branded type 別名
+   */
+  private isBrandAlias(node: ts.TypeReferenceNode): boolean {
+    if (!this.typeChecker) return false;
+    let symbol = this.typeChecker.getSymbolAtLocation(node.typeName);
+    if (symbol && symbol.flags & ts.SymbolFlags.Alias) {
+      symbol = this.typeChecker.getAliasedSymbol(symbol);
+    }
+    const decl = symbol?.declarations?.find(ts.isTypeAliasDeclaration);
+    if (!decl || !ts.isIntersectionTypeNode(decl.type)) return false;
+
+    let brand = this.brandAliases.get(decl);
+    if (brand === undefined) {
+      // 別名宣告轉換時已回報其診斷，這裡只為判斷而轉換，捨棄重複的診斷
+      const count = this.diagnostics.length;
+      const type = this.transformTypeNode(decl.type);
+      this.diagnostics.length = count;
+      brand = type instanceof ir.IntersectionType && getBrandBaseType(type) !== null;
+      this.brandAliases.set(decl, brand);
+    }
+    return brand;
+  }
+
   // ... 其他輔助方法 ...
 
   private transformParameter(param: ts.ParameterDeclaration): ir.Parameter {
-    const name = ts.isIdentifier(param.name) ? param.name.text : 'unknown';
-    return new ir.Parameter(
+    // 解構的 options 物件參數以 options 命名，綁定的區域變數記錄於 optionsObject.bindings
+    const name = ts.isIdentifier(param.name) ? param.name.text :
+      ts.isObjectBindingPattern(param.name) ? 'options' : 'unknown';
+    // 未標註型別的預設值參數（retries = 3）由 checker 推斷型別
+    const inferredType = !param.type && param.initializer && this.typeChecker ?
+      this.typeToIR(this.typeChecker.getTypeAtLocation(param), this.parser.getSourceLocation(param)) || undefined :
+      undefined;
+    const irParam = new ir.Parameter(
       name,
-      param.type ? this.transformTypeNode(param.type) : undefined,
+      param.type ? this.transformTypeNode(param.type) : inferredType,
       !!param.questionToken,
       param.initializer ? this.transformExpression(param.initializer) : undefined,
       !!param.dotDotDotToken,
       this.parser.getSourceLocation(param)
     );
+
+    const optionsObject = this.getOptionsObjectInfo(param);
+    if (optionsObject) {
+      irParam.metadata.set('optionsObject', optionsObject);
+    }
+    return irParam;
+  }
+
+  /**
+   * 函式或方法的最後一個參數為 options 物件（interface / 型別字面量，且有可選屬性）時，
+   * 回傳其屬性與預設值，供 GoCodeGenerator 依 optionsObjectStrategy 降階為 functional options 或參數 struct
+   * 預設值取自解構參數的初始值（{ timeout = 5000 }）或屬性的 JSDoc @default
+   */
+  private getOptionsObjectInfo(param: ts.ParameterDeclaration) {
+    const fn = param.parent;
+    if (!this.typeChecker || param.dotDotDotToken) return undefined;
+    if (!(ts.isFunctionDeclaration(fn) || ts.isMethodDeclaration(fn))) return undefined;
+    if (fn.parameters[fn.parameters.length - 1] !== param) return undefined;
+
+    const type = this.typeChecker.getNonNullableType(this.typeChecker.getTypeAtLocation(param));
+    const symbol = type.aliasSymbol || type.symbol;
+    if (!symbol || !(symbol.flags & (ts.SymbolFlags.Interface | ts.SymbolFlags.TypeLiteral | ts.SymbolFlags.TypeAlias))) {
+      return undefined;
+    }
+    if (type.getCallSignatures().length > 0 || this.typeChecker.getIndexInfosOfType(type).length > 0) return undefined;
+
+    const props = type.getProperties();
+    if (props.length === 0 || !props.some(p => p.flags & ts.SymbolFlags.Optional)) return undefined;
+
+    // 解構參數的綁定與初始值
+    const bindings: { property: string; name: string }[] = [];
+    const bindingDefaults = new Map<string, ts.Expression>();
+    if (ts.isObjectBindingPattern(param.name)) {
+      for (const element of param.name.elements) {
+        const property = element.propertyName && ts.isIdentifier(element.propertyName) ? element.propertyName.text :
+          ts.isIdentifier(element.name) ? element.name.text : undefined;
+        if (element.dotDotDotToken || !property || !ts.isIdentifier(element.name)) return undefined;
+        bindings.push({ property, name: element.name.text });
+        if (element.initializer) {
+          bindingDefaults.set(property, element.initializer);
+        }
+      }
+    }
+
+    const location = this.parser.getSourceLocation(param);
+    const properties = props.map(prop => {
+      const propType = this.typeChecker!.getNonNullableType(this.typeChecker!.getTypeOfSymbolAtLocation(prop, param));
+      const initializer = bindingDefaults.get(prop.name);
+      return {
+        name: prop.name,
+        type: this.typeToIR(propType, location) || undefined,
+        optional: (prop.flags & ts.SymbolFlags.Optional) !== 0,
+        defaultValue: initializer ? this.transformExpression(initializer) : this.getDocumentedDefault(prop, location)
+      };
+    });
+
+    return { properties, bindings: bindings.length > 0 ? bindings : undefined };
+  }
+
+  /**
+   * 屬性 JSDoc 中的 @default（僅接受 JSON 字面量）
+   */
+  private getDocumentedDefault(prop: ts.Symbol, location?: ir.SourceLocation): ir.Expression | undefined {
+    const tag = prop.getJsDocTags(this.typeChecker).find(t => t.name === 'default' || t.name === 'defaultValue');
+    const text = tag?.text?.map(part => part.text).join('').trim();
+    if (!text) return undefined;
+    try {
+      const value = JSON.parse(text);
+      if (['string', 'number', 'boolean'].includes(typeof value)) {
+        return new ir.Literal(value, text, location);
+      }
+    } catch {
+      // 非字面量的預設值只保留於文件中
+    }
+    return undefined;
   }
 
   private transformTypeParameter(tp: ts.TypeParameterDeclaration): ir.TypeParameter {
-    return new ir.TypeParameter(
+    // K extends keyof T（T 為型別參數）無法具體化，僅保留可作為 map key 的限制
+    const genericKeyOf = !!tp.constraint && this.isKeyOfNode(tp.constraint) && !!this.typeChecker &&
+      !!(this.typeChecker.getTypeFromTypeNode(tp.constraint.type).flags & ts.TypeFlags.TypeParameter);
+
+    const param = new ir.TypeParameter(
       tp.name.text,
-      tp.constraint ? this.transformTypeNode(tp.constraint) : undefined,
+      tp.constraint && !genericKeyOf ? this.transformTypeNode(tp.constraint) : undefined,
       tp.default ? this.transformTypeNode(tp.default) : undefined,
       this.parser.getSourceLocation(tp)
     );
+
+    if (genericKeyOf || this.requiresComparable(tp)) {
+      param.metadata.set('comparable', true);
+    }
+    return param;
+  }
+
+  // ============= Generic Constraints =============
+
+  /**
+   * 型別參數作為 map key，或以 ===、includes/indexOf 比較時需要 comparable
+   */
+  private requiresComparable(tp: ts.TypeParameterDeclaration): boolean {
+    const checker = this.typeChecker;
+    if (!checker) return false;
+    const target = checker.getTypeAtLocation(tp);
+    const isTarget = (node: ts.Node | undefined) =>
+      !!node && (ts.isTypeNode(node) ? checker.getTypeFromTypeNode(node) : checker.getTypeAtLocation(node)) === target;
+
+    const visit = (node: ts.Node): boolean => {
+      // Record<K, V>、Map<K, V>、Set<K>
+      if (ts.isTypeReferenceNode(node) && MAP_KEY_TYPES.has(this.getEntityName(node.typeName)) &&
+          isTarget(node.typeArguments?.[0])) {
+        return true;
+      }
+      // new Set(arr) 未標註型別引數時由 TypeChecker 推斷
+      if (ts.isNewExpression(node) && ts.isIdentifier(node.expression) && MAP_KEY_TYPES.has(node.expression.text)) {
+        const type = checker.getTypeAtLocation(node);
+        const typeArguments = type.flags & ts.TypeFlags.Object ? checker.getTypeArguments(type as ts.TypeReference) : [];
+        if (typeArguments[0] === target) return true;
+      }
+      // 與 null/undefined 比較屬於存在性檢查，不需要 comparable
+      if (ts.isBinaryExpression(node) && EQUALITY_OPERATORS.has(node.operatorToken.kind) &&
+          !this.isNullish(node.left) && !this.isNullish(node.right) &&
+          (isTarget(node.left) || isTarget(node.right))) {
+        return true;
+      }
+      if (ts.isCallExpression(node) && ts.isPropertyAccessExpression(node.expression) &&
+          ['includes', 'indexOf', 'lastIndexOf'].includes(node.expression.name.text) &&
+          isTarget(node.arguments[0])) {
+        return true;
+      }
+      return ts.forEachChild(node, visit) || false;
+    };
+
+    return visit(tp.parent);
+  }
+
+  private isNullish(node: ts.Expression): boolean {
+    return node.kind === ts.SyntaxKind.NullKeyword || (ts.isIdentifier(node) && node.text === 'undefined');
+  }
+
+  /**
+   * 判斷屬性存取的物件是否為以結構型別限制的型別參數
+   * 例如 T extends { id: string } 時的 item.id，於 Go 中需透過 getter 讀取
+   */
+  private isConstraintPropertyAccess(node: ts.PropertyAccessExpression): boolean {
+    const checker = this.typeChecker;
+    if (!checker) return false;
+    const type = checker.getTypeAtLocation(node.expression);
+    if (!(type.flags & ts.TypeFlags.TypeParameter)) return false;
+
+    const constraint = checker.getBaseConstraintOfType(type);
+    if (!constraint || !(constraint.flags & ts.TypeFlags.Object) || this.isArrayType(constraint)) {
+      return false;
+    }
+    const property = constraint.getProperty(node.name.text);
+    return !!property?.declarations?.some(d => ts.isPropertySignature(d) || ts.isPropertyDeclaration(d));
   }
 
   private getModifiers(node: ts.Node): ir.Modifier[] {
@@ -777,7 +1377,9 @@ export class IRTransformer {
 
   private transformBlock(node: ts.Block): ir.BlockStatement {
     const statements: ir.Statement[] = [];
+    const inlined = this.collectInlinedPromiseArrays(node.statements);
     for (const stmt of node.statements) {
+      if (inlined.has(stmt)) continue;
       const irStmt = this.transformStatement(stmt);
       if (irStmt) {
         statements.push(irStmt);
@@ -922,9 +1524,18 @@ export class IRTransformer {
   private transformForOfStatement(node: ts.ForOfStatement): ir.ForOfStatement {
     let varDecl: ir.VariableDeclaration;
 
+    // 走訪 Map / Set（或其 keys/values/entries()）時直接走訪集合本身
+    const collection = this.getCollectionIteration(node.expression);
+    let bindings: (string | undefined)[] | undefined;
+
     if (ts.isVariableDeclarationList(node.initializer)) {
       const decl = node.initializer.declarations[0];
-      if (ts.isIdentifier(decl.name)) {
+      if ((collection || this.isObjectCall(node.expression, 'entries')) && ts.isArrayBindingPattern(decl.name)) {
+        // for (const [key, value] of map)、for (const [key, value] of Object.entries(obj))
+        bindings = decl.name.elements.map(e =>
+          ts.isBindingElement(e) && ts.isIdentifier(e.name) ? e.name.text : undefined);
+        varDecl = new ir.VariableDeclaration('entry', undefined, undefined, true, [], this.parser.getSourceLocation(decl));
+      } else if (ts.isIdentifier(decl.name)) {
         const isConst = !!(node.initializer.flags & ts.NodeFlags.Const);
         varDecl = new ir.VariableDeclaration(
           decl.name.text,
@@ -941,17 +1552,102 @@ export class IRTransformer {
       varDecl = new ir.VariableDeclaration('item', undefined, undefined, false, []);
     }
 
-    return new ir.ForOfStatement(
+    const forOf = new ir.ForOfStatement(
       varDecl,
-      this.transformExpression(node.expression),
+      this.transformExpression(collection ? collection.receiver : node.expression),
       this.transformStatement(node.statement)!,
+      !!node.awaitModifier,
       this.parser.getSourceLocation(node)
     );
+    if (collection) {
+      forOf.metadata.set('collection', { ...collection.info, bindings });
+    } else if (bindings) {
+      forOf.metadata.set('entryBindings', bindings);
+    }
+
+    // 走訪 generator / async iterable 時標記，GoCodeGenerator 以 iter.Seq 或 runtime.Stream 消費
+    // （map.keys() 的型別亦為 IterableIterator，已由 collection 處理）
+    const iterator = collection ? undefined : this.getIteratorKind(node.expression);
+    if (iterator) {
+      forOf.metadata.set('iterator', iterator);
+    }
+
+    return forOf;
+  }
+
+  /**
+   * for (const key in obj) 改寫為走訪 Object.keys(obj)：map 形狀的物件依 mapStrategy 排序鍵，struct 依欄位宣告順序。
+   * 走訪的鍵必為自身屬性，包住整個本體的 obj.hasOwnProperty(key) / Object.hasOwn(obj, key) 檢查一併移除；
+   * 其他物件（class 實例、陣列等）沒有對應的走訪，產生 W4011 並略過迴圈
+   */
+  private transformForInStatement(node: ts.ForInStatement): ir.ForOfStatement | null {
+    const location = this.parser.getSourceLocation(node);
+    const shape = this.getObjectShape(node.expression);
+    const decl = ts.isVariableDeclarationList(node.initializer) ? node.initializer.declarations[0] : undefined;
+    if (!shape || shape.kind === 'literal' || !decl || !ts.isIdentifier(decl.name)) {
+      this.diagnostics.push({
+        code: 'W4011',
+        message: 'for...in is only supported over Record-like objects and interfaces; the loop is omitted',
+        location,
+        severity: 'warning',
+        hint: 'Declare the loop variable with const or let, or iterate over Object.keys() of a typed object'
+      });
+      return null;
+    }
+
+    const key = decl.name.text;
+    let body = node.statement;
+    const guard = ts.isBlock(body) && body.statements.length === 1 ? body.statements[0] : body;
+    if (ts.isIfStatement(guard) && !guard.elseStatement && this.isOwnPropertyCheck(guard.expression, node.expression, key)) {
+      body = guard.thenStatement;
+    }
+
+    const keys = new ir.CallExpression(
+      new ir.MemberExpression(new ir.Identifier('Object', location), new ir.Identifier('keys', location), false, false, location),
+      [this.transformExpression(node.expression)],
+      undefined,
+      location
+    );
+    keys.metadata.set('objectMethod', { method: 'keys', shape });
+
+    const isConst = !!(node.initializer.flags & ts.NodeFlags.Const);
+    return new ir.ForOfStatement(
+      new ir.VariableDeclaration(key, undefined, undefined, isConst, [], this.parser.getSourceLocation(decl)),
+      keys,
+      this.transformStatement(body)!,
+      false,
+      location
+    );
+  }
+
+  /**
+   * obj.hasOwnProperty(key) 或 Object.hasOwn(obj, key)，obj 與 key 為 for...in 的物件與鍵
+   */
+  private isOwnPropertyCheck(node: ts.Expression, object: ts.Expression, key: string): boolean {
+    if (!ts.isCallExpression(node) || !ts.isPropertyAccessExpression(node.expression)) return false;
+    const isKey = (arg: ts.Expression | undefined) => !!arg && ts.isIdentifier(arg) && arg.text === key;
+    const callee = node.expression;
+    if (callee.name.text === 'hasOwnProperty') {
+      return callee.expression.getText() === object.getText() && node.arguments.length === 1 && isKey(node.arguments[0]);
+    }
+    return this.isObjectCall(node, 'hasOwn') && node.arguments.length === 2 &&
+      node.arguments[0].getText() === object.getText() && isKey(node.arguments[1]);
+  }
+
+  /**
+   * 運算式為 generator / async iterable 時回傳 'sync' | 'async'，陣列等一般集合回傳 undefined
+   */
+  private getIteratorKind(node: ts.Expression): 'sync' | 'async' | undefined {
+    const name = this.typeChecker?.getTypeAtLocation(node).symbol?.name;
+    if (!name) return undefined;
+    if (ASYNC_ITERATOR_TYPES.includes(name)) return 'async';
+    if (SYNC_ITERATOR_TYPES.includes(name)) return 'sync';
+    return undefined;
   }
 
   private transformReturnStatement(node: ts.ReturnStatement): ir.ReturnStatement {
     return new ir.ReturnStatement(
-      node.expression ? this.transformExpression(node.expression) : undefined,
+      node.expression ? this.transformPromiseResult(node.expression) : undefined,
       this.parser.getSourceLocation(node)
     );
   }
@@ -1000,6 +1696,11 @@ export class IRTransformer {
     const cases: ir.SwitchCase[] = [];
 
     for (const clause of node.caseBlock.clauses) {
+      // default 中的 const _exhaustive: never = x 僅供 TS 檢查，改由 checkExhaustiveness 處理
+      if (ts.isDefaultClause(clause) && this.isNeverAssertion(clause)) {
+        continue;
+      }
+
       const test = ts.isCaseClause(clause)
         ? this.transformExpression(clause.expression)
         : undefined;
@@ -1019,11 +1720,76 @@ export class IRTransformer {
       ));
     }
 
-    return new ir.SwitchStatement(
+    const switchStmt = new ir.SwitchStatement(
       this.transformExpression(node.expression),
       cases,
       this.parser.getSourceLocation(node)
     );
+
+    if (!cases.some(c => !c.test) && this.checkExhaustiveness(node)) {
+      switchStmt.metadata.set('exhaustive', true);
+    }
+
+    return switchStmt;
+  }
+
+  /**
+   * 檢查對 enum、字面量 union 或判別欄位的 switch 是否涵蓋所有成員
+   * 未涵蓋時發出列出缺漏成員的診斷
+   */
+  private checkExhaustiveness(node: ts.SwitchStatement): boolean {
+    if (!this.typeChecker) return false;
+    const checker = this.typeChecker;
+
+    const type = checker.getTypeAtLocation(node.expression);
+    const unitFlags = ts.TypeFlags.StringLiteral | ts.TypeFlags.NumberLiteral |
+      ts.TypeFlags.BooleanLiteral | ts.TypeFlags.EnumLiteral;
+    const members = type.isUnion() ? type.types : [type];
+    if (!members.every(t => t.flags & unitFlags) || (!type.isUnion() && !(type.flags & ts.TypeFlags.EnumLiteral))) {
+      return false;
+    }
+
+    // 字面量型別由 checker 唯一化，可直接以物件比較
+    const covered = new Set<ts.Type>();
+    for (const clause of node.caseBlock.clauses) {
+      if (!ts.isCaseClause(clause)) continue;
+      const caseType = checker.getTypeAtLocation(clause.expression);
+      if (!(caseType.flags & unitFlags)) return false;
+      covered.add(caseType);
+    }
+
+    const missing = members.filter(t => !covered.has(t));
+    if (missing.length > 0) {
+      const hasDefault = node.caseBlock.clauses.some(c => ts.isDefaultClause(c) && !this.isNeverAssertion(c));
+      if (!hasDefault) {
+        this.diagnostics.push({
+          code: 'W4002',
+          message: `Switch over '${checker.typeToString(type)}' is not exhaustive; missing: ` +
+            missing.map(t => checker.typeToString(t)).join(', '),
+          location: this.parser.getSourceLocation(node),
+          severity: 'warning',
+          hint: 'Add the missing cases or a default clause'
+        });
+      }
+      return false;
+    }
+
+    return true;
+  }
+
+  /**
+   * 判斷 default 是否僅為 never 斷言，例如 const _exhaustive: never = x
+   */
+  private isNeverAssertion(clause: ts.DefaultClause): boolean {
+    return clause.statements.length > 0 && clause.statements.every(stmt =>
+      (ts.isVariableStatement(stmt) && stmt.declarationList.declarations.every(
+        d => d.type?.kind === ts.SyntaxKind.NeverKeyword
+      )) ||
+      // 斷言之後常見的 return _exhaustive / throw
+      ts.isReturnStatement(stmt) ||
+      ts.isThrowStatement(stmt) ||
+      ts.isBreakStatement(stmt)
+    ) && clause.statements.some(stmt => ts.isVariableStatement(stmt));
   }
 
   private transformObjectLiteral(node: ts.ObjectLiteralExpression): ir.ObjectExpression {
@@ -1064,10 +1830,19 @@ export class IRTransformer {
       }
     }
 
-    return new ir.ObjectExpression(properties, this.parser.getSourceLocation(node));
+    const object = new ir.ObjectExpression(properties, this.parser.getSourceLocation(node));
+
+    // 上下文型別為 union 別名（return { status: 'success', data } 於回傳 Result 的函式中），
+    // 供 DiscriminatedUnionPass 依判別值選擇 variant struct
+    const contextual = this.typeChecker?.getContextualType(node);
+    if (contextual && contextual.isUnion() && contextual.aliasSymbol) {
+      object.metadata.set('unionType', contextual.aliasSymbol.name);
+    }
+
+    return object;
   }
 
-  private transformCallExpression(node: ts.CallExpression): ir.CallExpression | ir.SuperExpression {
+  private transformCallExpression(node: ts.CallExpression): ir.Expression {
     // Check if this is a super() call
     if (node.expression.kind === ts.SyntaxKind.SuperKeyword) {
       return new ir.SuperExpression(
@@ -1076,63 +1851,1275 @@ export class IRTransformer {
       );
     }
 
+    // Array.from(set) 與 [...set] 相同
+    if (ts.isPropertyAccessExpression(node.expression) && ts.isIdentifier(node.expression.expression) &&
+        node.expression.expression.text === 'Array' && node.expression.name.text === 'from' &&
+        node.arguments.length === 1 && this.getCollectionIteration(node.arguments[0])) {
+      return new ir.ArrayExpression(
+        [this.transformSpreadElement(node.arguments[0], node.arguments[0])],
+        this.parser.getSourceLocation(node)
+      );
+    }
+
     const typeArguments = node.typeArguments?.map(t => this.transformTypeNode(t));
 
-    return new ir.CallExpression(
-      this.transformExpression(node.expression),
-      node.arguments.map(arg => this.transformExpression(arg)),
+    const call = new ir.CallExpression(
+      this.transformCallee(node),
+      node.arguments.map(arg => this.transformExpression(this.inlinedPromiseArrays.get(arg) || arg)),
       typeArguments,
       this.parser.getSourceLocation(node)
     );
+    this.annotateAsyncCall(node, call);
+    this.annotateOptionalArgs(node, call);
+    this.annotateGenericMethodCall(node, call);
+    this.annotateArrayMethod(node, call);
+    this.annotateStringMethod(node, call);
+    this.annotateRegExpMethod(node, call);
+    this.annotateDateMethod(node, call);
+    this.annotateNumberMethod(node, call);
+    this.annotateCollectionMethod(node, call);
+    this.annotateObjectMethod(node, call);
+
+    // 使用計時器的模組需在 main 結束後執行事件迴圈
+    if (ts.isIdentifier(node.expression) && TIMER_FUNCTIONS.includes(node.expression.text)) {
+      this.currentModule?.metadata.set('eventLoop', true);
+    }
+
+    return call;
   }
 
-  private transformNewExpression(node: ts.NewExpression): ir.NewExpression {
-    const typeArguments = node.typeArguments?.map(t => this.transformTypeNode(t));
+  /**
+   * 被呼叫的函式、方法、函式運算式或建構函式有可選 / 預設值參數時標記 optionalArgs：
+   * { name, owner, parameters, passThrough, inline }
+   * passThrough[i] 表示第 i 個引數的型別本身含 undefined，Go 端已是可缺值的表示，不需再包裝；
+   * inline 表示函式運算式與建構函式，其參數不併入 options struct
+   */
+  private annotateOptionalArgs(node: ts.CallExpression | ts.NewExpression, call: ir.CallExpression | ir.NewExpression): void {
+    if (!this.typeChecker) return;
 
-    return new ir.NewExpression(
-      this.transformExpression(node.expression),
-      node.arguments?.map(arg => this.transformExpression(arg)) || [],
-      typeArguments,
-      this.parser.getSourceLocation(node)
-    );
-  }
+    const declaration = this.typeChecker.getResolvedSignature(node)?.declaration;
+    if (!declaration || !(ts.isFunctionDeclaration(declaration) || ts.isMethodDeclaration(declaration) ||
+        ts.isArrowFunction(declaration) || ts.isFunctionExpression(declaration) || ts.isConstructorDeclaration(declaration))) {
+      return;
+    }
+    const parameters = declaration.parameters.map(p => this.transformParameter(p));
+    if (!parameters.some(p => p.optional || p.defaultValue || p.metadata.get('optionsObject'))) return;
+
+    const inline = !(ts.isFunctionDeclaration(declaration) || ts.isMethodDeclaration(declaration));
+    const name = ts.isConstructorDeclaration(declaration) ? 'constructor' :
+      inline ? '' :
+      this.getOverloadedName(declaration) ||
+      (declaration.name && ts.isIdentifier(declaration.name) ? declaration.name.text : undefined);
+    if (name === undefined) return;
+    const owner = ts.isClassLike(declaration.parent) ? declaration.parent.name?.text : undefined;
+
+    const passThrough = (node.arguments || []).map(arg => {
+      const type = this.typeChecker!.getTypeAtLocation(arg);
+      const parts = type.isUnion() ? type.types : [type];
+      return parts.some(t => (t.flags & ts.TypeFlags.Undefined) !== 0);
+    });
 
-  private transformPropertyAccess(node: ts.PropertyAccessExpression): ir.MemberExpression {
-    return new ir.MemberExpression(
-      this.transformExpression(node.expression),
-      new ir.Identifier(node.name.text),
-      false,
-      !!node.questionDotToken,
-      this.parser.getSourceLocation(node)
-    );
+    call.metadata.set('optionalArgs', {
+      name,
+      owner,
+      parameters,
+      passThrough,
+      inline: inline || undefined
+    });
   }
 
-  private transformElementAccess(node: ts.ElementAccessExpression): ir.MemberExpression {
-    return new ir.MemberExpression(
-      this.transformExpression(node.expression),
-      this.transformExpression(node.argumentExpression!),
-      true,
-      !!node.questionDotToken,
-      this.parser.getSourceLocation(node)
-    );
-  }
+  /**
+   * 呼叫帶有自身型別參數的實例方法（box.map(f)）時標記 genericMethodCall：
+   * Go 的方法不能有型別參數，GoCodeGenerator 將其改寫為提升後的函式 MapBox(box, f)。
+   * 以 TypeChecker 解析的簽名判斷，宣告於其他檔案的類別亦適用
+   */
+  private annotateGenericMethodCall(node: ts.CallExpression, call: ir.CallExpression): void {
+    const checker = this.typeChecker;
+    if (!checker || !ts.isPropertyAccessExpression(node.expression)) return;
+
+    const declaration = checker.getResolvedSignature(node)?.declaration;
+    if (!declaration || !ts.isMethodDeclaration(declaration) || !declaration.typeParameters?.length ||
+        !ts.isClassDeclaration(declaration.parent) || !declaration.parent.name ||
+        this.parser.getModifiers(declaration).has('static')) {
+      return;
+    }
+
+    const owner = declaration.parent;
+    const receiverType = checker.getTypeAtLocation(node.expression.expression);
+
+    // 明確的方法型別引數需補上類別的型別引數；無法具體化時交由 Go 推斷
+    let classTypeArguments: (ir.IRType | null)[] | undefined;
+    if (node.typeArguments && receiverType.flags & ts.TypeFlags.Object) {
+      classTypeArguments = checker.getTypeArguments(receiverType as ts.TypeReference)
+        .map(t => this.typeToIR(t, this.parser.getSourceLocation(node)));
+    }
+
+    call.metadata.set('genericMethodCall', {
+      className: owner.name!.text,
+      methodName: node.expression.name.text,
+      exported: this.parser.getModifiers(owner).has('export'),
+      // 繼承而來的方法以內嵌的父類別作為接收者
+      embedded: !receiverType.symbol?.declarations?.includes(owner),
+      classTypeArguments: classTypeArguments?.every(t => t !== null) ? classTypeArguments : undefined
+    });
+  }
+
+  /**
+   * 陣列（Array、ReadonlyArray）或 tuple 型別
+   */
+  private isArrayType(type: ts.Type): boolean {
+    if (type.symbol?.name === 'Array' || type.symbol?.name === 'ReadonlyArray') return true;
+    const target = (type as ts.TypeReference).target;
+    return !!target && !!(target.objectFlags & ts.ObjectFlags.Tuple);
+  }
+
+  /**
+   * 接收者為陣列（或 tuple）的 Array.prototype 方法標記 arrayMethod：
+   * { method, elementType, flatDepth, callbackArity, arrayArgs }，由 GoCodeGenerator 降階為 slices 或 runtime helper
+   */
+  private annotateArrayMethod(node: ts.CallExpression, call: ir.CallExpression): void {
+    const checker = this.typeChecker;
+    if (!checker || !ts.isPropertyAccessExpression(node.expression) || !ARRAY_METHODS.has(node.expression.name.text)) {
+      return;
+    }
+    const receiver = checker.getNonNullableType(checker.getTypeAtLocation(node.expression.expression));
+    if (!this.isArrayType(receiver)) return;
+
+    const location = this.parser.getSourceLocation(node);
+    const element = checker.getIndexTypeOfType(receiver, ts.IndexKind.Number);
+    // 泛型元素型別 T 無法具體化，直接引用型別參數
+    const elementType = element && (
+      this.typeToIR(element, location) ||
+      (element.flags & ts.TypeFlags.TypeParameter ? new ir.TypeReference(element.symbol.name, undefined, location) : undefined)
+    );
+    const callback = node.arguments[0] && checker.getTypeAtLocation(node.arguments[0]).getCallSignatures()[0];
+    const method = node.expression.name.text;
+
+    // 不帶比較函式的 sort 以字串排序，數字陣列多半不是預期的結果
+    if (this.options.lintNumericSort && (method === 'sort' || method === 'toSorted') &&
+        node.arguments.length === 0 && element && element.flags & ts.TypeFlags.NumberLike) {
+      this.diagnostics.push({
+        code: 'W4004',
+        message: `'${method}()' without a comparator sorts numbers by their string forms`,
+        location,
+        severity: 'warning',
+        hint: 'Pass (a, b) => a - b to sort numerically'
+      });
+    }
+
+    call.metadata.set('arrayMethod', {
+      method,
+      elementType,
+      flatDepth: method === 'flat' && element ? this.getFlatDepth(node, element, location) : undefined,
+      // 回呼宣告的參數個數，接收索引時改用 Indexed helper
+      callbackArity: callback ? callback.parameters.length : 0,
+      // concat 的引數為陣列時展開，否則視為單一元素
+      arrayArgs: node.arguments.map(arg => {
+        const type = checker.getNonNullableType(checker.getTypeAtLocation(arg));
+        return this.isArrayType(type);
+      })
+    });
+  }
+
+  /**
+   * flat(depth) 實際展開的層數：Go 的結果型別須於編譯期決定，depth 須為數字字面量型別或 Infinity，
+   * 並以元素的巢狀陣列層數為上限。其他深度產生 W4008 並展開一層
+   */
+  private getFlatDepth(node: ts.CallExpression, element: ts.Type, location: SourceLocation): number {
+    const checker = this.typeChecker!;
+    let nesting = 0;
+    for (let type: ts.Type | undefined = checker.getNonNullableType(element); type && this.isArrayType(type);
+         type = checker.getIndexTypeOfType(type, ts.IndexKind.Number)) {
+      nesting++;
+    }
+
+    const arg = node.arguments[0];
+    if (!arg) return Math.min(1, nesting);
+    const type = checker.getTypeAtLocation(arg);
+    if (type.isNumberLiteral()) {
+      return Math.max(0, Math.min(Math.trunc(type.value), nesting));
+    }
+    const text = arg.getText();
+    if (text === 'Infinity' || text === 'Number.POSITIVE_INFINITY') {
+      return nesting;
+    }
+
+    this.diagnostics.push({
+      code: 'W4008',
+      message: 'flat() depth is not a constant; Go needs the result type at compile time, so only one level is flattened',
+      location,
+      severity: 'warning',
+      hint: 'Pass a number literal or Infinity as the depth'
+    });
+    return Math.min(1, nesting);
+  }
+
+  /**
+   * 字串（含字串字面量型別）
+   */
+  private isStringType(node: ts.Expression): boolean {
+    if (!this.typeChecker) return false;
+    const type = this.typeChecker.getNonNullableType(this.typeChecker.getTypeAtLocation(node));
+    return (type.flags & ts.TypeFlags.StringLike) !== 0;
+  }
+
+  /**
+   * 接收者為字串的 String.prototype 方法標記 stringMethod：
+   * { method, regexArgs, globalRegex, callbackArity }，由 GoCodeGenerator 依 stringFidelity 降階為 strings 或 runtime helper
+   */
+  private annotateStringMethod(node: ts.CallExpression, call: ir.CallExpression): void {
+    const checker = this.typeChecker;
+    if (!checker || !ts.isPropertyAccessExpression(node.expression) || !STRING_METHODS.has(node.expression.name.text) ||
+        !this.isStringType(node.expression.expression)) {
+      return;
+    }
+
+    const regexArgs = node.arguments.map(arg => checker.getTypeAtLocation(arg).symbol?.name === 'RegExp');
+    call.metadata.set('stringMethod', {
+      method: node.expression.name.text,
+      regexArgs,
+      // replace 搭配 /g 的正規表達式時取代全部；旗標僅能由字面量（或以字面量初始化的 const）得知
+      globalRegex: regexArgs[0] ? this.getRegexFlags(node.arguments[0]).includes('g') : false,
+      // replace 的取代函式宣告的參數個數（符合的字串與各捕獲群組）
+      callbackArity: node.arguments[1] ?
+        checker.getTypeAtLocation(node.arguments[1]).getCallSignatures()[0]?.parameters.length ?? 0 : 0
+    });
+  }
+
+  /**
+   * RegExp 的 test/exec 標記 regexpMethod，由 GoCodeGenerator 對應為 MatchString / FindStringSubmatch
+   * Go 的 regexp 沒有 lastIndex 狀態，帶 g 旗標的 exec 迴圈每次都從頭比對
+   */
+  private annotateRegExpMethod(node: ts.CallExpression, call: ir.CallExpression): void {
+    const checker = this.typeChecker;
+    if (!checker || !ts.isPropertyAccessExpression(node.expression)) return;
+    const method = node.expression.name.text;
+    if ((method !== 'test' && method !== 'exec') ||
+        checker.getTypeAtLocation(node.expression.expression).symbol?.name !== 'RegExp') {
+      return;
+    }
+
+    if (method === 'exec' && this.getRegexFlags(node.expression.expression).includes('g')) {
+      this.diagnostics.push({
+        code: 'W4005',
+        message: 'exec() on a global RegExp relies on lastIndex, which Go regexp does not track',
+        location: this.parser.getSourceLocation(node),
+        severity: 'warning',
+        hint: 'Iterate over str.matchAll(regex) instead'
+      });
+    }
+
+    call.metadata.set('regexpMethod', method);
+  }
+
+  /**
+   * number（含數字字面量型別），不含 enum：enum 於 Go 為具名整數型別，沿用原生運算子
+   */
+  private isNumberType(node: ts.Expression): boolean {
+    if (!this.typeChecker) return false;
+    const type = this.typeChecker.getNonNullableType(this.typeChecker.getTypeAtLocation(node));
+    const parts = type.isUnion() ? type.types : [type];
+    return parts.every(t => (t.flags & ts.TypeFlags.NumberLike) !== 0 && (t.flags & ts.TypeFlags.EnumLike) === 0);
+  }
+
+  /**
+   * 接收者為 number 的 toFixed / toString 標記 numberMethod，由 GoCodeGenerator 以 runtime 依 JS 的格式輸出
+   */
+  private annotateNumberMethod(node: ts.CallExpression, call: ir.CallExpression): void {
+    if (!ts.isPropertyAccessExpression(node.expression) || !NUMBER_METHODS.has(node.expression.name.text) ||
+        !this.isNumberType(node.expression.expression)) {
+      return;
+    }
+    call.metadata.set('numberMethod', node.expression.name.text);
+  }
+
+  /**
+   * Date（不含 null / undefined）
+   */
+  private isDateType(node: ts.Expression): boolean {
+    if (!this.typeChecker) return false;
+    return this.typeChecker.getNonNullableType(this.typeChecker.getTypeAtLocation(node)).symbol?.name === 'Date';
+  }
+
+  /**
+   * 接收者為 Date 的方法標記 dateMethod，由 GoCodeGenerator 對應為 time.Time 的方法或 runtime 的 setter
+   */
+  private annotateDateMethod(node: ts.CallExpression, call: ir.CallExpression): void {
+    if (!ts.isPropertyAccessExpression(node.expression) || !DATE_METHODS.has(node.expression.name.text) ||
+        !this.isDateType(node.expression.expression)) {
+      return;
+    }
+    call.metadata.set('dateMethod', node.expression.name.text);
+  }
+
+  /**
+   * new Date(...) 的建構方式，依引數個數與型別：
+   * now（無引數）、string（字串解析）、copy（複製 Date）、millis（epoch 毫秒數）、components（年、月…）
+   */
+  private getDateConstructor(args: readonly ts.Expression[]): string {
+    if (args.length === 0) return 'now';
+    if (args.length > 1) return 'components';
+    if (ts.isStringLiteralLike(args[0]) || ts.isTemplateExpression(args[0]) || this.isStringType(args[0])) {
+      return 'string';
+    }
+    return this.isDateType(args[0]) ? 'copy' : 'millis';
+  }
+
+  /**
+   * new Date(str) 解析失敗時 JavaScript 得到 Invalid Date。async 函式與 generator 內以 error 回傳；
+   * 頂層函式與類別方法標記 dateParseError，由 ErrorPropagationPass 將其改為回傳 (T, error)。
+   * 其他位置無法回傳 error，以零值 time.Time 代替，非字面量的字串產生 W4010 提醒檢查 IsZero()
+   */
+  private checkDateParse(node: ts.NewExpression, arg: ts.Expression, expression: ir.NewExpression): void {
+    if (ts.isStringLiteralLike(arg)) return;
+    let fn: ts.Node | undefined = node.parent;
+    while (fn && !ts.isFunctionLike(fn)) {
+      fn = fn.parent;
+    }
+    if (this.options.errorHandling !== 'panic' && fn) {
+      if (this.isAsyncFunction(fn as ts.SignatureDeclaration) || !!(fn as ts.FunctionLikeDeclaration).asteriskToken) {
+        return;
+      }
+      if (this.canReturnError(fn)) {
+        expression.metadata.set('dateParseError', true);
+        return;
+      }
+    }
+
+    this.diagnostics.push({
+      code: 'W4010',
+      message: 'new Date() cannot report an unparsable string here; an Invalid Date becomes the zero time.Time',
+      location: this.parser.getSourceLocation(node),
+      severity: 'warning',
+      hint: 'Check the result with IsZero(), or parse the date in a function or method that can return an error'
+    });
+  }
+
+  /**
+   * 可改為回傳 (T, error) 的同步函式：main 以外的頂層函式宣告，以及頂層類別的方法（建構子除外）
+   */
+  private canReturnError(fn: ts.Node): boolean {
+    if (ts.isFunctionDeclaration(fn)) {
+      return ts.isSourceFile(fn.parent) && !!fn.body && fn.name?.text !== 'main';
+    }
+    return ts.isMethodDeclaration(fn) && !!fn.body &&
+      ts.isClassDeclaration(fn.parent) && ts.isSourceFile(fn.parent.parent);
+  }
+
+  /**
+   * Map / Set（含 Readonly、Weak 版本）的集合種類；weak 表示不可走訪
+   */
+  private getCollectionType(node: ts.Expression): { kind: 'map' | 'set'; weak: boolean } | undefined {
+    if (!this.typeChecker) return undefined;
+    const name = this.typeChecker.getNonNullableType(this.typeChecker.getTypeAtLocation(node)).symbol?.name;
+    const kind = name ? COLLECTION_TYPES.get(name) : undefined;
+    return kind && { kind, weak: name!.startsWith('Weak') };
+  }
+
+  /**
+   * 走訪 Map / Set 本身或其 keys() / values() / entries() 時，回傳被走訪的集合與走訪的內容：
+   * iterate 為 entries（[key, value]）、keys 或 values；Set 的 keys() 與 values() 相同
+   */
+  private getCollectionIteration(node: ts.Expression):
+      { receiver: ts.Expression; info: { kind: 'map' | 'set'; weak: boolean; iterate: string } } | undefined {
+    if (ts.isCallExpression(node) && ts.isPropertyAccessExpression(node.expression) && node.arguments.length === 0) {
+      const method = node.expression.name.text;
+      const collection = this.getCollectionType(node.expression.expression);
+      if (collection && !collection.weak && (method === 'keys' || method === 'values' ||
+          (method === 'entries' && collection.kind === 'map'))) {
+        return {
+          receiver: node.expression.expression,
+          info: { ...collection, iterate: collection.kind === 'set' ? 'values' : method }
+        };
+      }
+    }
+
+    const collection = this.getCollectionType(node);
+    if (collection && !collection.weak) {
+      return { receiver: node, info: { ...collection, iterate: collection.kind === 'map' ? 'entries' : 'values' } };
+    }
+    return undefined;
+  }
+
+  /**
+   * 展開運算（...expression）；展開 Map / Set 時標記 collection，由 GoCodeGenerator 轉為 slice
+   */
+  private transformSpreadElement(expression: ts.Expression, node: ts.Node): ir.SpreadElement {
+    const collection = this.getCollectionIteration(expression);
+    const spread = new ir.SpreadElement(
+      this.transformExpression(collection ? collection.receiver : expression),
+      this.parser.getSourceLocation(node)
+    );
+    if (collection) {
+      spread.metadata.set('collection', collection.info);
+    }
+    return spread;
+  }
+
+  /**
+   * 接收者為 Map / Set 的方法標記 collectionMethod：{ kind, weak, method, callbackArity }，
+   * 由 GoCodeGenerator 依 mapStrategy 降階為 OrderedMap / OrderedSet 的方法或 Go map 操作
+   */
+  private annotateCollectionMethod(node: ts.CallExpression, call: ir.CallExpression): void {
+    if (!ts.isPropertyAccessExpression(node.expression) || !COLLECTION_METHODS.has(node.expression.name.text)) {
+      return;
+    }
+    const collection = this.getCollectionType(node.expression.expression);
+    if (!collection) return;
+
+    const method = node.expression.name.text;
+    const callback = method === 'forEach' && node.arguments[0] ?
+      this.typeChecker!.getTypeAtLocation(node.arguments[0]).getCallSignatures()[0] : undefined;
+    call.metadata.set('collectionMethod', {
+      ...collection,
+      method,
+      // forEach 回呼宣告的參數個數，只接收值時改用 ForEachValue
+      callbackArity: callback ? callback.parameters.length : 0
+    });
+  }
+
+  /**
+   * new Map(...) / new Set(...) 標記 collectionConstructor：{ kind, weak, typeArguments, source, entryType }
+   * source 依引數為 empty（無引數）、literal（陣列字面量）、copy（同種集合）、
+   * iteration（其他集合的 keys() / values()）或 array（陣列，entryType 為 Map 的 [key, value] 元素型別）
+   */
+  private annotateCollectionConstructor(node: ts.NewExpression, name: string, newExpression: ir.NewExpression): void {
+    const checker = this.typeChecker;
+    if (!checker) return;
+
+    const location = this.parser.getSourceLocation(node);
+    const kind = COLLECTION_TYPES.get(name)!;
+    const type = checker.getTypeAtLocation(node);
+    const typeArguments = (type.flags & ts.TypeFlags.Object ? checker.getTypeArguments(type as ts.TypeReference) : [])
+      .map(t => this.typeToIR(t, location) ||
+        (t.flags & ts.TypeFlags.TypeParameter ? new ir.TypeReference(t.symbol.name, undefined, location) :
+          new ir.PrimitiveType('any', location)));
+
+    const arg = node.arguments?.[0];
+    let source = 'empty';
+    let entryType: ir.IRType | null | undefined;
+    if (arg && !this.isNullish(arg)) {
+      const iteration = this.getCollectionIteration(arg);
+      if (iteration && iteration.info.kind === kind && iteration.info.iterate === (kind === 'map' ? 'entries' : 'values')) {
+        source = 'copy';
+        newExpression.args = [this.transformExpression(iteration.receiver)];
+      } else if (iteration) {
+        source = 'iteration';
+      } else if (ts.isArrayLiteralExpression(arg) && arg.elements.every(e => kind === 'set' ?
+          !ts.isSpreadElement(e) : ts.isArrayLiteralExpression(e) && e.elements.length === 2)) {
+        source = 'literal';
+      } else {
+        source = 'array';
+        const element = checker.getIndexTypeOfType(checker.getTypeAtLocation(arg), ts.IndexKind.Number);
+        entryType = element && this.typeToIR(element, location);
+      }
+    }
+
+    newExpression.metadata.set('collectionConstructor', {
+      kind,
+      weak: name.startsWith('Weak'),
+      typeArguments,
+      source,
+      entryType: entryType || undefined
+    });
+  }
+
+  /**
+   * Object.<method>(...) 呼叫
+   */
+  private isObjectCall(node: ts.Expression, method: string): node is ts.CallExpression {
+    return ts.isCallExpression(node) && ts.isPropertyAccessExpression(node.expression) &&
+      ts.isIdentifier(node.expression.expression) && node.expression.expression.text === 'Object' &&
+      node.expression.name.text === method;
+  }
+
+  /**
+   * Object 靜態方法引數的形狀：
+   * map 為 Record 或僅有 index signature 的型別（Go map），{ type, keyType, valueType }；
+   * struct 為非泛型的 interface 或物件型別別名，{ type, fields }，fields 依宣告順序，可選欄位於 Go 為指標；
+   * literal 為只有一般屬性的物件字面量（assign 的來源）。
+   * 陣列、Map / Set、函式、class 實例與含方法的 interface（Go interface）回傳 undefined
+   */
+  private getObjectShape(node: ts.Expression): ObjectShape | undefined {
+    const checker = this.typeChecker;
+    if (!checker) return undefined;
+    if (ts.isObjectLiteralExpression(node)) {
+      return node.properties.every(p => ts.isPropertyAssignment(p) || ts.isShorthandPropertyAssignment(p)) ?
+        { kind: 'literal' } : undefined;
+    }
+
+    const location = this.parser.getSourceLocation(node);
+    const type = checker.getNonNullableType(checker.getTypeAtLocation(node));
+    if (!(type.flags & ts.TypeFlags.Object) || this.isArrayType(type) || this.getCollectionType(node) ||
+        type.getCallSignatures().length > 0) {
+      return undefined;
+    }
+    const irType = this.typeToIR(type, location);
+    if (!irType) return undefined;
+
+    if (type.aliasSymbol?.name === 'Record' && type.aliasTypeArguments?.length === 2) {
+      const [keyType, valueType] = type.aliasTypeArguments.map(t => this.typeToIR(t, location));
+      return keyType && valueType ? { kind: 'map', type: irType, keyType, valueType } : undefined;
+    }
+
+    const properties = checker.getPropertiesOfType(type);
+    const [index] = checker.getIndexInfosOfType(type);
+    if (index) {
+      const valueType = properties.length === 0 && this.typeToIR(index.type, location);
+      const keyType = new ir.PrimitiveType(index.keyType.flags & ts.TypeFlags.NumberLike ? 'number' : 'string', location);
+      return valueType ? { kind: 'map', type: irType, keyType, valueType } : undefined;
+    }
+
+    const symbol = type.aliasSymbol || type.symbol;
+    if (!(irType instanceof ir.TypeReference) || irType.typeArguments || !symbol ||
+        !(symbol.flags & (ts.SymbolFlags.Interface | ts.SymbolFlags.TypeAlias))) {
+      return undefined;
+    }
+    const fields: { name: string; optional: boolean; type: ir.IRType }[] = [];
+    for (const prop of properties) {
+      const declaration = prop.valueDeclaration;
+      const fieldType = declaration && ts.isPropertySignature(declaration) && declaration.type ?
+        this.transformTypeNode(declaration.type) :
+        this.typeToIR(checker.getNonNullableType(checker.getTypeOfSymbol(prop)), location);
+      if (!(prop.flags & ts.SymbolFlags.Property) || !fieldType || fieldType instanceof ir.FunctionType) {
+        return undefined;
+      }
+      fields.push({ name: prop.name, optional: (prop.flags & ts.SymbolFlags.Optional) !== 0, type: fieldType });
+    }
+    return { kind: 'struct', type: irType, fields };
+  }
+
+  /**
+   * Object 的靜態方法標記 objectMethod，由 GoCodeGenerator 依引數的形狀降階：
+   *   keys / values / entries：{ method, shape }，shape 為 map 或 struct
+   *   assign：{ method, shape, fresh, sources }，shape 為目標的形狀；目標為 {} 時 fresh 並取第一個來源的形狀
+   *   fromEntries：{ method, entriesSource, entryType, keyType, valueType }，entriesSource 依引數為
+   *     literal（[key, value] 字面量）、entries（Object.entries）、map（Map）或 array（tuple 陣列，entryType 為元素型別）
+   *   freeze：{ method }，Go 無法凍結值，之後修改凍結物件的位置產生 W4006
+   */
+  private annotateObjectMethod(node: ts.CallExpression, call: ir.CallExpression): void {
+    const checker = this.typeChecker;
+    if (!checker || !ts.isPropertyAccessExpression(node.expression) ||
+        !OBJECT_METHODS.has(node.expression.name.text) || !this.isObjectCall(node, node.expression.name.text) ||
+        node.arguments.length === 0 || node.arguments.some(ts.isSpreadElement)) {
+      return;
+    }
+    const method = node.expression.name.text;
+    const [arg] = node.arguments;
+    const location = this.parser.getSourceLocation(node);
+
+    if (method === 'freeze') {
+      this.checkFrozenMutations(node);
+      call.metadata.set('objectMethod', { method });
+      return;
+    }
+
+    if (method === 'fromEntries') {
+      const [index] = checker.getIndexInfosOfType(checker.getTypeAtLocation(node));
+      const valueType = index && this.typeToIR(index.type, location);
+      if (!valueType) return;
+
+      let entriesSource: string;
+      let entryType: ir.IRType | null | undefined;
+      if (ts.isArrayLiteralExpression(arg) &&
+          arg.elements.every(e => ts.isArrayLiteralExpression(e) && e.elements.length === 2)) {
+        entriesSource = 'literal';
+      } else if (this.isObjectCall(arg, 'entries')) {
+        entriesSource = 'entries';
+      } else if (this.getCollectionType(arg)?.kind === 'map') {
+        entriesSource = 'map';
+      } else {
+        const element = checker.getIndexTypeOfType(checker.getTypeAtLocation(arg), ts.IndexKind.Number);
+        entryType = element && this.typeToIR(element, location);
+        if (!(entryType instanceof ir.TupleType)) return;
+        entriesSource = 'array';
+      }
+      call.metadata.set('objectMethod', {
+        method,
+        entriesSource,
+        entryType: entryType || undefined,
+        keyType: new ir.PrimitiveType('string', location),
+        valueType
+      });
+      return;
+    }
+
+    if (method === 'assign') {
+      const sources = node.arguments.slice(1).map(source => this.getObjectShape(source));
+      const fresh = ts.isObjectLiteralExpression(arg) && arg.properties.length === 0 && sources.length > 0;
+      const shape = fresh ? sources[0] : this.getObjectShape(arg);
+      if (!shape || shape.kind === 'literal' ||
+          !sources.every(source => source && (source.kind === 'literal' || source.kind === shape.kind))) {
+        return;
+      }
+      call.metadata.set('objectMethod', { method, shape, fresh, sources });
+      return;
+    }
+
+    const shape = this.getObjectShape(arg);
+    if (shape && shape.kind !== 'literal') {
+      call.metadata.set('objectMethod', { method, shape });
+    }
+  }
+
+  /**
+   * Object.freeze 於 Go 沒有對應，凍結的物件之後被修改時產生 W4006 警告（JavaScript 於 strict mode 拋出 TypeError）。
+   * 凍結的對象為 const x = Object.freeze(...) 宣告的變數，或 Object.freeze(x) 的引數 x（僅檢查其後的使用）
+   */
+  private checkFrozenMutations(node: ts.CallExpression): void {
+    const checker = this.typeChecker!;
+    let parent = node.parent;
+    while (ts.isParenthesizedExpression(parent) || ts.isAsExpression(parent)) {
+      parent = parent.parent;
+    }
+    const target = ts.isVariableDeclaration(parent) && ts.isIdentifier(parent.name) ? parent.name :
+      ts.isIdentifier(node.arguments[0]) ? node.arguments[0] : undefined;
+    const symbol = target && checker.getSymbolAtLocation(target);
+    if (!target || !symbol) return;
+
+    const visit = (child: ts.Node): void => {
+      if (ts.isIdentifier(child) && child.text === target.text && child.pos >= node.end &&
+          checker.getSymbolAtLocation(child) === symbol) {
+        const mutation = this.getMutation(child);
+        if (mutation) {
+          this.diagnostics.push({
+            code: 'W4006',
+            message: `'${target.text}' is frozen with Object.freeze(), which Go cannot enforce; this mutation succeeds`,
+            location: this.parser.getSourceLocation(mutation),
+            severity: 'warning',
+            hint: 'Modify a copy of the object, or remove Object.freeze() if it is meant to change'
+          });
+        }
+      }
+      ts.forEachChild(child, visit);
+    };
+    visit(node.getSourceFile());
+  }
+
+  /**
+   * 經由 identifier 修改其屬性或元素的運算式：賦值、++ / --、delete 與原地修改陣列的方法
+   */
+  private getMutation(identifier: ts.Identifier): ts.Node | undefined {
+    const access = identifier.parent;
+    if (!(ts.isPropertyAccessExpression(access) || ts.isElementAccessExpression(access)) ||
+        access.expression !== identifier) {
+      return undefined;
+    }
+
+    const parent = access.parent;
+    if (ts.isBinaryExpression(parent) && parent.left === access &&
+        parent.operatorToken.kind >= ts.SyntaxKind.FirstAssignment &&
+        parent.operatorToken.kind <= ts.SyntaxKind.LastAssignment) {
+      return parent;
+    }
+    if ((ts.isPrefixUnaryExpression(parent) || ts.isPostfixUnaryExpression(parent)) &&
+        (parent.operator === ts.SyntaxKind.PlusPlusToken || parent.operator === ts.SyntaxKind.MinusMinusToken)) {
+      return parent;
+    }
+    if (ts.isDeleteExpression(parent)) {
+      return parent;
+    }
+    if (ts.isPropertyAccessExpression(access) && MUTATING_ARRAY_METHODS.has(access.name.text) &&
+        ts.isCallExpression(parent) && parent.expression === access &&
+        this.isArrayType(this.typeChecker!.getNonNullableType(this.typeChecker!.getTypeAtLocation(identifier)))) {
+      return parent;
+    }
+    return undefined;
+  }
+
+  /**
+   * 正規表達式字面量的旗標；識別字則取其 const 宣告的初始值，無法得知時為空字串
+   */
+  private getRegexFlags(node: ts.Expression): string {
+    if (ts.isIdentifier(node) && this.typeChecker) {
+      const declaration = this.typeChecker.getSymbolAtLocation(node)?.valueDeclaration;
+      if (declaration && ts.isVariableDeclaration(declaration) && declaration.initializer &&
+          ts.getCombinedNodeFlags(declaration) & ts.NodeFlags.Const) {
+        return this.getRegexFlags(declaration.initializer);
+      }
+    }
+    if (ts.isRegularExpressionLiteral(node)) {
+      return node.text.slice(node.text.lastIndexOf('/') + 1);
+    }
+    if (ts.isNewExpression(node) && ts.isIdentifier(node.expression) && node.expression.text === 'RegExp') {
+      const flags = node.arguments?.[1];
+      return flags && ts.isStringLiteralLike(flags) ? flags.text : '';
+    }
+    return '';
+  }
+
+  // ============= Overloads =============
+
+  /**
+   * 函式或方法的多載集合：有本體的實作與其前的多載簽名；非多載回傳 undefined
+   */
+  private getOverloadSet(declaration: ts.Declaration): OverloadSet | undefined {
+    if (!this.typeChecker) return undefined;
+    if (!(ts.isFunctionDeclaration(declaration) || ts.isMethodDeclaration(declaration)) || !declaration.name) {
+      return undefined;
+    }
+
+    const symbol = this.typeChecker.getSymbolAtLocation(declaration.name);
+    const declarations = (symbol?.declarations || []).filter(
+      (d): d is ts.FunctionDeclaration | ts.MethodDeclaration => ts.isFunctionDeclaration(d) || ts.isMethodDeclaration(d)
+    );
+    const implementation = declarations.find(d => !!d.body);
+    const signatures = declarations.filter(d => !d.body);
+    if (!implementation || signatures.length === 0) return undefined;
+    return { implementation, signatures };
+  }
+
+  /**
+   * 多載在 Go 端的名稱：每個簽名為加上後綴的變體（parseString、parseNumber），實作為 parseImpl
+   * 後綴取自各簽名間不同的參數型別；只出現在部分簽名的參數以 With + 參數名稱表示（fetchWithOptions）
+   */
+  private getOverloadedName(declaration: ts.Declaration): string | undefined {
+    const set = this.getOverloadSet(declaration);
+    if (!set) return undefined;
+
+    const decl = declaration as ts.FunctionDeclaration | ts.MethodDeclaration;
+    const base = this.getPropertyName(decl.name!);
+    if (!base) return undefined;
+    if (decl === set.implementation) return `${base}Impl`;
+
+    const checker = this.typeChecker!;
+    const typeText = (param: ts.ParameterDeclaration) => checker.typeToString(checker.getTypeAtLocation(param));
+    const minArity = Math.min(...set.signatures.map(sig => sig.parameters.length));
+    const names = set.signatures.map(sig => base + sig.parameters.map((param, i) => {
+      if (i >= minArity) {
+        return `With${this.capitalizeName(ts.isIdentifier(param.name) ? param.name.text : 'options')}`;
+      }
+      const types = new Set(set.signatures.map(other => typeText(other.parameters[i])));
+      if (types.size === 1) return '';
+      return typeText(param).replace(/\[\]/g, 'Array').split(/[^A-Za-z0-9]+/)
+        .filter(Boolean).map(part => this.capitalizeName(part)).join('');
+    }).join(''));
+
+    // 仍然相同的名稱以序號區分
+    const index = set.signatures.indexOf(decl);
+    const name = names[index];
+    return names.filter(n => n === name).length > 1 ? `${name}${index + 1}` : name;
+  }
+
+  private capitalizeName(name: string): string {
+    return name.charAt(0).toUpperCase() + name.slice(1);
+  }
+
+  /**
+   * 變體沿用簽名的修飾詞，實作為 async 時一併標記；實作本身不匯出
+   */
+  private getOverloadModifiers(
+    node: ts.FunctionDeclaration | ts.MethodDeclaration,
+    set: OverloadSet | undefined
+  ): ir.Modifier[] {
+    const modifiers = this.getModifiers(node);
+    if (!set) return modifiers;
+    if (node === set.implementation) {
+      return modifiers.filter(m => m.kind !== 'export' && m.kind !== 'default');
+    }
+    if (this.isAsyncFunction(set.implementation) && !modifiers.some(m => m.kind === 'async')) {
+      modifiers.push(new ir.Modifier('async'));
+    }
+    return modifiers;
+  }
+
+  /**
+   * 多載變體的本體：以簽名的參數呼叫共用的實作
+   *   func ParseString(x string) A { return parseImpl(x).(A) }
+   */
+  private createOverloadBody(
+    signature: ts.FunctionDeclaration | ts.MethodDeclaration,
+    set: OverloadSet
+  ): ir.BlockStatement {
+    const location = this.parser.getSourceLocation(signature);
+    const implName = this.getOverloadedName(set.implementation)!;
+    const callee = ts.isMethodDeclaration(signature) ?
+      new ir.MemberExpression(new ir.Identifier('this', location), new ir.Identifier(implName, location), false, false, location) :
+      new ir.Identifier(implName, location);
+
+    const args = signature.parameters.map(param => {
+      const name = new ir.Identifier(ts.isIdentifier(param.name) ? param.name.text : 'options', location);
+      return param.dotDotDotToken ? new ir.SpreadElement(name, location) : name;
+    });
+    const call = new ir.CallExpression(callee, args, undefined, location);
+
+    const implementationKey = this.getCalleeKey(set.implementation);
+    if (implementationKey) {
+      call.metadata.set('callee', implementationKey);
+    }
+    if (this.isAsyncFunction(set.implementation)) {
+      call.metadata.set('asyncCall', true);
+      call.metadata.set('promiseValue', true);
+    }
+    if (set.implementation.parameters.some(p => p.questionToken || p.initializer)) {
+      call.metadata.set('optionalArgs', {
+        name: implName,
+        owner: ts.isClassLike(set.implementation.parent) ? set.implementation.parent.name?.text : undefined,
+        parameters: set.implementation.parameters.map(p => this.transformParameter(p)),
+        passThrough: signature.parameters.map(p => !!p.questionToken)
+      });
+    }
+
+    // 實作回傳較寬的型別（A | B）時，轉回簽名的回傳型別
+    const checker = this.typeChecker!;
+    const signatureReturn = checker.getSignatureFromDeclaration(signature)?.getReturnType();
+    const implementationReturn = checker.getSignatureFromDeclaration(set.implementation)?.getReturnType();
+    if (signatureReturn && implementationReturn && this.isInterfaceLike(implementationReturn) &&
+        checker.typeToString(signatureReturn) !== checker.typeToString(implementationReturn)) {
+      const resultType = this.typeToIR(signatureReturn, location);
+      if (resultType) {
+        call.metadata.set('resultAssertion', resultType);
+      }
+    }
+
+    const isVoid = !!signatureReturn && (signatureReturn.flags & ts.TypeFlags.Void) !== 0;
+    return new ir.BlockStatement(
+      [isVoid ? new ir.ExpressionStatement(call, location) : new ir.ReturnStatement(call, location)],
+      location
+    );
+  }
+
+  /**
+   * 型別在 Go 端是否為 interface{}：any / unknown，或由不同基本型別組成的 union
+   * （boolean 與同類字面量的 union 會退化為基本型別）
+   */
+  private isInterfaceLike(type: ts.Type): boolean {
+    if (type.flags & (ts.TypeFlags.Any | ts.TypeFlags.Unknown)) return true;
+    if (!type.isUnion()) return false;
+    // T | undefined 為指標
+    const members = type.types.filter(t => !(t.flags & (ts.TypeFlags.Null | ts.TypeFlags.Undefined)));
+    if (members.length <= 1) return false;
+    const kinds = [ts.TypeFlags.StringLike, ts.TypeFlags.NumberLike, ts.TypeFlags.BooleanLike];
+    return !kinds.some(kind => members.every(t => (t.flags & kind) !== 0));
+  }
+
+  /**
+   * 呼叫解析到多載簽名時改呼叫對應的變體
+   */
+  private transformCallee(node: ts.CallExpression): ir.Expression {
+    const callee = this.transformExpression(node.expression);
+    const declaration = this.typeChecker?.getResolvedSignature(node)?.declaration;
+    const variant = declaration && !ts.isJSDocSignature(declaration) ? this.getOverloadedName(declaration) : undefined;
+    if (!variant) return callee;
+
+    if (callee instanceof ir.Identifier) {
+      return new ir.Identifier(variant, callee.location);
+    }
+    if (callee instanceof ir.MemberExpression && !callee.computed) {
+      return new ir.MemberExpression(callee.object, new ir.Identifier(variant, callee.property.location),
+        false, callee.optional, callee.location);
+    }
+    return callee;
+  }
+
+  // ============= Async =============
+
+  private transformAwaitExpression(node: ts.AwaitExpression): ir.AwaitExpression {
+    const location = this.parser.getSourceLocation(node);
+    const awaitExpr = new ir.AwaitExpression(this.transformExpression(node.expression), location);
+
+    if (this.typeChecker) {
+      const awaitedType = this.typeToIR(this.typeChecker.getTypeAtLocation(node), location);
+      if (awaitedType) {
+        awaitExpr.metadata.set('awaitedType', awaitedType);
+      }
+    }
+
+    return awaitExpr;
+  }
+
+  /**
+   * 轉換 return 的值；值為 Promise 時標記 promiseValue
+   * async 函式 return 一個 Promise 會被攤平，Go 端需等待其結果
+   */
+  private transformPromiseResult(node: ts.Expression): ir.Expression {
+    const expr = this.transformExpression(node);
+    if (this.typeChecker && this.getPromiseValueType(this.typeChecker.getTypeAtLocation(node))) {
+      expr.metadata.set('promiseValue', true);
+    }
+    return expr;
+  }
+
+  /**
+   * 標記 Promise 相關的呼叫，供 GoCodeGenerator 依 asyncStrategy 降階
+   * - asyncCall: 呼叫 async 函式，Go 端需傳入 ctx
+   * - callee: 同一模組內被呼叫函式的鍵（fetchData、DataService.getData），供 ContextThreadingPass 建立呼叫圖
+   * - promiseMethod: p.then / p.catch / p.finally
+   * - promiseCombinator: Promise.all / allSettled / race / any / resolve / reject
+   */
+  private annotateAsyncCall(node: ts.CallExpression, call: ir.CallExpression): void {
+    if (!this.typeChecker) return;
+    const checker = this.typeChecker;
+
+    const declaration = checker.getResolvedSignature(node)?.declaration;
+    if (declaration && !ts.isJSDocSignature(declaration)) {
+      // 多載簽名本身不帶 async，以實作為準
+      const implementation = this.getOverloadSet(declaration)?.implementation;
+      if (this.isAsyncFunction(implementation || declaration)) {
+        call.metadata.set('asyncCall', true);
+      }
+      const callee = this.getCalleeKey(declaration);
+      if (callee) {
+        call.metadata.set('callee', callee);
+      }
+    }
+
+    if (!ts.isPropertyAccessExpression(node.expression)) return;
+    const method = node.expression.name.text;
+    const receiver = node.expression.expression;
+
+    if (ts.isIdentifier(receiver) && receiver.text === 'Promise') {
+      if (!PROMISE_COMBINATORS.includes(method)) return;
+      // resolve/reject 取結果的值型別，其餘取參數陣列的元素值型別
+      const valueType = method === 'resolve' || method === 'reject' ?
+        this.getPromiseValueType(checker.getTypeAtLocation(node)) :
+        node.arguments.length > 0 ? this.getPromiseElementType(node.arguments[0]) : undefined;
+      call.metadata.set('promiseCombinator', {
+        method,
+        valueType: valueType ? this.typeToIR(valueType, call.location) || undefined : undefined
+      });
+      return;
+    }
+
+    if (method !== 'then' && method !== 'catch' && method !== 'finally') return;
+    const valueType = this.getPromiseValueType(checker.getTypeAtLocation(receiver));
+    const resultType = this.getPromiseValueType(checker.getTypeAtLocation(node));
+    if (!valueType || !resultType) return;
+
+    if (method === 'then' && node.arguments.length > 1) {
+      this.diagnostics.push({
+        code: 'W4003',
+        message: 'The rejection handler of Promise.then is not supported',
+        location: call.location,
+        severity: 'warning',
+        hint: 'Use .catch() for the rejection handler'
+      });
+    }
+
+    call.metadata.set('promiseMethod', {
+      method,
+      valueType: this.typeToIR(valueType, call.location) || new ir.PrimitiveType('any', call.location),
+      resultType: this.typeToIR(resultType, call.location) || new ir.PrimitiveType('any', call.location)
+    });
+  }
+
+  /**
+   * 呼叫圖中被呼叫端的鍵：宣告所在檔案（與 Module.path 相同）與函式鍵，ContextThreadingPass 據此跨檔案比對
+   */
+  private getCalleeKey(node: ts.SignatureDeclaration): string | undefined {
+    const sourceFile = node.getSourceFile();
+    const key = sourceFile.isDeclarationFile ? undefined : this.getFunctionKey(node);
+    return key && `${sourceFile.fileName}#${key}`;
+  }
+
+  /**
+   * 函式於模組內的鍵：函式名稱、類別名稱.方法名稱，或指派函式表達式的變數名稱
+   */
+  private getFunctionKey(node: ts.SignatureDeclaration): string | undefined {
+    if (ts.isFunctionDeclaration(node)) {
+      return this.getOverloadedName(node) || node.name?.text;
+    }
+    if (ts.isMethodDeclaration(node) && ts.isClassDeclaration(node.parent) && node.parent.name) {
+      const name = this.getOverloadedName(node) || this.getPropertyName(node.name);
+      return name ? `${node.parent.name.text}.${name}` : undefined;
+    }
+    if ((ts.isArrowFunction(node) || ts.isFunctionExpression(node)) &&
+        ts.isVariableDeclaration(node.parent) && ts.isIdentifier(node.parent.name)) {
+      return node.parent.name.text;
+    }
+    return undefined;
+  }
+
+  /**
+   * 未標註回傳型別的 async 函式表達式，以推斷的 Promise<T> 作為回傳型別
+   */
+  private inferAsyncReturnType(node: ts.ArrowFunction | ts.FunctionExpression): ir.IRType | undefined {
+    if (!this.typeChecker || !this.isAsyncFunction(node)) return undefined;
+    const signature = this.typeChecker.getSignatureFromDeclaration(node);
+    if (!signature) return undefined;
+    return this.typeToIR(signature.getReturnType(), this.parser.getSourceLocation(node)) || undefined;
+  }
+
+  /**
+   * function* / async function* 標記 generator 資訊：{ async, yieldType }
+   * yieldType 取自回傳型別 Generator<T> / AsyncGenerator<T> 的第一個型別參數
+   */
+  private annotateGenerator<T extends ir.IRNode>(
+    node: ts.FunctionDeclaration | ts.MethodDeclaration | ts.FunctionExpression,
+    irNode: T
+  ): T {
+    if (!node.asteriskToken) return irNode;
+
+    let yieldType: ir.IRType | undefined;
+    const signature = this.typeChecker?.getSignatureFromDeclaration(node);
+    if (signature) {
+      const returnType = signature.getReturnType() as ts.TypeReference;
+      const typeArgs = returnType.target ? this.typeChecker!.getTypeArguments(returnType) : [];
+      if (typeArgs.length > 0) {
+        yieldType = this.typeToIR(typeArgs[0], irNode.location) || undefined;
+      }
+    }
+
+    irNode.metadata.set('generator', { async: this.isAsyncFunction(node), yieldType });
+    return irNode;
+  }
+
+  private isAsyncFunction(node: ts.SignatureDeclaration): boolean {
+    return ts.canHaveModifiers(node) &&
+      !!ts.getModifiers(node)?.some(m => m.kind === ts.SyntaxKind.AsyncKeyword);
+  }
+
+  /**
+   * 取得 Promise<T> 的 T；非 Promise 時回傳 undefined
+   */
+  private getPromiseValueType(type: ts.Type): ts.Type | undefined {
+    if (type.symbol?.name !== 'Promise' || !(type.flags & ts.TypeFlags.Object)) return undefined;
+    if (!((type as ts.ObjectType).objectFlags & ts.ObjectFlags.Reference)) return undefined;
+    return this.typeChecker!.getTypeArguments(type as ts.TypeReference)[0];
+  }
+
+  /**
+   * 取得 Promise 陣列（或元素型別皆相同的 tuple）的元素值型別
+   */
+  private getPromiseElementType(node: ts.Expression): ts.Type | undefined {
+    const checker = this.typeChecker!;
+    const type = checker.getTypeAtLocation(node);
+    if (!(type.flags & ts.TypeFlags.Object) ||
+        !((type as ts.ObjectType).objectFlags & ts.ObjectFlags.Reference)) {
+      return undefined;
+    }
+
+    const elements = checker.getTypeArguments(type as ts.TypeReference);
+    const valueTypes = elements.map(t => this.getPromiseValueType(t) || t);
+    if (valueTypes.length === 0 || valueTypes.some(t => t !== valueTypes[0])) return undefined;
+    return valueTypes[0];
+  }
+
+  /**
+   * errgroup 模式下，緊接在 Promise.all/allSettled 前且僅在其中使用一次的
+   * `const promises = urls.map(...)` 併入呼叫，使其能降階為 errgroup
+   */
+  private collectInlinedPromiseArrays(statements: ts.NodeArray<ts.Statement>): Set<ts.Statement> {
+    const skipped = new Set<ts.Statement>();
+    if (this.options.asyncStrategy !== 'errgroup' || !this.typeChecker) return skipped;
+    const checker = this.typeChecker;
+
+    for (let i = 0; i + 1 < statements.length; i++) {
+      const stmt = statements[i];
+      if (!ts.isVariableStatement(stmt) ||
+          !(stmt.declarationList.flags & ts.NodeFlags.Const) ||
+          stmt.declarationList.declarations.length !== 1) {
+        continue;
+      }
+      const decl = stmt.declarationList.declarations[0];
+      if (!ts.isIdentifier(decl.name) || !decl.initializer) continue;
+
+      const symbol = checker.getSymbolAtLocation(decl.name);
+      const references: ts.Identifier[] = [];
+      const visit = (node: ts.Node): void => {
+        if (ts.isIdentifier(node) && node !== decl.name && checker.getSymbolAtLocation(node) === symbol) {
+          references.push(node);
+        }
+        ts.forEachChild(node, visit);
+      };
+      statements.slice(i + 1).forEach(visit);
+
+      const reference = references[0];
+      if (references.length !== 1 || !this.isCombinatorArgument(reference, ['all', 'allSettled']) ||
+          !this.isWithin(reference, statements[i + 1])) {
+        continue;
+      }
+
+      this.inlinedPromiseArrays.set(reference, decl.initializer);
+      skipped.add(stmt);
+    }
+
+    return skipped;
+  }
+
+  private isCombinatorArgument(node: ts.Node, methods: string[]): boolean {
+    const call = node.parent;
+    return !!call && ts.isCallExpression(call) && call.arguments[0] === node &&
+      ts.isPropertyAccessExpression(call.expression) &&
+      ts.isIdentifier(call.expression.expression) &&
+      call.expression.expression.text === 'Promise' &&
+      methods.includes(call.expression.name.text);
+  }
+
+  private isWithin(node: ts.Node, ancestor: ts.Node): boolean {
+    for (let current: ts.Node | undefined = node; current; current = current.parent) {
+      if (current === ancestor) return true;
+    }
+    return false;
+  }
+
+  private transformNewExpression(node: ts.NewExpression): ir.Expression {
+    // new RegExp('...', 'g') 的字面量 pattern 與字面量提升方式相同；動態 pattern 執行時編譯
+    if (ts.isIdentifier(node.expression) && node.expression.text === 'RegExp' && node.arguments?.length) {
+      const [pattern, flags] = node.arguments;
+      const flagsText = !flags ? '' : ts.isStringLiteralLike(flags) ? flags.text : undefined;
+      if (ts.isStringLiteralLike(pattern) && flagsText !== undefined) {
+        // 不含跳脫的字串引數，pattern 中的位置可對應回原始碼
+        const raw = pattern.getText().slice(1, -1) === pattern.text;
+        return this.hoistRegExp(pattern.text, flagsText, pattern, raw ? pattern.getStart() + 1 : undefined);
+      }
+      // Go 的 *regexp.Regexp 不可變，複製 RegExp 時直接沿用
+      if (!flags && this.typeChecker?.getTypeAtLocation(pattern).symbol?.name === 'RegExp') {
+        return this.transformExpression(pattern);
+      }
+      const newRegExp = new ir.NewExpression(
+        this.transformExpression(node.expression),
+        [this.transformExpression(pattern)],
+        undefined,
+        this.parser.getSourceLocation(node)
+      );
+      // 旗標轉為 pattern 開頭的 (?i) 等；非字面量的旗標無法轉換
+      newRegExp.metadata.set('regexpFlags', flagsText ? translateRegExp('', flagsText).source : '');
+      return newRegExp;
+    }
+
+    const typeArguments = node.typeArguments?.map(t => this.transformTypeNode(t));
+
+    const newExpression = new ir.NewExpression(
+      this.transformExpression(node.expression),
+      node.arguments?.map(arg => this.transformExpression(arg)) || [],
+      typeArguments,
+      this.parser.getSourceLocation(node)
+    );
+    if (ts.isIdentifier(node.expression) && node.expression.text === 'Date') {
+      const kind = this.getDateConstructor(node.arguments || []);
+      newExpression.metadata.set('dateConstructor', kind);
+      if (kind === 'string') {
+        this.checkDateParse(node, node.arguments![0], newExpression);
+      }
+    }
+    if (ts.isIdentifier(node.expression) && COLLECTION_TYPES.has(node.expression.text)) {
+      this.annotateCollectionConstructor(node, node.expression.text, newExpression);
+    }
+    this.annotateOptionalArgs(node, newExpression);
+    return newExpression;
+  }
+
+  private transformPropertyAccess(node: ts.PropertyAccessExpression): ir.MemberExpression {
+    const member = new ir.MemberExpression(
+      this.transformExpression(node.expression),
+      new ir.Identifier(node.name.text),
+      false,
+      !!node.questionDotToken,
+      this.parser.getSourceLocation(node)
+    );
+
+    // 記錄物件的 union 別名，供 DiscriminatedUnionPass 辨識判別欄位的存取
+    const unionType = this.getUnionAliasName(node.expression);
+    if (unionType) {
+      member.metadata.set('unionType', unionType);
+    }
+
+    // 結構型別限制於 Go 中降階為 getter 介面，欄位改以 getter 讀取
+    if (this.isConstraintPropertyAccess(node)) {
+      member.metadata.set('constraintGetter', true);
+    }
+
+    // 字串的 length 依 stringFidelity 為 len(s) 或 UTF-16 長度
+    if (node.name.text === 'length' && this.isStringType(node.expression)) {
+      member.metadata.set('stringLength', true);
+    }
+
+    // Map / Set 的 size
+    const collection = node.name.text === 'size' ? this.getCollectionType(node.expression) : undefined;
+    if (collection) {
+      member.metadata.set('collectionSize', collection);
+    }
+
+    // AbortSignal 對應 context.Context，其成員由 GoCodeGenerator 改寫
+    if (this.typeChecker?.getTypeAtLocation(node.expression).symbol?.name === 'AbortSignal') {
+      member.metadata.set('abortSignal', true);
+    }
+
+    return member;
+  }
+
+  /**
+   * 正規表達式提升為套件層級的 regexp.MustCompile 變數，相同的 pattern 與旗標共用一個變數；
+   * RE2 無法表達的構造（lookaround、反向參照等）回報 E3001，位置指向字面量中的該構造。
+   * patternStart 為 pattern 於檔案中的起點，未提供時（字串引數含跳脫，位置無法對應）指向整個節點
+   */
+  private hoistRegExp(pattern: string, flags: string, node: ts.Node, patternStart?: number): ir.Identifier {
+    const location = this.parser.getSourceLocation(node);
+    const translated = translateRegExp(pattern, flags);
+
+    for (const issue of translated.issues) {
+      this.diagnostics.push({
+        code: 'E3001',
+        message: issue.message,
+        location: patternStart === undefined ? location : this.parser.getRangeLocation(
+          node.getSourceFile(), patternStart + issue.offset, patternStart + issue.offset + issue.length
+        ),
+        severity: 'error',
+        hint: issue.hint
+      });
+    }
+
+    // g 旗標不影響 Go 的 pattern（僅決定方法的對應），/x/ 與 /x/g 共用同一個變數
+    let hoisted = this.hoistedRegExps.get(translated.source);
+    if (!hoisted) {
+      hoisted = { name: `regex${this.hoistedRegExps.size + 1}`, source: translated.source };
+      this.hoistedRegExps.set(translated.source, hoisted);
+    }
+    return new ir.Identifier(hoisted.name, location);
+  }
+
+  /**
+   * 取得表達式（於此位置窄化後）型別的 union 別名名稱
+   */
+  private getUnionAliasName(node: ts.Expression): string | undefined {
+    if (!this.typeChecker) return undefined;
+    const type = this.typeChecker.getTypeAtLocation(node);
+    return type.isUnion() && type.aliasSymbol ? type.aliasSymbol.name : undefined;
+  }
+
+  private transformElementAccess(node: ts.ElementAccessExpression): ir.MemberExpression {
+    return new ir.MemberExpression(
+      this.transformExpression(node.expression),
+      this.transformExpression(node.argumentExpression!),
+      true,
+      !!node.questionDotToken,
+      this.parser.getSourceLocation(node)
+    );
+  }
 
   private transformBinaryExpression(node: ts.BinaryExpression): ir.Expression {
     const op = this.getBinaryOperator(node.operatorToken.kind);
     if (this.isAssignmentOperator(op)) {
-      return new ir.AssignmentExpression(
+      const assignment = new ir.AssignmentExpression(
         op as ir.AssignmentOperator,
         this.transformExpression(node.left as ts.Expression),
         this.transformExpression(node.right),
         this.parser.getSourceLocation(node)
       );
+      if (NUMERIC_OPERATORS.has(op.slice(0, -1)) && this.isNumberType(node.left) && this.isNumberType(node.right)) {
+        assignment.metadata.set('numericOperands', true);
+      }
+      return assignment;
     }
-    return new ir.BinaryExpression(
+    const binary = new ir.BinaryExpression(
       op as ir.BinaryOperator,
       this.transformExpression(node.left as ts.Expression),
       this.transformExpression(node.right),
       this.parser.getSourceLocation(node)
     );
+    // % 與位元運算於 Go 需依 number 的對應型別降階；enum 等具名整數型別沿用 Go 的運算子
+    if (NUMERIC_OPERATORS.has(op) && this.isNumberType(node.left) && this.isNumberType(node.right)) {
+      binary.metadata.set('numericOperands', true);
+    }
+    // Date 的算術與比較透過 valueOf() 以毫秒數進行，標記哪一側為 Date
+    if (DATE_VALUE_OPERATORS.has(op)) {
+      const dateOperands = [this.isDateType(node.left), this.isDateType(node.right)];
+      if (dateOperands.some(Boolean)) {
+        binary.metadata.set('dateOperands', dateOperands);
+      }
+    }
+    return binary;
   }
 
   private transformUnaryExpression(node: ts.UnaryExpression): ir.UnaryExpression {
@@ -1140,12 +3127,17 @@ export class IRTransformer {
     const operand = ts.isPrefixUnaryExpression(node) || ts.isPostfixUnaryExpression(node) ?
       (node as any).operand :
       (node as ts.PrefixUnaryExpression).operand;
-    return new ir.UnaryExpression(
+    const unary = new ir.UnaryExpression(
       op,
       this.transformExpression(operand),
       ts.isPrefixUnaryExpression(node),
       this.parser.getSourceLocation(node)
     );
+    // +date、-date 以毫秒數運算
+    if ((op === '+' || op === '-') && this.isDateType(operand)) {
+      unary.metadata.set('dateOperand', true);
+    }
+    return unary;
   }
 
   private transformConditionalExpression(node: ts.ConditionalExpression): ir.ConditionalExpression {
@@ -1160,12 +3152,12 @@ export class IRTransformer {
   private transformArrowFunction(node: ts.ArrowFunction): ir.ArrowFunctionExpression {
     const body = ts.isBlock(node.body)
       ? this.transformBlock(node.body)
-      : this.transformExpression(node.body);
+      : this.transformPromiseResult(node.body);
 
     return new ir.ArrowFunctionExpression(
       node.parameters.map(p => this.transformParameter(p)),
       body,
-      node.type ? this.transformTypeNode(node.type) : undefined,
+      node.type ? this.transformTypeNode(node.type) : this.inferAsyncReturnType(node),
       node.typeParameters?.map(tp => this.transformTypeParameter(tp)),
       !!node.modifiers?.some(m => m.kind === ts.SyntaxKind.AsyncKeyword),
       this.parser.getSourceLocation(node)
@@ -1173,15 +3165,15 @@ export class IRTransformer {
   }
 
   private transformFunctionExpression(node: ts.FunctionExpression): ir.FunctionExpression {
-    return new ir.FunctionExpression(
+    return this.annotateGenerator(node, new ir.FunctionExpression(
       node.parameters.map(p => this.transformParameter(p)),
       node.body ? this.transformBlock(node.body) : new ir.BlockStatement([]),
-      node.type ? this.transformTypeNode(node.type) : undefined,
+      node.type ? this.transformTypeNode(node.type) : this.inferAsyncReturnType(node),
       node.typeParameters?.map(tp => this.transformTypeParameter(tp)),
       !!node.modifiers?.some(m => m.kind === ts.SyntaxKind.AsyncKeyword),
       node.name?.text,
       this.parser.getSourceLocation(node)
-    );
+    ));
   }
 
   private transformTemplateExpression(node: ts.TemplateExpression): ir.TemplateLiteral {
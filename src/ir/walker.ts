@@ -0,0 +1,310 @@
+/**
+ * IR Walker
+ * 完整走訪 IR 樹（含型別位置）的基底 visitor，供各 pass 繼承並覆寫感興趣的節點
+ */
+
+import * as ir from './nodes';
+
+export class IRWalker implements ir.IRVisitor<void> {
+  /**
+   * 走訪可能為空的節點
+   */
+  protected walk(node?: ir.IRNode | null): void {
+    if (node) {
+      node.accept(this);
+    }
+  }
+
+  protected walkAll(nodes?: (ir.IRNode | null)[]): void {
+    if (!nodes) return;
+    for (const node of nodes) {
+      this.walk(node);
+    }
+  }
+
+  // ============= Types =============
+
+  visitPrimitiveType(node: ir.PrimitiveType): void {}
+
+  visitArrayType(node: ir.ArrayType): void {
+    this.walk(node.elementType);
+  }
+
+  visitTupleType(node: ir.TupleType): void {
+    this.walkAll(node.elements);
+  }
+
+  visitObjectType(node: ir.ObjectType): void {
+    this.walkAll(node.properties);
+    this.walk(node.indexSignature);
+  }
+
+  visitFunctionType(node: ir.FunctionType): void {
+    this.walkAll(node.typeParameters);
+    this.walkAll(node.parameters);
+    this.walk(node.returnType);
+  }
+
+  visitUnionType(node: ir.UnionType): void {
+    this.walkAll(node.types);
+  }
+
+  visitIntersectionType(node: ir.IntersectionType): void {
+    this.walkAll(node.types);
+  }
+
+  visitTypeReference(node: ir.TypeReference): void {
+    this.walkAll(node.typeArguments);
+  }
+
+  visitLiteralType(node: ir.LiteralType): void {}
+
+  visitPropertySignature(node: ir.PropertySignature): void {
+    this.walk(node.type);
+  }
+
+  visitIndexSignature(node: ir.IndexSignature): void {
+    this.walk(node.keyType);
+    this.walk(node.valueType);
+  }
+
+  // ============= Declarations =============
+
+  visitVariableDeclaration(node: ir.VariableDeclaration): void {
+    this.walk(node.type);
+    this.walk(node.initializer);
+  }
+
+  visitFunctionDeclaration(node: ir.FunctionDeclaration): void {
+    this.walkAll(node.typeParameters);
+    this.walkAll(node.parameters);
+    this.walk(node.returnType);
+    this.walk(node.body);
+  }
+
+  visitClassDeclaration(node: ir.ClassDeclaration): void {
+    this.walkAll(node.typeParameters);
+    this.walk(node.extendsClause);
+    this.walkAll(node.implementsClause);
+    this.walkAll(node.members);
+  }
+
+  visitInterfaceDeclaration(node: ir.InterfaceDeclaration): void {
+    this.walkAll(node.typeParameters);
+    this.walkAll(node.extendsClause);
+    this.walkAll(node.members);
+  }
+
+  visitTypeAliasDeclaration(node: ir.TypeAliasDeclaration): void {
+    this.walkAll(node.typeParameters);
+    this.walk(node.type);
+  }
+
+  visitEnumDeclaration(node: ir.EnumDeclaration): void {
+    this.walkAll(node.members);
+  }
+
+  visitParameter(node: ir.Parameter): void {
+    this.walk(node.type);
+    this.walk(node.defaultValue);
+  }
+
+  visitTypeParameter(node: ir.TypeParameter): void {
+    this.walk(node.constraint);
+    this.walk(node.defaultType);
+  }
+
+  visitPropertyMember(node: ir.PropertyMember): void {
+    this.walk(node.type);
+    this.walk(node.initializer);
+  }
+
+  visitMethodMember(node: ir.MethodMember): void {
+    this.walkAll(node.typeParameters);
+    this.walkAll(node.parameters);
+    this.walk(node.returnType);
+    this.walk(node.body);
+  }
+
+  visitEnumMember(node: ir.EnumMember): void {
+    this.walk(node.value);
+  }
+
+  // ============= Statements =============
+
+  visitBlockStatement(node: ir.BlockStatement): void {
+    this.walkAll(node.statements);
+  }
+
+  visitExpressionStatement(node: ir.ExpressionStatement): void {
+    this.walk(node.expression);
+  }
+
+  visitReturnStatement(node: ir.ReturnStatement): void {
+    this.walk(node.argument);
+  }
+
+  visitIfStatement(node: ir.IfStatement): void {
+    this.walk(node.test);
+    this.walk(node.consequent);
+    this.walk(node.alternate);
+  }
+
+  visitWhileStatement(node: ir.WhileStatement): void {
+    this.walk(node.test);
+    this.walk(node.body);
+  }
+
+  visitForStatement(node: ir.ForStatement): void {
+    this.walk(node.init);
+    this.walk(node.test);
+    this.walk(node.update);
+    this.walk(node.body);
+  }
+
+  visitForOfStatement(node: ir.ForOfStatement): void {
+    this.walk(node.left);
+    this.walk(node.right);
+    this.walk(node.body);
+  }
+
+  visitTryStatement(node: ir.TryStatement): void {
+    this.walk(node.block);
+    this.walk(node.handler);
+    this.walk(node.finalizer);
+  }
+
+  visitCatchClause(node: ir.CatchClause): void {
+    this.walk(node.param);
+    this.walk(node.body);
+  }
+
+  visitThrowStatement(node: ir.ThrowStatement): void {
+    this.walk(node.argument);
+  }
+
+  visitSwitchStatement(node: ir.SwitchStatement): void {
+    this.walk(node.discriminant);
+    this.walkAll(node.cases);
+  }
+
+  visitSwitchCase(node: ir.SwitchCase): void {
+    this.walk(node.test);
+    this.walkAll(node.consequent);
+  }
+
+  // ============= Expressions =============
+
+  visitIdentifier(node: ir.Identifier): void {}
+
+  visitLiteral(node: ir.Literal): void {}
+
+  visitArrayExpression(node: ir.ArrayExpression): void {
+    this.walkAll(node.elements);
+  }
+
+  visitObjectExpression(node: ir.ObjectExpression): void {
+    this.walkAll(node.properties);
+  }
+
+  visitProperty(node: ir.Property): void {
+    if (node.computed) {
+      this.walk(node.key);
+    }
+    this.walk(node.value);
+  }
+
+  visitFunctionExpression(node: ir.FunctionExpression): void {
+    this.walkAll(node.typeParameters);
+    this.walkAll(node.parameters);
+    this.walk(node.returnType);
+    this.walk(node.body);
+  }
+
+  visitArrowFunctionExpression(node: ir.ArrowFunctionExpression): void {
+    this.walkAll(node.typeParameters);
+    this.walkAll(node.parameters);
+    this.walk(node.returnType);
+    this.walk(node.body);
+  }
+
+  visitCallExpression(node: ir.CallExpression): void {
+    this.walk(node.callee);
+    this.walkAll(node.typeArguments);
+    this.walkAll(node.args);
+  }
+
+  visitMemberExpression(node: ir.MemberExpression): void {
+    this.walk(node.object);
+    if (node.computed) {
+      this.walk(node.property);
+    }
+  }
+
+  visitNewExpression(node: ir.NewExpression): void {
+    this.walk(node.callee);
+    this.walkAll(node.typeArguments);
+    this.walkAll(node.args);
+  }
+
+  visitSuperExpression(node: ir.SuperExpression): void {
+    this.walkAll(node.args);
+  }
+
+  visitBinaryExpression(node: ir.BinaryExpression): void {
+    this.walk(node.left);
+    this.walk(node.right);
+  }
+
+  visitUnaryExpression(node: ir.UnaryExpression): void {
+    this.walk(node.argument);
+  }
+
+  visitAssignmentExpression(node: ir.AssignmentExpression): void {
+    this.walk(node.left);
+    this.walk(node.right);
+  }
+
+  visitConditionalExpression(node: ir.ConditionalExpression): void {
+    this.walk(node.test);
+    this.walk(node.consequent);
+    this.walk(node.alternate);
+  }
+
+  visitAwaitExpression(node: ir.AwaitExpression): void {
+    this.walk(node.argument);
+  }
+
+  visitYieldExpression(node: ir.YieldExpression): void {
+    this.walk(node.argument);
+  }
+
+  visitSpreadElement(node: ir.SpreadElement): void {
+    this.walk(node.argument);
+  }
+
+  visitTemplateLiteral(node: ir.TemplateLiteral): void {
+    this.walkAll(node.expressions);
+  }
+
+  // ============= Module =============
+
+  visitModule(node: ir.Module): void {
+    this.walkAll(node.imports);
+    this.walkAll(node.statements);
+    this.walkAll(node.exports);
+  }
+
+  visitImportDeclaration(node: ir.ImportDeclaration): void {
+    this.walkAll(node.specifiers);
+  }
+
+  visitImportSpecifier(node: ir.ImportSpecifier): void {}
+
+  visitExportDeclaration(node: ir.ExportDeclaration): void {
+    this.walk(node.declaration);
+    this.walkAll(node.specifiers);
+  }
+
+  visitExportSpecifier(node: ir.ExportSpecifier): void {}
+}
@@ -5,10 +5,15 @@
 
 import * as ir from '../ir/nodes';
 import { CompilerOptions } from '../config/options';
+import { SourceLocation } from '../ir/location';
+import { IRWalker } from '../ir/walker';
+import { UtilityTypeKind, UTILITY_TYPE_KINDS, getUtilityTypeName, getLiteralKeys } from '../backend/type-mapper';
 
 export interface OptimizationPass {
   name: string;
   run(module: ir.Module, options: CompilerOptions): ir.Module;
+  /** 需要整個專案資訊的 pass（例如跨檔案的呼叫圖）；未實作時逐一對各模組執行 run */
+  runProject?(modules: ir.Module[], options: CompilerOptions): ir.Module[];
 }
 
 export class IROptimizer {
@@ -24,6 +29,14 @@ export class IROptimizer {
   private initializePasses(): void {
     const level: number = this.options.optimizationLevel !== undefined ? this.options.optimizationLevel : 1;
 
+    // 語義降階（utility types、discriminated unions、泛型限制、ctx 與 error 傳遞、options 設定函式）與優化等級無關，永遠執行
+    this.passes.push(new TypeSimplificationPass());
+    this.passes.push(new DiscriminatedUnionPass());
+    this.passes.push(new GenericConstraintPass());
+    this.passes.push(new ContextThreadingPass());
+    this.passes.push(new ErrorPropagationPass());
+    this.passes.push(new OptionSetterPass());
+
     // Level 0: 不優化
     if (level === 0) return;
 
@@ -35,7 +48,6 @@ export class IROptimizer {
 
     // Level 2: 進階優化
     if (level >= 2) {
-      this.passes.push(new TypeSimplificationPass());
       this.passes.push(new ControlFlowNormalizationPass());
       this.passes.push(new InliningPass());
     }
@@ -57,6 +69,24 @@ export class IROptimizer {
     return optimized;
   }
 
+  /**
+   * 對專案的所有模組執行 passes；每個 pass 處理完所有模組後才執行下一個
+   */
+  optimizeProject(modules: ir.Module[]): ir.Module[] {
+    let optimized = modules;
+
+    for (const pass of this.passes) {
+      if (this.options.verbose) {
+        console.log(`Running optimization pass: ${pass.name}`);
+      }
+      optimized = pass.runProject ?
+        pass.runProject(optimized, this.options) :
+        optimized.map(module => pass.run(module, this.options));
+    }
+
+    return optimized;
+  }
+
   /**
    * 添加自訂優化 pass
    */
@@ -100,6 +130,11 @@ export class DeadCodeEliminationPass implements OptimizationPass {
 
     for (const stmt of module.statements) {
       stmt.accept(visitor);
+      // 具體化的 utility type 會產生與來源 struct 互轉的 helper
+      const utility = stmt.metadata.get('utilityType') as UtilityTypeInfo | undefined;
+      if (utility) {
+        used.add(utility.source);
+      }
     }
 
     return used;
@@ -307,6 +342,9 @@ class SymbolCollector implements ir.IRVisitor<void> {
   visitAwaitExpression(node: ir.AwaitExpression): void {
     node.argument.accept(this);
   }
+  visitYieldExpression(node: ir.YieldExpression): void {
+    node.argument?.accept(this);
+  }
   visitSpreadElement(node: ir.SpreadElement): void {
     node.argument.accept(this);
   }
@@ -338,18 +376,1408 @@ export class ConstantFoldingPass implements OptimizationPass {
   }
 }
 
+/**
+ * Utility type 具體化資訊，記錄於產生的 InterfaceDeclaration metadata ('utilityType')
+ */
+export interface UtilityTypeInfo {
+  kind: UtilityTypeKind;
+  /** 來源 struct 名稱 */
+  source: string;
+  /** 來源 struct 是否為 Readonly 具體化結果（欄位未匯出） */
+  sourceUnexported: boolean;
+  fields: Array<{ name: string; sourceOptional: boolean }>;
+}
+
 /**
  * 型別簡化 Pass
- * 簡化複雜的型別表達式
+ * 將 Partial/Required/Readonly/Pick/Omit 依宣告的介面具體化為具名 struct
  */
 export class TypeSimplificationPass implements OptimizationPass {
   name = 'type-simplification';
 
   run(module: ir.Module, options: CompilerOptions): ir.Module {
-    // TODO: 實作型別簡化
-    // 例如: A | A → A, A & never → never
+    const resolver = new UtilityTypeResolver(module);
+    const statements: ir.Statement[] = [];
+
+    for (const stmt of module.statements) {
+      // type PartialUser = Partial<User> 直接以別名作為 struct 名稱
+      if (stmt instanceof ir.TypeAliasDeclaration &&
+          !(stmt.typeParameters && stmt.typeParameters.length > 0) &&
+          stmt.type instanceof ir.TypeReference) {
+        // 先解析巢狀的 utility type 引用，外層交由 materialize 處理
+        (stmt.type.typeArguments || []).forEach(arg => arg.accept(resolver));
+        const materialized = resolver.materialize(stmt.type, stmt.name, stmt.modifiers, stmt.location);
+        if (materialized) {
+          statements.push(materialized);
+          continue;
+        }
+      }
+
+      stmt.accept(resolver);
+      statements.push(stmt);
+    }
+
+    // 行內使用的 utility types 插入於來源宣告之後
+    for (const decl of resolver.synthesized.values()) {
+      const info = decl.metadata.get('utilityType') as UtilityTypeInfo;
+      let sourceIndex = statements.findIndex(
+        s => s instanceof ir.Declaration && s.name === info.source
+      );
+      if (sourceIndex >= 0) {
+        // 依出現順序排在先前為同一來源產生的宣告之後
+        while (sourceIndex + 1 < statements.length &&
+               resolver.synthesized.has((statements[sourceIndex + 1] as ir.Declaration).name) &&
+               statements[sourceIndex + 1].metadata.get('utilityType')?.source === info.source) {
+          sourceIndex++;
+        }
+        statements.splice(sourceIndex + 1, 0, decl);
+      } else {
+        statements.push(decl);
+      }
+    }
+
+    module.statements = statements;
+    return module;
+  }
+}
+
+/**
+ * 模組內可具體化為 struct 的物件型別
+ */
+interface ObjectTypeInfo {
+  members: ir.PropertySignature[];
+  decl: ir.Declaration;
+}
+
+/**
+ * 收集模組內的物件型別（非泛型、僅含屬性），interface 的 extends 會被展開
+ */
+function collectObjectTypes(module: ir.Module): Map<string, ObjectTypeInfo> {
+  const objectTypes = new Map<string, ObjectTypeInfo>();
+  const interfaces = new Map<string, ir.InterfaceDeclaration>();
+
+  for (const stmt of module.statements) {
+    if (stmt instanceof ir.InterfaceDeclaration && !(stmt.typeParameters && stmt.typeParameters.length > 0)) {
+      interfaces.set(stmt.name, stmt);
+    } else if (stmt instanceof ir.TypeAliasDeclaration && !(stmt.typeParameters && stmt.typeParameters.length > 0) &&
+               stmt.type instanceof ir.ObjectType && !stmt.type.indexSignature) {
+      objectTypes.set(stmt.name, { members: stmt.type.properties, decl: stmt });
+    }
+  }
+
+  const flatten = (decl: ir.InterfaceDeclaration, seen: Set<string>): ir.PropertySignature[] | null => {
+    if (seen.has(decl.name)) return null;
+    seen.add(decl.name);
+
+    const members: ir.PropertySignature[] = [];
+    for (const ext of decl.extendsClause || []) {
+      const parent = interfaces.get(ext.name);
+      const inherited = parent ? flatten(parent, seen) : null;
+      if (!inherited) return null;
+      members.push(...inherited);
+    }
+    for (const member of decl.members) {
+      // 方法與 index signature 無法具體化為資料欄位
+      if (member.type instanceof ir.FunctionType || member.name.startsWith('[')) {
+        return null;
+      }
+      members.push(member);
+    }
+    return members;
+  };
+
+  for (const decl of interfaces.values()) {
+    const members = flatten(decl, new Set());
+    if (members) {
+      objectTypes.set(decl.name, { members, decl });
+    }
+  }
+
+  return objectTypes;
+}
+
+/**
+ * 解析並改寫 utility type 引用
+ */
+class UtilityTypeResolver extends IRWalker {
+  /** 行內引用所產生的 struct（依名稱去重） */
+  synthesized = new Map<string, ir.InterfaceDeclaration>();
+  private objectTypes: Map<string, ObjectTypeInfo>;
+  private keyAliases = new Map<string, ir.IRType>();
+
+  constructor(module: ir.Module) {
+    super();
+    this.objectTypes = collectObjectTypes(module);
+    this.collectDeclarations(module);
+  }
+
+  /**
+   * 行內引用：Partial<User> → UserPartial
+   */
+  visitTypeReference(node: ir.TypeReference): void {
+    super.visitTypeReference(node);
+
+    const resolved = this.resolveUtility(node);
+    if (!resolved) return;
+
+    const name = getUtilityTypeName(resolved.info.kind, resolved.info.source,
+      node.typeArguments && node.typeArguments[1] ? this.getKeys(node.typeArguments[1]) : []);
+    if (!this.synthesized.has(name) && !this.objectTypes.has(name)) {
+      const sourceDecl = this.objectTypes.get(resolved.info.source)?.decl;
+      const decl = this.createDeclaration(name, resolved, sourceDecl ? sourceDecl.modifiers : [], node.location);
+      this.synthesized.set(name, decl);
+      this.objectTypes.set(name, { members: decl.members, decl });
+    }
+
+    node.name = name;
+    node.typeArguments = undefined;
+  }
+
+  /**
+   * 將型別別名的右側具體化為 struct 宣告
+   */
+  materialize(
+    type: ir.TypeReference,
+    name: string,
+    modifiers: ir.Modifier[],
+    location?: SourceLocation
+  ): ir.InterfaceDeclaration | null {
+    const resolved = this.resolveUtility(type);
+    if (!resolved) return null;
+
+    const decl = this.createDeclaration(name, resolved, modifiers, location);
+    this.objectTypes.set(name, { members: decl.members, decl });
+    return decl;
+  }
+
+  private createDeclaration(
+    name: string,
+    resolved: { members: ir.PropertySignature[]; info: UtilityTypeInfo },
+    modifiers: ir.Modifier[],
+    location?: SourceLocation
+  ): ir.InterfaceDeclaration {
+    const decl = new ir.InterfaceDeclaration(name, resolved.members, undefined, undefined, modifiers, location);
+    decl.metadata.set('utilityType', resolved.info);
+    return decl;
+  }
+
+  private resolveUtility(node: ir.TypeReference): { members: ir.PropertySignature[]; info: UtilityTypeInfo } | null {
+    if (!UTILITY_TYPE_KINDS.has(node.name) || !node.typeArguments || node.typeArguments.length === 0) {
+      return null;
+    }
+
+    const sourceRef = node.typeArguments[0];
+    if (!(sourceRef instanceof ir.TypeReference) || sourceRef.typeArguments) {
+      return null;
+    }
+
+    const source = this.objectTypes.get(sourceRef.name);
+    if (!source) return null;
+
+    const kind = node.name as UtilityTypeKind;
+    let members = source.members;
+
+    if (kind === 'Pick' || kind === 'Omit') {
+      const keys = node.typeArguments[1] ? this.getKeys(node.typeArguments[1]) : [];
+      if (keys.length === 0) return null;
+      members = kind === 'Pick'
+        ? keys.map(k => members.find(m => m.name === k)).filter((m): m is ir.PropertySignature => !!m)
+        : members.filter(m => !keys.includes(m.name));
+    }
+
+    const sourceInfo = source.decl.metadata.get('utilityType') as UtilityTypeInfo | undefined;
+
+    return {
+      members: members.map(m => new ir.PropertySignature(
+        m.name,
+        m.type,
+        kind === 'Partial' ? true : kind === 'Required' ? false : m.optional,
+        kind === 'Readonly' ? true : m.readonly,
+        m.location
+      )),
+      info: {
+        kind,
+        source: sourceRef.name,
+        sourceUnexported: sourceInfo?.kind === 'Readonly',
+        fields: members.map(m => ({ name: m.name, sourceOptional: m.optional }))
+      }
+    };
+  }
+
+  /**
+   * 取得 Pick/Omit 的鍵名，支援指向字面量 union 的別名
+   */
+  private getKeys(type: ir.IRType): string[] {
+    if (type instanceof ir.TypeReference && this.keyAliases.has(type.name)) {
+      return getLiteralKeys(this.keyAliases.get(type.name)!);
+    }
+    return getLiteralKeys(type);
+  }
+
+  /**
+   * 收集 Pick/Omit 可引用的字面量 union 別名
+   */
+  private collectDeclarations(module: ir.Module): void {
+    for (const stmt of module.statements) {
+      if (stmt instanceof ir.TypeAliasDeclaration && !(stmt.typeParameters && stmt.typeParameters.length > 0) &&
+          getLiteralKeys(stmt.type).length > 0) {
+        this.keyAliases.set(stmt.name, stmt.type);
+      }
+    }
+  }
+}
+
+/**
+ * Discriminated union 具體化資訊，記錄於 union 別名的 metadata ('discriminatedUnion')
+ */
+export interface DiscriminatedUnionInfo {
+  /** 判別欄位名稱，例如 status */
+  discriminant: string;
+  /** 判別欄位的字面量型別（決定 getter 的回傳型別） */
+  discriminantType: ir.IRType;
+  variants: Array<{ name: string; value: string | number | boolean }>;
+}
+
+/**
+ * Variant struct 資訊，記錄於 variant 宣告的 metadata ('unionVariant')
+ */
+export interface UnionVariantInfo {
+  union: string;
+  discriminant: string;
+  value: string | number | boolean;
+}
+
+/**
+ * 上下文型別為 discriminated union 的物件字面量，記錄於 ObjectExpression 的 metadata ('variantLiteral')；
+ * GoCodeGenerator 依此產生 variant struct 字面量（SuccessResult{Status: "success", Data: data}）而非 map
+ */
+export interface VariantLiteralInfo {
+  name: string;
+  members: ir.PropertySignature[];
+}
+
+/**
+ * 型別為 sealed union（或其陣列）的 struct 欄位，記錄於 interface 宣告的 metadata ('unionFields')；
+ * encoding/json 無法解碼 Go interface 欄位，GoCodeGenerator 為該 struct 產生以 UnmarshalXxx 解碼的 UnmarshalJSON
+ */
+export interface UnionFieldInfo {
+  name: string;
+  union: ir.TypeReference;
+  /** Shape[] */
+  array: boolean;
+  optional: boolean;
+}
+
+/**
+ * Discriminated Union Pass
+ * 偵測共用字面量判別欄位的物件 union，降階為 sealed interface 與各 variant struct，
+ * 並將判別欄位上的 switch/if 窄化改寫為 type switch / type assertion
+ */
+export class DiscriminatedUnionPass implements OptimizationPass {
+  name = 'discriminated-union';
+
+  run(module: ir.Module, options: CompilerOptions): ir.Module {
+    const objectTypes = collectObjectTypes(module);
+    const unions = new Map<string, DiscriminatedUnionInfo>();
+    const variantDecls = new Map<ir.TypeAliasDeclaration, ir.InterfaceDeclaration[]>();
+    // 具名 variant 被引用於幾個 union
+    const namedVariantUse = new Map<string, string[]>();
+
+    for (const stmt of module.statements) {
+      if (!(stmt instanceof ir.TypeAliasDeclaration) ||
+          (stmt.typeParameters && stmt.typeParameters.length > 0) ||
+          !(stmt.type instanceof ir.UnionType)) {
+        continue;
+      }
+
+      const variants = this.resolveVariants(stmt.type, objectTypes);
+      const discriminant = variants && this.findDiscriminant(variants.map(v => v.members));
+      if (!variants || !discriminant) continue;
+
+      const info: DiscriminatedUnionInfo = {
+        discriminant,
+        discriminantType: this.getDiscriminantType(variants[0].members, discriminant)!,
+        variants: []
+      };
+      const decls: ir.InterfaceDeclaration[] = [];
+
+      for (const variant of variants) {
+        const value = this.getDiscriminantValue(variant.members, discriminant)!;
+        // 具名 variant: Success → SuccessResult；行內 variant: { kind: 'circle' } → CircleShape
+        const name = variant.source ?
+          `${variant.source}${stmt.name}` :
+          `${toPascalCase(String(value))}${stmt.name}`;
+
+        const decl = new ir.InterfaceDeclaration(name, variant.members, undefined, undefined, stmt.modifiers, stmt.location);
+        decl.metadata.set('unionVariant', { union: stmt.name, discriminant, value } as UnionVariantInfo);
+        decls.push(decl);
+        info.variants.push({ name, value });
+
+        if (variant.source) {
+          namedVariantUse.set(variant.source, [...(namedVariantUse.get(variant.source) || []), name]);
+        }
+      }
+
+      stmt.type = new ir.UnionType(info.variants.map(v => new ir.TypeReference(v.name)), stmt.type.location);
+      stmt.metadata.set('discriminatedUnion', info);
+      unions.set(stmt.name, info);
+      variantDecls.set(stmt, decls);
+    }
+
+    if (unions.size === 0) return module;
+
+    // 只屬於單一 union 的具名 variant 以 variant struct 取代
+    const renames = new Map<string, string>();
+    for (const [source, names] of namedVariantUse) {
+      if (names.length === 1) {
+        renames.set(source, names[0]);
+      }
+    }
+
+    const statements: ir.Statement[] = [];
+    for (const stmt of module.statements) {
+      if (stmt instanceof ir.Declaration && renames.has(stmt.name) &&
+          (stmt instanceof ir.InterfaceDeclaration || stmt instanceof ir.TypeAliasDeclaration)) {
+        continue;
+      }
+      statements.push(stmt);
+      if (stmt instanceof ir.TypeAliasDeclaration && variantDecls.has(stmt)) {
+        statements.push(...variantDecls.get(stmt)!);
+      }
+    }
+    module.statements = statements;
+
+    const variants = new Map<string, ir.InterfaceDeclaration>();
+    for (const decls of variantDecls.values()) {
+      decls.forEach(decl => variants.set(decl.name, decl));
+    }
+    const narrowing = new UnionNarrowingRewriter(unions, renames, variants);
+    for (const stmt of module.statements) {
+      stmt.accept(narrowing);
+    }
+
+    for (const stmt of module.statements) {
+      if (!(stmt instanceof ir.InterfaceDeclaration) || (stmt.typeParameters && stmt.typeParameters.length > 0)) continue;
+      const fields = stmt.members
+        .map(member => this.getUnionField(member, unions))
+        .filter((field): field is UnionFieldInfo => !!field);
+      if (fields.length > 0) stmt.metadata.set('unionFields', fields);
+    }
+
     return module;
   }
+
+  private getUnionField(member: ir.PropertySignature, unions: Map<string, DiscriminatedUnionInfo>): UnionFieldInfo | undefined {
+    const array = member.type instanceof ir.ArrayType;
+    const element = member.type instanceof ir.ArrayType ? member.type.elementType : member.type;
+    if (!(element instanceof ir.TypeReference) || element.typeArguments || !unions.has(element.name)) return undefined;
+    return { name: member.name, union: element, array, optional: member.optional };
+  }
+
+  private resolveVariants(
+    union: ir.UnionType,
+    objectTypes: Map<string, ObjectTypeInfo>
+  ): Array<{ members: ir.PropertySignature[]; source?: string }> | null {
+    if (union.types.length < 2) return null;
+
+    const variants: Array<{ members: ir.PropertySignature[]; source?: string }> = [];
+    for (const type of union.types) {
+      if (type instanceof ir.ObjectType && !type.indexSignature) {
+        variants.push({ members: type.properties });
+      } else if (type instanceof ir.TypeReference && !type.typeArguments && objectTypes.has(type.name)) {
+        variants.push({ members: objectTypes.get(type.name)!.members, source: type.name });
+      } else {
+        return null;
+      }
+    }
+    return variants;
+  }
+
+  /**
+   * 找出所有 variant 皆具備、型別為互不相同字面量的欄位
+   */
+  private findDiscriminant(variants: ir.PropertySignature[][]): string | null {
+    for (const candidate of variants[0]) {
+      const values = variants.map(members => this.getDiscriminantValue(members, candidate.name));
+      if (values.some(v => v === undefined)) continue;
+      if (new Set(values).size !== values.length) continue;
+      if (new Set(values.map(v => typeof v)).size !== 1) continue;
+      return candidate.name;
+    }
+    return null;
+  }
+
+  private getDiscriminantType(members: ir.PropertySignature[], name: string): ir.LiteralType | undefined {
+    const member = members.find(m => m.name === name && !m.optional);
+    return member && member.type instanceof ir.LiteralType && member.type.value !== null && member.type.value !== undefined ?
+      member.type :
+      undefined;
+  }
+
+  private getDiscriminantValue(members: ir.PropertySignature[], name: string): string | number | boolean | undefined {
+    return this.getDiscriminantType(members, name)?.value as string | number | boolean | undefined;
+  }
+}
+
+/**
+ * 將判別欄位上的窄化改寫為 type switch / type assertion
+ */
+class UnionNarrowingRewriter extends IRWalker {
+  constructor(
+    private unions: Map<string, DiscriminatedUnionInfo>,
+    private renames: Map<string, string>,
+    private variants: Map<string, ir.InterfaceDeclaration>
+  ) {
+    super();
+  }
+
+  /**
+   * { status: 'success', data } 的上下文型別為 Result 時，依判別值記錄對應的 variant struct
+   */
+  visitObjectExpression(node: ir.ObjectExpression): void {
+    super.visitObjectExpression(node);
+    const union = this.unions.get(node.metadata.get('unionType'));
+    if (!union || node.properties.some(p => p.computed || propertyKeyName(p) === undefined)) return;
+
+    const discriminant = node.properties.find(p => propertyKeyName(p) === union.discriminant);
+    const variant = discriminant && this.getVariant(union, discriminant.value);
+    const decl = variant && this.variants.get(variant);
+    if (decl) {
+      node.metadata.set('variantLiteral', { name: decl.name, members: decl.members } as VariantLiteralInfo);
+    }
+  }
+
+  visitTypeReference(node: ir.TypeReference): void {
+    super.visitTypeReference(node);
+    const renamed = this.renames.get(node.name);
+    if (renamed) {
+      node.name = renamed;
+    }
+  }
+
+  /**
+   * 其餘對判別欄位的存取改用 getter，例如 result.status → result.GetStatus()
+   */
+  visitMemberExpression(node: ir.MemberExpression): void {
+    super.visitMemberExpression(node);
+    if (this.getDiscriminatedUnion(node)) {
+      node.metadata.set('discriminantGetter', true);
+    }
+  }
+
+  /**
+   * switch (r.status) → switch v := r.(type)
+   */
+  visitSwitchStatement(node: ir.SwitchStatement): void {
+    const union = this.getDiscriminatedUnion(node.discriminant);
+    const subject = union && ((node.discriminant as ir.MemberExpression).object as ir.Identifier).name;
+
+    if (union && subject) {
+      const variants = node.cases.map(c => this.getVariant(union, c.test));
+      if (variants.every((v, i) => v || !node.cases[i].test)) {
+        const binding = this.pickBinding(subject, node);
+        let used = false;
+
+        node.cases.forEach((c, i) => {
+          if (!variants[i]) return;
+          c.metadata.set('variant', variants[i]);
+          // 多個 case 共用同一段程式碼時，綁定變數的型別為 interface，不改寫
+          const grouped = i > 0 && variants[i - 1] && node.cases[i - 1].consequent.length === 0;
+          if (c.consequent.length > 0 && !grouped) {
+            used = this.renameIdentifier(c.consequent, subject, binding) || used;
+          }
+        });
+
+        node.metadata.set('typeSwitch', { subject, binding: used ? binding : undefined });
+        node.cases.forEach(c => this.walkAll(c.consequent));
+        return;
+      }
+    }
+
+    super.visitSwitchStatement(node);
+  }
+
+  /**
+   * if (r.status === 'error') → if v, ok := r.(ErrorResult); ok
+   */
+  visitIfStatement(node: ir.IfStatement): void {
+    const test = node.test;
+    if (test instanceof ir.BinaryExpression && ['===', '==', '!==', '!='].includes(test.operator)) {
+      const [member, literal] = test.left instanceof ir.MemberExpression ?
+        [test.left, test.right] :
+        [test.right, test.left];
+      const union = this.getDiscriminatedUnion(member);
+      const variant = union && this.getVariant(union, literal);
+
+      if (variant) {
+        const subject = ((member as ir.MemberExpression).object as ir.Identifier).name;
+        const negated = test.operator === '!==' || test.operator === '!=';
+        const narrowed = negated ? node.alternate : node.consequent;
+        const binding = this.pickBinding(subject, node);
+        const used = narrowed ? this.renameIdentifier([narrowed], subject, binding) : false;
+
+        node.metadata.set('typeGuard', { subject, variant, binding: used ? binding : undefined, negated });
+        this.walk(node.consequent);
+        this.walk(node.alternate);
+        return;
+      }
+    }
+
+    super.visitIfStatement(node);
+  }
+
+  private getDiscriminatedUnion(node?: ir.Expression): DiscriminatedUnionInfo | undefined {
+    if (!(node instanceof ir.MemberExpression) || node.computed || !(node.property instanceof ir.Identifier)) {
+      return undefined;
+    }
+    const union = this.unions.get(node.metadata.get('unionType'));
+    return union && union.discriminant === node.property.name && node.object instanceof ir.Identifier ?
+      union :
+      undefined;
+  }
+
+  private getVariant(union: DiscriminatedUnionInfo, test?: ir.Expression): string | undefined {
+    if (!(test instanceof ir.Literal)) return undefined;
+    return union.variants.find(v => v.value === test.value)?.name;
+  }
+
+  /**
+   * 以 subject 首字母作為綁定變數，與既有識別字衝突時改用較長名稱
+   */
+  private pickBinding(subject: string, scope: ir.IRNode): string {
+    const short = subject.charAt(0).toLowerCase();
+    // 單字母變數直接遮蔽：switch r := r.(type)
+    if (short === subject) return subject;
+
+    const names = new Set<string>();
+    scope.accept(new IdentifierCollector(names));
+    return names.has(short) ? `${subject}Variant` : short;
+  }
+
+  private renameIdentifier(statements: ir.Statement[], from: string, to: string): boolean {
+    const renamer = new IdentifierRenamer(from, to);
+    if (renamer.declares(statements)) return false;
+    statements.forEach(s => s.accept(renamer));
+    return renamer.renamed;
+  }
+}
+
+class IdentifierCollector extends IRWalker {
+  constructor(private names: Set<string>) {
+    super();
+  }
+
+  visitIdentifier(node: ir.Identifier): void {
+    this.names.add(node.name);
+  }
+}
+
+/**
+ * 將 from 改名為 to；遇到重新宣告 from 的範圍（參數、區塊內的宣告、迴圈變數、catch 參數）時不進入，
+ * 其中的同名識別字指向內層的宣告
+ */
+class IdentifierRenamer extends IRWalker {
+  renamed = false;
+
+  constructor(private from: string, private to: string) {
+    super();
+  }
+
+  /**
+   * statements 直接宣告 from 時（let / const 於整個區塊內遮蔽外層）回傳 true
+   */
+  declares(statements: ir.Statement[]): boolean {
+    return statements.some(s =>
+      (s instanceof ir.VariableDeclaration || s instanceof ir.FunctionDeclaration || s instanceof ir.ClassDeclaration) &&
+      s.name === this.from);
+  }
+
+  visitIdentifier(node: ir.Identifier): void {
+    if (node.name === this.from) {
+      node.name = this.to;
+      this.renamed = true;
+    }
+  }
+
+  visitBlockStatement(node: ir.BlockStatement): void {
+    if (!this.declares(node.statements)) {
+      super.visitBlockStatement(node);
+    }
+  }
+
+  visitFunctionDeclaration(node: ir.FunctionDeclaration): void {
+    if (!node.parameters.some(p => p.name === this.from)) {
+      super.visitFunctionDeclaration(node);
+    }
+  }
+
+  visitFunctionExpression(node: ir.FunctionExpression): void {
+    if (!node.parameters.some(p => p.name === this.from)) {
+      super.visitFunctionExpression(node);
+    }
+  }
+
+  visitArrowFunctionExpression(node: ir.ArrowFunctionExpression): void {
+    if (!node.parameters.some(p => p.name === this.from)) {
+      super.visitArrowFunctionExpression(node);
+    }
+  }
+
+  visitForStatement(node: ir.ForStatement): void {
+    if (!(node.init instanceof ir.VariableDeclaration && node.init.name === this.from)) {
+      super.visitForStatement(node);
+    }
+  }
+
+  visitForOfStatement(node: ir.ForOfStatement): void {
+    this.walk(node.right);
+    if (node.left.name !== this.from) {
+      this.walk(node.left);
+      this.walk(node.body);
+    }
+  }
+
+  visitCatchClause(node: ir.CatchClause): void {
+    if (node.param?.name !== this.from) {
+      super.visitCatchClause(node);
+    }
+  }
+}
+
+/**
+ * 物件字面量屬性的鍵名；展開（...x）與無法靜態得知的鍵回傳 undefined
+ */
+function propertyKeyName(prop: ir.Property): string | undefined {
+  if (prop.key instanceof ir.Identifier) return prop.key.name;
+  if (prop.key instanceof ir.Literal && prop.key.raw !== '...') return String(prop.key.value);
+  return undefined;
+}
+
+/**
+ * 'circle' → Circle, 'in-progress' → InProgress
+ */
+function toPascalCase(value: string): string {
+  return value
+    .split(/[^A-Za-z0-9]+/)
+    .filter(part => part.length > 0)
+    .map(part => part.charAt(0).toUpperCase() + part.slice(1))
+    .join('');
+}
+
+/**
+ * 泛型限制 Pass
+ * Go 的型別參數無法存取欄位，結構型別限制（T extends { id: string } 或具名資料介面）
+ * 降階為 getter 介面：型別參數標記 constraintGetters，模組內具備相同欄位的 struct
+ * 與 class 亦標記 constraintGetters（對應的限制成員），由 GoCodeGenerator 產生 getter 使其滿足該限制
+ */
+export class GenericConstraintPass implements OptimizationPass {
+  name = 'generic-constraint';
+
+  run(module: ir.Module, options: CompilerOptions): ir.Module {
+    return this.runProject([module], options)[0];
+  }
+
+  /**
+   * 限制與滿足限制的 struct 可位於不同檔案（models.ts 的 User 與 repo.ts 的 find<T extends HasId>），
+   * 因此先收集整個專案的限制成員，再為各模組的 struct 加上 getter
+   */
+  runProject(modules: ir.Module[], options: CompilerOptions): ir.Module[] {
+    const objectTypes = modules.map(module => collectObjectTypes(module));
+    // 限制引用的具名型別先於同一模組中尋找，再找其他模組
+    const projectTypes = new Map<string, ObjectTypeInfo>();
+    for (const types of objectTypes) {
+      for (const [name, info] of types) {
+        if (!projectTypes.has(name)) projectTypes.set(name, info);
+      }
+    }
+    const required = new Map<string, ir.PropertySignature[]>();
+
+    modules.forEach((module, i) => {
+      const collector = new TypeParameterCollector();
+      module.statements.forEach(s => s.accept(collector));
+
+      for (const tp of collector.typeParameters) {
+        const members = this.getStructuralMembers(tp.constraint, objectTypes[i], projectTypes);
+        if (!members) continue;
+        tp.metadata.set('constraintGetters', members);
+        for (const member of members) {
+          required.set(member.name, [...(required.get(member.name) || []), member]);
+        }
+      }
+    });
+
+    if (required.size === 0) return modules;
+
+    // 回傳欄位所對應的限制成員，型別或可選性不符者不產生 getter
+    const match = (name: string, type: ir.IRType | undefined, optional: boolean): ir.PropertySignature | undefined =>
+      type && (required.get(name) || []).find(m => m.optional === optional && sameType(m.type, type));
+
+    modules.forEach((module, i) => {
+      for (const stmt of module.statements) {
+        if (stmt instanceof ir.InterfaceDeclaration && objectTypes[i].get(stmt.name)?.decl === stmt) {
+          // sealed union 的 variant 已具備判別欄位的 getter
+          const variant = stmt.metadata.get('unionVariant') as UnionVariantInfo | undefined;
+          const getters = stmt.members
+            .filter(m => m.name !== variant?.discriminant)
+            .map(m => match(m.name, m.type, m.optional))
+            .filter((m): m is ir.PropertySignature => !!m);
+          if (getters.length > 0) stmt.metadata.set('constraintGetters', getters);
+        } else if (stmt instanceof ir.ClassDeclaration && !(stmt.typeParameters && stmt.typeParameters.length > 0)) {
+          const methods = new Set(stmt.members
+            .filter((m): m is ir.MethodMember => m instanceof ir.MethodMember)
+            .map(m => m.name.toLowerCase()));
+          const getters = stmt.members
+            .filter((m): m is ir.PropertyMember => m instanceof ir.PropertyMember)
+            .filter(m => !m.modifiers.some(mod => mod.kind === 'static' || mod.kind === 'private') &&
+                         !m.name.startsWith('#') && !methods.has(`get${m.name.toLowerCase()}`))
+            .map(m => match(m.name, m.type, !!m.metadata.get('isOptional')))
+            .filter((m): m is ir.PropertySignature => !!m);
+          if (getters.length > 0) stmt.metadata.set('constraintGetters', getters);
+        }
+      }
+    });
+
+    return modules;
+  }
+
+  /**
+   * 僅含資料欄位的物件型別限制回傳其欄位；含方法的介面維持原本的 Go interface
+   */
+  private getStructuralMembers(
+    constraint: ir.IRType | undefined,
+    objectTypes: Map<string, ObjectTypeInfo>,
+    projectTypes: Map<string, ObjectTypeInfo>
+  ): ir.PropertySignature[] | undefined {
+    if (constraint instanceof ir.ObjectType && !constraint.indexSignature &&
+        constraint.properties.length > 0 &&
+        constraint.properties.every(p => !(p.type instanceof ir.FunctionType))) {
+      return constraint.properties;
+    }
+    if (constraint instanceof ir.TypeReference && !constraint.typeArguments) {
+      const members = (objectTypes.get(constraint.name) || projectTypes.get(constraint.name))?.members;
+      return members && members.length > 0 ? members : undefined;
+    }
+    return undefined;
+  }
+}
+
+class TypeParameterCollector extends IRWalker {
+  typeParameters: ir.TypeParameter[] = [];
+
+  visitTypeParameter(node: ir.TypeParameter): void {
+    super.visitTypeParameter(node);
+    this.typeParameters.push(node);
+  }
+}
+
+/**
+ * 比較兩個 IR 型別是否產生相同的 Go 型別（僅處理 getter 會遇到的簡單型別）
+ */
+function sameType(a: ir.IRType, b: ir.IRType): boolean {
+  if (a instanceof ir.PrimitiveType && b instanceof ir.PrimitiveType) return a.kind === b.kind;
+  if (a instanceof ir.TypeReference && b instanceof ir.TypeReference) {
+    return a.name === b.name && !a.typeArguments && !b.typeArguments;
+  }
+  if (a instanceof ir.ArrayType && b instanceof ir.ArrayType) return sameType(a.elementType, b.elementType);
+  return false;
+}
+
+/**
+ * Context 傳遞 Pass
+ * 依呼叫圖找出需要 ctx 的函式：async 函式，以及（遞移地）呼叫它們的同步函式與方法。
+ * 後者標記 contextParam 以加上 ctx 參數，呼叫處標記 contextCall 以傳入 ctx；
+ * 頂層進入點（模組層級初始化與 main）則由 GoCodeGenerator 建立 context.Background()。
+ * 另將區域的 AbortController 標記為 context.WithCancel（搭配 setTimeout 中止時為 WithTimeout）
+ */
+export class ContextThreadingPass implements OptimizationPass {
+  name = 'context-threading';
+
+  run(module: ir.Module, options: CompilerOptions): ir.Module {
+    return this.runProject([module], options)[0];
+  }
+
+  /**
+   * 呼叫圖涵蓋專案的所有模組，函式以「模組路徑#函式鍵」識別（與 IRTransformer 記錄的 callee 相同），
+   * 呼叫其他檔案中需要 ctx 的函式亦會傳遞 ctx
+   */
+  runProject(modules: ir.Module[], options: CompilerOptions): ir.Module[] {
+    const functions = new Map<string, { node: ir.IRNode; isAsync: boolean; calls: ir.CallExpression[] }>();
+
+    const addFunction = (key: string, node: ir.IRNode, isAsync: boolean, body?: ir.IRNode): void => {
+      const calls: ir.CallExpression[] = [];
+      if (body) body.accept(new CallCollector(calls));
+      functions.set(key, { node, isAsync, calls });
+    };
+
+    for (const module of modules) {
+      const key = (name: string): string => `${module.path}#${name}`;
+      for (const stmt of module.statements) {
+        if (stmt instanceof ir.FunctionDeclaration) {
+          addFunction(key(stmt.name), stmt, stmt.modifiers.some(m => m.kind === 'async'), stmt.body);
+        } else if (stmt instanceof ir.ClassDeclaration) {
+          for (const member of stmt.members) {
+            if (member instanceof ir.MethodMember && member.name !== 'constructor') {
+              addFunction(key(`${stmt.name}.${member.name}`), member, member.modifiers.some(m => m.kind === 'async'), member.body);
+            }
+          }
+        } else if (stmt instanceof ir.VariableDeclaration &&
+                   (stmt.initializer instanceof ir.ArrowFunctionExpression ||
+                    stmt.initializer instanceof ir.FunctionExpression)) {
+          addFunction(key(stmt.name), stmt.initializer, stmt.initializer.isAsync, stmt.initializer.body);
+        }
+      }
+    }
+
+    // 呼叫 async 函式（含其他模組匯入者）或需要 ctx 的函式即需要 ctx，反覆傳播至不動點
+    const needsContext = new Set<string>();
+    const requiresContext = (call: ir.CallExpression): boolean =>
+      !!call.metadata.get('asyncCall') || needsContext.has(call.metadata.get('callee'));
+
+    for (const [key, fn] of functions) {
+      if (fn.isAsync) needsContext.add(key);
+    }
+    let changed = true;
+    while (changed) {
+      changed = false;
+      for (const [key, fn] of functions) {
+        if (!needsContext.has(key) && fn.calls.some(requiresContext)) {
+          needsContext.add(key);
+          changed = true;
+        }
+      }
+    }
+
+    for (const [key, fn] of functions) {
+      if (!needsContext.has(key) || fn.isAsync) continue;
+      // main 為進入點，於其中建立 context.Background()
+      fn.node.metadata.set(key.endsWith('#main') ? 'contextEntry' : 'contextParam', true);
+    }
+
+    for (const module of modules) {
+      const calls: ir.CallExpression[] = [];
+      for (const stmt of module.statements) {
+        stmt.accept(new CallCollector(calls));
+      }
+      for (const call of calls) {
+        if (requiresContext(call)) {
+          call.metadata.set('contextCall', true);
+        }
+      }
+
+      for (const stmt of module.statements) {
+        stmt.accept(new AbortControllerLowering());
+      }
+    }
+
+    return modules;
+  }
+}
+
+class CallCollector extends IRWalker {
+  constructor(private calls: ir.CallExpression[]) {
+    super();
+  }
+
+  visitCallExpression(node: ir.CallExpression): void {
+    this.calls.push(node);
+    super.visitCallExpression(node);
+  }
+}
+
+/**
+ * 錯誤回傳 Pass
+ * 同步函式內的 new Date(str) 無法解析時應回傳 error（IRTransformer 標記 dateParseError），
+ * 該函式標記 errorReturn 以改為回傳 (T, error)；如同 ContextThreadingPass，呼叫它們的同步函式與方法
+ * 遞移地標記 errorReturn，呼叫處標記 errorCall（void 表示被呼叫端只回傳 error）。
+ * async 函式與 generator 本已回傳 error，main 為進入點，皆不改變簽名；閉包內的呼叫不影響外層函式
+ */
+export class ErrorPropagationPass implements OptimizationPass {
+  name = 'error-propagation';
+
+  run(module: ir.Module, options: CompilerOptions): ir.Module {
+    return this.runProject([module], options)[0];
+  }
+
+  runProject(modules: ir.Module[], options: CompilerOptions): ir.Module[] {
+    const functions = new Map<string, { node: ir.IRNode; widenable: boolean; returnType?: ir.IRType; sources: ErrorSources }>();
+
+    const addFunction = (key: string, node: ir.FunctionDeclaration | ir.MethodMember): void => {
+      const sources: ErrorSources = { dateParse: false, calls: [] };
+      if (node.body) node.body.accept(new ErrorSourceCollector(sources));
+      const widenable = !node.modifiers.some(m => m.kind === 'async') && !node.metadata.get('generator') &&
+        !key.endsWith('#main');
+      functions.set(key, { node, widenable, returnType: node.returnType, sources });
+    };
+
+    for (const module of modules) {
+      const key = (name: string): string => `${module.path}#${name}`;
+      for (const stmt of module.statements) {
+        if (stmt instanceof ir.FunctionDeclaration) {
+          addFunction(key(stmt.name), stmt);
+        } else if (stmt instanceof ir.ClassDeclaration) {
+          for (const member of stmt.members) {
+            if (member instanceof ir.MethodMember && member.name !== 'constructor') {
+              addFunction(key(`${stmt.name}.${member.name}`), member);
+            }
+          }
+        }
+      }
+    }
+
+    // 直接解析日期或呼叫回傳 error 的函式即回傳 error，反覆傳播至不動點
+    const returnsError = new Set<string>();
+    for (const [key, fn] of functions) {
+      if (fn.widenable && fn.sources.dateParse) returnsError.add(key);
+    }
+    let changed = true;
+    while (changed) {
+      changed = false;
+      for (const [key, fn] of functions) {
+        if (fn.widenable && !returnsError.has(key) &&
+            fn.sources.calls.some(call => returnsError.has(call.metadata.get('callee')))) {
+          returnsError.add(key);
+          changed = true;
+        }
+      }
+    }
+
+    for (const key of returnsError) {
+      functions.get(key)!.node.metadata.set('errorReturn', true);
+    }
+
+    for (const module of modules) {
+      const calls: ir.CallExpression[] = [];
+      for (const stmt of module.statements) {
+        stmt.accept(new CallCollector(calls));
+      }
+      for (const call of calls) {
+        const callee = call.metadata.get('callee');
+        if (returnsError.has(callee)) {
+          const returnType = functions.get(callee)!.returnType;
+          const info: ErrorCallInfo = { void: isVoidType(returnType), returnType };
+          call.metadata.set('errorCall', info);
+        }
+      }
+    }
+
+    return modules;
+  }
+}
+
+/**
+ * 呼叫回傳 error 的同步函式，記錄於 CallExpression 的 metadata ('errorCall')；
+ * void 為 true 時被呼叫端只回傳 error，returnType 為其原本的回傳型別
+ */
+export interface ErrorCallInfo {
+  void: boolean;
+  returnType?: ir.IRType;
+}
+
+interface ErrorSources {
+  dateParse: boolean;
+  calls: ir.CallExpression[];
+}
+
+function isVoidType(type?: ir.IRType): boolean {
+  return !type || (type instanceof ir.PrimitiveType && (type.kind === 'void' || type.kind === 'never'));
+}
+
+/**
+ * 收集函式本體直接產生 error 的位置；巢狀的函式表達式不回傳外層的 error，不進入
+ */
+class ErrorSourceCollector extends IRWalker {
+  constructor(private sources: ErrorSources) {
+    super();
+  }
+
+  visitNewExpression(node: ir.NewExpression): void {
+    if (node.metadata.get('dateParseError')) this.sources.dateParse = true;
+    super.visitNewExpression(node);
+  }
+
+  visitCallExpression(node: ir.CallExpression): void {
+    this.sources.calls.push(node);
+    super.visitCallExpression(node);
+  }
+
+  visitArrowFunctionExpression(): void {}
+
+  visitFunctionExpression(): void {}
+}
+
+/**
+ * 區域的 `const controller = new AbortController()` 標記為 abortController，
+ * 其後的 controller.signal / controller.abort() 標記為對應的 ctx 與 cancel。
+ * 同一區塊內 `setTimeout(() => controller.abort(), ms)` 併入為逾時，
+ * 並移除該計時器與其 clearTimeout（逾時於 ctx 的 cancel 時一併釋放）。
+ * 迴圈中的 controller 不以 defer 釋放（會累積至函式結束），改於區塊結尾與其後的 return / throw 之前呼叫 abort()
+ */
+class AbortControllerLowering extends IRWalker {
+  private loopDepth = 0;
+
+  visitWhileStatement(node: ir.WhileStatement): void {
+    this.inLoop(() => super.visitWhileStatement(node));
+  }
+
+  visitForStatement(node: ir.ForStatement): void {
+    this.inLoop(() => super.visitForStatement(node));
+  }
+
+  visitForOfStatement(node: ir.ForOfStatement): void {
+    this.inLoop(() => super.visitForOfStatement(node));
+  }
+
+  visitFunctionDeclaration(node: ir.FunctionDeclaration): void {
+    this.inFunction(() => super.visitFunctionDeclaration(node));
+  }
+
+  visitMethodMember(node: ir.MethodMember): void {
+    this.inFunction(() => super.visitMethodMember(node));
+  }
+
+  visitFunctionExpression(node: ir.FunctionExpression): void {
+    this.inFunction(() => super.visitFunctionExpression(node));
+  }
+
+  visitArrowFunctionExpression(node: ir.ArrowFunctionExpression): void {
+    this.inFunction(() => super.visitArrowFunctionExpression(node));
+  }
+
+  private inLoop(visit: () => void): void {
+    this.loopDepth++;
+    visit();
+    this.loopDepth--;
+  }
+
+  /** 函式本體自成一層，外層的迴圈不影響其中的 controller */
+  private inFunction(visit: () => void): void {
+    const saved = this.loopDepth;
+    this.loopDepth = 0;
+    visit();
+    this.loopDepth = saved;
+  }
+
+  visitBlockStatement(node: ir.BlockStatement): void {
+    super.visitBlockStatement(node);
+
+    for (const decl of [...node.statements]) {
+      if (!(decl instanceof ir.VariableDeclaration) ||
+          !(decl.initializer instanceof ir.NewExpression) ||
+          !(decl.initializer.callee instanceof ir.Identifier) ||
+          decl.initializer.callee.name !== 'AbortController') {
+        continue;
+      }
+
+      const info: { timeout?: ir.Expression; scoped?: boolean } = {};
+      const timers = new Set<string>();
+      node.statements = node.statements.filter(stmt => {
+        const timer = this.getAbortTimer(stmt, decl.name);
+        if (!timer || info.timeout) return true;
+        info.timeout = timer.delay;
+        if (timer.name) timers.add(timer.name);
+        return false;
+      });
+      if (this.loopDepth > 0) {
+        info.scoped = true;
+        this.releaseAtExits(node, decl);
+      }
+      decl.metadata.set('abortController', info);
+
+      node.accept(new AbortReferenceMarker(decl.name, timers));
+    }
+  }
+
+  /**
+   * 於宣告之後離開區塊的每條路徑呼叫 controller.abort()：區塊結尾，以及其後（含巢狀區塊）的 return / throw 之前。
+   * 區塊最後的 abort 標記 releasesAbortController，GoCodeGenerator 之後的錯誤返回不再呼叫其 cancel
+   */
+  private releaseAtExits(node: ir.BlockStatement, decl: ir.VariableDeclaration): void {
+    const index = node.statements.indexOf(decl);
+    const rest = new ir.BlockStatement(node.statements.slice(index + 1));
+    rest.accept(new AbortBeforeExit(decl.name));
+    const last = rest.statements[rest.statements.length - 1];
+    if (!(last instanceof ir.ReturnStatement || last instanceof ir.ThrowStatement)) {
+      rest.statements.push(abortStatement(decl.name));
+    }
+    const release = [...rest.statements].reverse().find(stmt =>
+      stmt instanceof ir.ExpressionStatement && isAbortCall(stmt.expression, decl.name)) as ir.ExpressionStatement;
+    release.expression.metadata.set('releasesAbortController', true);
+    node.statements = [...node.statements.slice(0, index + 1), ...rest.statements];
+  }
+
+  /**
+   * 辨識 setTimeout(() => controller.abort(), ms) 或 const timer = setTimeout(...)
+   */
+  private getAbortTimer(stmt: ir.Statement, controller: string): { delay: ir.Expression; name?: string } | null {
+    let call: ir.Expression | undefined;
+    let name: string | undefined;
+    if (stmt instanceof ir.ExpressionStatement) {
+      call = stmt.expression;
+    } else if (stmt instanceof ir.VariableDeclaration) {
+      call = stmt.initializer;
+      name = stmt.name;
+    }
+
+    if (!(call instanceof ir.CallExpression) || !(call.callee instanceof ir.Identifier) ||
+        call.callee.name !== 'setTimeout' || call.args.length !== 2 ||
+        !(call.args[0] instanceof ir.ArrowFunctionExpression || call.args[0] instanceof ir.FunctionExpression)) {
+      return null;
+    }
+
+    let body = call.args[0].body;
+    if (body instanceof ir.BlockStatement && body.statements.length === 1 &&
+        body.statements[0] instanceof ir.ExpressionStatement) {
+      body = body.statements[0].expression;
+    }
+    return isAbortCall(body, controller) ? { delay: call.args[1], name } : null;
+  }
+}
+
+function abortStatement(controller: string): ir.ExpressionStatement {
+  return new ir.ExpressionStatement(new ir.CallExpression(
+    new ir.MemberExpression(new ir.Identifier(controller), new ir.Identifier('abort'), false, false), []
+  ));
+}
+
+/**
+ * 於 return / throw 之前插入 controller.abort()；回傳值先存入變數，於 abort 之前求值
+ */
+class AbortBeforeExit extends IRWalker {
+  constructor(private controller: string) {
+    super();
+  }
+
+  visitBlockStatement(node: ir.BlockStatement): void {
+    super.visitBlockStatement(node);
+    node.statements = this.release(node.statements);
+  }
+
+  visitSwitchCase(node: ir.SwitchCase): void {
+    super.visitSwitchCase(node);
+    node.consequent = this.release(node.consequent);
+  }
+
+  visitIfStatement(node: ir.IfStatement): void {
+    node.consequent = this.asBlock(node.consequent);
+    if (node.alternate && !(node.alternate instanceof ir.IfStatement)) {
+      node.alternate = this.asBlock(node.alternate);
+    }
+    super.visitIfStatement(node);
+  }
+
+  visitWhileStatement(node: ir.WhileStatement): void {
+    node.body = this.asBlock(node.body);
+    super.visitWhileStatement(node);
+  }
+
+  visitForStatement(node: ir.ForStatement): void {
+    node.body = this.asBlock(node.body);
+    super.visitForStatement(node);
+  }
+
+  visitForOfStatement(node: ir.ForOfStatement): void {
+    node.body = this.asBlock(node.body);
+    super.visitForOfStatement(node);
+  }
+
+  // 巢狀函式的 return 不離開 controller 所在的函式
+  visitFunctionExpression(): void {}
+
+  visitArrowFunctionExpression(): void {}
+
+  private asBlock(stmt: ir.Statement): ir.Statement {
+    return stmt instanceof ir.ReturnStatement || stmt instanceof ir.ThrowStatement ?
+      new ir.BlockStatement([stmt], stmt.location) :
+      stmt;
+  }
+
+  private release(statements: ir.Statement[]): ir.Statement[] {
+    return statements.flatMap(stmt => {
+      if (stmt instanceof ir.ThrowStatement) {
+        return [abortStatement(this.controller), stmt];
+      }
+      if (!(stmt instanceof ir.ReturnStatement)) {
+        return [stmt];
+      }
+      const argument = stmt.argument;
+      if (!argument || argument instanceof ir.Literal ||
+          (argument instanceof ir.Identifier && argument.name !== this.controller)) {
+        return [abortStatement(this.controller), stmt];
+      }
+      const result = `${this.controller}Result`;
+      stmt.argument = new ir.Identifier(result, argument.location);
+      return [new ir.VariableDeclaration(result, undefined, argument, true, [], stmt.location), abortStatement(this.controller), stmt];
+    });
+  }
+}
+
+function isAbortCall(node: ir.IRNode, controller: string): boolean {
+  return node instanceof ir.CallExpression &&
+    node.callee instanceof ir.MemberExpression &&
+    !node.callee.computed &&
+    node.callee.object instanceof ir.Identifier &&
+    node.callee.object.name === controller &&
+    node.callee.property instanceof ir.Identifier &&
+    node.callee.property.name === 'abort';
+}
+
+class AbortReferenceMarker extends IRWalker {
+  constructor(private controller: string, private timers: Set<string>) {
+    super();
+  }
+
+  visitBlockStatement(node: ir.BlockStatement): void {
+    // 併入逾時的計時器不再需要 clearTimeout
+    node.statements = node.statements.filter(stmt =>
+      !(stmt instanceof ir.ExpressionStatement &&
+        stmt.expression instanceof ir.CallExpression &&
+        stmt.expression.callee instanceof ir.Identifier &&
+        stmt.expression.callee.name === 'clearTimeout' &&
+        stmt.expression.args[0] instanceof ir.Identifier &&
+        this.timers.has(stmt.expression.args[0].name)));
+    super.visitBlockStatement(node);
+  }
+
+  visitCallExpression(node: ir.CallExpression): void {
+    if (isAbortCall(node, this.controller)) {
+      node.metadata.set('abortCall', this.controller);
+      return;
+    }
+    super.visitCallExpression(node);
+  }
+
+  visitMemberExpression(node: ir.MemberExpression): void {
+    if (!node.computed && node.object instanceof ir.Identifier && node.object.name === this.controller &&
+        node.property instanceof ir.Identifier && node.property.name === 'signal') {
+      node.metadata.set('abortSignalOf', this.controller);
+      return;
+    }
+    super.visitMemberExpression(node);
+  }
+}
+
+/**
+ * options 物件參數上由 IRTransformer 標記的屬性與解構綁定，記錄於參數的 metadata ('optionsObject')；
+ * setters 與 declaresSetters 由 OptionSetterPass 補上
+ */
+export interface OptionsObjectInfo {
+  properties: { name: string; type?: ir.IRType; optional: boolean; defaultValue?: ir.Expression }[];
+  bindings?: { property: string; name: string }[];
+  /** 屬性名稱 → functional options 的設定函式名稱（WithTimeout） */
+  setters?: Record<string, string>;
+  /** 此宣告負責產生該 options 型別的設定函式（每個型別僅一處） */
+  declaresSetters?: boolean;
+}
+
+/**
+ * Option Setter Pass
+ * functional options 的 WithXxx 設定函式位於套件層級，名稱須與檔案及宣告的處理順序無關：
+ * 屬性的設定函式名稱只屬於一個 options 型別時為 WithTimeout，
+ * 多個型別共用時一律加上型別名稱（WithRequestTimeout、WithUploadTimeout）。
+ * 名稱記錄於宣告與呼叫處的 optionsObject.setters，
+ * 每個 options 型別的第一個宣告（依模組路徑排序）標記 declaresSetters
+ */
+export class OptionSetterPass implements OptimizationPass {
+  name = 'option-setters';
+
+  run(module: ir.Module, options: CompilerOptions): ir.Module {
+    return this.runProject([module], options)[0];
+  }
+
+  runProject(modules: ir.Module[], options: CompilerOptions): ir.Module[] {
+    const collector = new OptionsObjectCollector();
+    for (const module of [...modules].sort((a, b) => a.path.localeCompare(b.path))) {
+      module.statements.forEach(stmt => stmt.accept(collector));
+    }
+
+    // 設定函式名稱 → 宣告該屬性的 options 型別
+    const owners = new Map<string, Set<string>>();
+    for (const { typeName, info } of collector.declarations) {
+      for (const prop of info.properties) {
+        const setter = optionSetterName(typeName, prop.name);
+        owners.set(setter, (owners.get(setter) || new Set()).add(typeName));
+      }
+    }
+
+    const assign = (typeName: string, info: OptionsObjectInfo): void => {
+      info.setters = {};
+      for (const prop of info.properties) {
+        const shared = (owners.get(optionSetterName(typeName, prop.name))?.size || 0) > 1;
+        info.setters[prop.name] = optionSetterName(typeName, prop.name, shared);
+      }
+    };
+
+    const declared = new Set<string>();
+    for (const { typeName, info } of collector.declarations) {
+      assign(typeName, info);
+      info.declaresSetters = !declared.has(typeName);
+      declared.add(typeName);
+    }
+    for (const { typeName, info } of collector.calls) {
+      assign(typeName, info);
+    }
+
+    return modules;
+  }
+}
+
+/**
+ * options 物件對應的 Go 型別名稱（與 GoCodeGenerator 相同）：具名型別沿用其名稱，型別字面量為 [Owner]NameOptions
+ */
+function optionsTypeName(param: ir.Parameter, name: string, owner?: string): string {
+  if (param.type instanceof ir.TypeReference) return param.type.name;
+  return `${owner ? capitalize(owner) : ''}${capitalize(name)}Options`;
+}
+
+function capitalize(value: string): string {
+  return value.charAt(0).toUpperCase() + value.slice(1);
+}
+
+/**
+ * WithTimeout；qualified 時加上型別名稱（RequestOptions → WithRequestTimeout），未匯出的型別使用 with
+ */
+function optionSetterName(typeName: string, property: string, qualified = false): string {
+  const prefix = /^[a-z]/.test(typeName) ? 'with' : 'With';
+  const qualifier = qualified ? capitalize(typeName.replace(/Options?$/, '')) : '';
+  return `${prefix}${qualifier}${capitalize(property)}`;
+}
+
+/**
+ * 收集函式 / 方法宣告的 options 物件參數，以及呼叫處（optionalArgs）的 options 物件參數
+ */
+class OptionsObjectCollector extends IRWalker {
+  declarations: { typeName: string; info: OptionsObjectInfo }[] = [];
+  calls: { typeName: string; info: OptionsObjectInfo }[] = [];
+  private className?: string;
+
+  visitFunctionDeclaration(node: ir.FunctionDeclaration): void {
+    this.add(this.declarations, node.parameters, node.name);
+    super.visitFunctionDeclaration(node);
+  }
+
+  visitClassDeclaration(node: ir.ClassDeclaration): void {
+    const outer = this.className;
+    this.className = node.name;
+    super.visitClassDeclaration(node);
+    this.className = outer;
+  }
+
+  visitMethodMember(node: ir.MethodMember): void {
+    this.add(this.declarations, node.parameters, node.name, this.className);
+    super.visitMethodMember(node);
+  }
+
+  visitCallExpression(node: ir.CallExpression): void {
+    const args = node.metadata.get('optionalArgs') as { name: string; owner?: string; parameters: ir.Parameter[] } | undefined;
+    if (args) this.add(this.calls, args.parameters, args.name, args.owner);
+    super.visitCallExpression(node);
+  }
+
+  private add(target: { typeName: string; info: OptionsObjectInfo }[], parameters: ir.Parameter[], name: string, owner?: string): void {
+    const param = parameters[parameters.length - 1];
+    const info = param?.metadata.get('optionsObject') as OptionsObjectInfo | undefined;
+    if (info) target.push({ typeName: optionsTypeName(param, name, owner), info });
+  }
 }
 
 /**
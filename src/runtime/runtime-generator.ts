@@ -27,6 +27,7 @@ export type RuntimeFeature =
   | 'optional'
   | 'union'
   | 'future'
+  | 'timers'
   | 'array'
   | 'type-checking'
   | 'json'
@@ -109,6 +110,7 @@ export class RuntimeGenerator {
       ['optional', /\/\/ ={12,} Optional Chaining Helpers ={12,}[\s\S]*?(?=\/\/ ={12,}|$)/],
       ['union', /\/\/ ={12,} Union Type Helpers ={12,}[\s\S]*?(?=\/\/ ={12,}|$)/],
       ['future', /\/\/ ={12,} Promise\/Future Helpers ={12,}[\s\S]*?(?=\/\/ ={12,}|$)/],
+      ['timers', /\/\/ ={12,} Event Loop Helpers ={12,}[\s\S]*?(?=\/\/ ={12,}|$)/],
       ['array', /\/\/ ={12,} Array Helpers ={12,}[\s\S]*?(?=\/\/ ={12,}|$)/],
       ['type-checking', /\/\/ ={12,} Type Checking Helpers ={12,}[\s\S]*?(?=\/\/ ={12,}|$)/],
       ['json', /\/\/ ={12,} JSON Helpers ={12,}[\s\S]*?(?=\/\/ ={12,}|$)/]
@@ -72,6 +72,11 @@ interface Response<T = any> {
   message: string;
 }
 
+// 來源為型別參數的 utility type 無法具體化為 struct
+function latest<T>(updates: Partial<T>[]): Partial<T> {
+  return updates[updates.length - 1];
+}
+
 // 條件型別 (簡化版)
 type IsString<T> = T extends string ? true : false;
 type IsArray<T> = T extends any[] ? true : false;
@@ -81,6 +86,7 @@ const num = identity(42);
 const str = identity("hello");
 const arr = map([1, 2, 3], x => x * 2);
 const box = new Box<number>(10);
+const label = box.map(x => x * 2).map(x => `#${x}`);
 const pair = new Pair("key", "value");
 
 export { identity, map, Box, Pair, logLength };
\ No newline at end of file
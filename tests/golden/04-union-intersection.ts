@@ -5,6 +5,8 @@
 // Union 型別
 type StringOrNumber = string | number;
 type Status = 'pending' | 'success' | 'error';
+// 空字串與轉換後同名的值
+type Alignment = '' | 'left' | 'top-left' | 'top_left';
 
 function processValue(value: StringOrNumber): string {
   if (typeof value === 'string') {
@@ -43,6 +45,13 @@ function handleResult(result: Result): string {
   }
 }
 
+// 含 discriminated union 欄位的 struct 可由 JSON 解碼
+interface Job {
+  id: string;
+  result: Result;
+  history?: Result[];
+}
+
 // Intersection 型別
 interface Named {
   name: string;
@@ -113,6 +122,26 @@ function processResult(result: Result): void {
   }
 }
 
+// 回傳型別為 union 的物件字面量依判別值建立 variant
+function loadResult(data: string): Result {
+  if (data === '') {
+    return { status: 'error', error: 'empty payload', code: 400 };
+  }
+  return { status: 'success', data };
+}
+
+// case 內同名的參數遮蔽外層的 result，不改寫為窄化後的變數
+function describeResult(result: Result): string {
+  switch (result.status) {
+    case 'error': {
+      const label = (result: string) => `[${result}]`;
+      return label(result.error);
+    }
+    default:
+      return 'ok';
+  }
+}
+
 export {
   processValue,
   handleResult,
@@ -121,6 +150,8 @@ export {
   isError,
   isSuccess,
   processResult,
+  loadResult,
+  describeResult,
   type Result,
   type Person
 };
\ No newline at end of file
@@ -57,6 +57,15 @@ function getKeys(obj: Record<string, any>): string[] {
   return keys;
 }
 
+// Object.keys / Object.entries
+function describeScores(scores: Record<string, number>): string[] {
+  const lines: string[] = [];
+  for (const [name, score] of Object.entries(scores)) {
+    lines.push(`${name}: ${score}`);
+  }
+  return Object.keys(scores).length > 0 ? lines : ["(empty)"];
+}
+
 // 解構賦值
 function processCoordinates(coords: [number, number][]): void {
   for (const [x, y] of coords) {
@@ -75,6 +84,15 @@ const arr2 = [4, 5, 6];
 const combined = [...arr1, ...arr2];
 const cloned = [...arr1];
 
+// 套件層級的 Object.assign（struct）
+interface Theme {
+  color: string;
+  size?: number;
+}
+
+const baseTheme: Theme = { color: "black" };
+const largeTheme = Object.assign({}, baseTheme, { size: 16 });
+
 // Array.from
 function range(start: number, end: number): number[] {
   return Array.from({ length: end - start }, (_, i) => start + i);
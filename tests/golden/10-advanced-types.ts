@@ -164,6 +164,16 @@ function getProperty<T, K extends keyof T>(obj: T, key: K): T[K] {
   return obj[key];
 }
 
+// 行內的 keyof User 沿用已宣告的 UserKey，不另外產生同名的列舉
+function describeField(key: keyof User): string {
+  return `field ${key}`;
+}
+
+// 索引存取型別於具體型別處解析為欄位的型別
+function normalizeName(name: User['name']): User['name'] {
+  return name.trim();
+}
+
 // Branded types (nominal typing simulation)
 type UserId = string & { __brand: 'UserId' };
 type PostId = string & { __brand: 'PostId' };
@@ -172,6 +182,19 @@ function getUserById(id: UserId): User {
   return user;
 }
 
+// 斷言為 branded type 即轉換為具名型別
+function toUserId(raw: string): UserId {
+  return raw as UserId;
+}
+
+// 以 unique symbol 為標記的 branded type；declare 的標記沒有執行期的值
+declare const slugBrand: unique symbol;
+type PostSlug = string & { [slugBrand]: true };
+
+function toPostSlug(title: string): PostSlug {
+  return title.toLowerCase() as PostSlug;
+}
+
 // Type inference in conditional types
 type UnwrapPromise<T> = T extends Promise<infer U> ? U : T;
 type UnwrapArray<T> = T extends (infer U)[] ? U : T;
@@ -179,6 +202,11 @@ type UnwrapArray<T> = T extends (infer U)[] ? U : T;
 type PromiseString = Promise<string>;
 type UnwrappedString = UnwrapPromise<PromiseString>; // string
 
+// 條件型別於具體實例化處解析
+function shout(value: UnwrapPromise<Promise<string>>): UnwrapArray<string[]> {
+  return value.toUpperCase();
+}
+
 export {
   type ReadonlyUser,
   type PartialUser,
@@ -194,7 +222,14 @@ export {
   FluentBuilder,
   processRestTuple,
   getProperty,
+  describeField,
+  normalizeName,
+  shout,
   getUserById,
+  toUserId,
+  toPostSlug,
   type UserId,
-  type PostId
+  type PostId,
+  type PostSlug,
+  type UserKey
 };
\ No newline at end of file
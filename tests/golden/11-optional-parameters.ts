@@ -0,0 +1,44 @@
+/**
+ * 測試 11: 可選與預設值參數
+ */
+
+// 預設值參數：省略的引數傳入 nil，明確傳入的 0、"" 或 false 不會被預設值覆蓋
+function retry(task: string, attempts: number = 3, verbose: boolean = true): string {
+  if (verbose) {
+    return `${task} x${attempts}`;
+  }
+  return task;
+}
+
+// 可選參數
+function label(name: string, suffix?: string): string {
+  if (suffix) {
+    return `${name}-${suffix}`;
+  }
+  return name;
+}
+
+// 箭頭函式與函式運算式
+const scale = (x: number, factor: number = 2): number => x * factor;
+
+const pad = function (text: string, fill: string = " "): string {
+  return fill + text + fill;
+};
+
+// 建構函式
+class Server {
+  constructor(public host: string, public port: number = 80) {}
+}
+
+const defaults = retry("sync");
+const noRetry = retry("sync", 0, false);
+const plain = label("app");
+const empty = label("app", "");
+const doubled = scale(3);
+const zeroed = scale(3, 0);
+const padded = pad("x");
+const unpadded = pad("x", "");
+const server = new Server("localhost");
+const ephemeral = new Server("localhost", 0);
+
+export { retry, label, Server };
@@ -0,0 +1,51 @@
+/**
+ * 測試 12: switch 的窮盡檢查
+ */
+
+// 數字 enum：涵蓋所有成員的 switch 補上 unreachable 的 default
+enum Shape {
+  Circle,
+  Square,
+  Triangle
+}
+
+function sides(shape: Shape): number {
+  switch (shape) {
+    case Shape.Circle:
+      return 0;
+    case Shape.Square:
+      return 4;
+    case Shape.Triangle:
+      return 3;
+  }
+}
+
+// 字面量 union：default 中的 never 斷言僅供 TS 檢查，同樣改為 unreachable 的 default
+type Level = 'low' | 'medium' | 'high';
+
+function weight(level: Level): number {
+  switch (level) {
+    case 'low':
+      return 1;
+    case 'medium':
+      return 5;
+    case 'high':
+      return 10;
+    default:
+      const unreachable: never = level;
+      return unreachable;
+  }
+}
+
+// 未涵蓋所有成員（缺少 'medium'）且沒有 default：不補 default，並產生 W4002
+function label(level: Level): string {
+  switch (level) {
+    case 'low':
+      return 'relaxed';
+    case 'high':
+      return 'urgent';
+  }
+  return 'normal';
+}
+
+export { Shape, Level, sides, weight, label };
@@ -0,0 +1,59 @@
+/**
+ * 測試 13: Generator、yield* 與 for await
+ * 預設 goVersion 1.22 沒有 range-over-func 迭代器，generator 降階為 *runtime.Stream
+ */
+
+// yield
+function* digits(): Generator<number> {
+  yield 1;
+  yield 2;
+}
+
+// yield* 轉交另一個 generator 與陣列的值
+function* sequence(extra: number[]): Generator<number> {
+  yield* digits();
+  yield* extra;
+  yield 0;
+}
+
+// for...of 中提前 return：離開函式前 Close stream
+function findAbove(limit: number): number {
+  for (const value of sequence([5, 8])) {
+    if (value > limit) {
+      return value;
+    }
+  }
+  return -1;
+}
+
+// 外層迴圈中的 for...of：每次走訪結束即 Close，不累積到函式結束
+function countAll(rounds: number[]): number {
+  let count = 0;
+  for (const round of rounds) {
+    for (const value of sequence([round])) {
+      if (value === 0) {
+        break;
+      }
+      count++;
+    }
+  }
+  return count;
+}
+
+// async generator 與 for await：迴圈結束後由 Close 取得 producer 的 error
+async function* countdown(from: number): AsyncGenerator<number> {
+  for (let n = from; n > 0; n--) {
+    yield n;
+  }
+}
+
+async function firstEven(from: number): Promise<number> {
+  for await (const n of countdown(from)) {
+    if (n % 2 === 0) {
+      return n;
+    }
+  }
+  return 0;
+}
+
+export { digits, sequence, findAbove, countAll, countdown, firstEven };
@@ -0,0 +1,40 @@
+/**
+ * 測試 14: Promise 組合子
+ * Promise.all/race/any 降階為 runtime.AllTasks/RaceTasks/AnyTasks，結果確定後即取消其餘工作
+ */
+
+async function fetchName(id: string): Promise<string> {
+  return `user-${id}`;
+}
+
+async function touch(id: string): Promise<void> {
+  await fetchName(id);
+}
+
+// 以 async 回呼 map 的陣列：每個元素一個工作
+async function loadNames(ids: string[]): Promise<string[]> {
+  const names = await Promise.all(ids.map(async (id) => {
+    const name = await fetchName(id);
+    return name.toUpperCase();
+  }));
+  return names;
+}
+
+// void 的工作只回報錯誤
+async function touchAll(ids: string[]): Promise<void> {
+  await Promise.all(ids.map(async (id) => {
+    await touch(id);
+  }));
+}
+
+// 第一個完成者勝出，其餘工作取消
+async function firstName(a: string, b: string): Promise<string> {
+  return await Promise.race([fetchName(a), fetchName(b)]);
+}
+
+// 全部失敗時回傳 *runtime.AggregateError
+async function anyName(ids: string[]): Promise<string> {
+  return await Promise.any(ids.map(id => fetchName(id)));
+}
+
+export { fetchName, touch, loadNames, touchAll, firstName, anyName };
@@ -0,0 +1,39 @@
+/**
+ * 測試 15: 函式多載
+ * 每個多載簽名降階為以參數型別為後綴的變體，共用不匯出的實作
+ */
+
+interface Point {
+  x: number;
+  y: number;
+}
+
+interface Span {
+  start: number;
+  end: number;
+}
+
+// 實作回傳較寬的 Point | Span，變體斷言回各自簽名的回傳型別
+function parse(x: string): Point;
+function parse(x: number): Span;
+function parse(x: string | number): Point | Span {
+  if (typeof x === 'string') {
+    return { x: x.length, y: 0 };
+  }
+  return { start: 0, end: x };
+}
+
+// 回傳型別與實作相同時不需斷言；各簽名相同的 width 不出現在名稱中
+function pad(value: string, width: number): string;
+function pad(value: number, width: number): string;
+function pad(value: string | number, width: number): string {
+  return `${value}`.padStart(width);
+}
+
+function summarize(text: string, size: number): string {
+  const point = parse(text);
+  const span = parse(size);
+  return `${pad(point.x, 3)}..${pad(span.end, 3)}`;
+}
+
+export { Point, Span, parse, pad, summarize };
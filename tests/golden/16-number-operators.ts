@@ -0,0 +1,44 @@
+/**
+ * 測試 16: 數值運算子
+ * Go 沒有或語義不同的 **、%、>>> 及其複合指定依 JS 的 float64 語義降階
+ */
+
+// ** → math.Pow
+function distance(dx: number, dy: number): number {
+  return Math.sqrt(dx ** 2 + dy ** 2);
+}
+
+// % 的結果與被除數同號（math.Mod），負的索引需再加上 size
+function wrapIndex(index: number, size: number): number {
+  const r = index % size;
+  if (r < 0) {
+    return r + size;
+  }
+  return r;
+}
+
+// >>> 以 uint32 運算；>>> 0 只轉為無號整數
+function toUnsigned(value: number): number {
+  return value >>> 0;
+}
+
+// 位移量只取低 5 位元
+function highBits(value: number, bits: number): number {
+  return value >>> (32 - bits);
+}
+
+// 複合指定展開為 x = x ** y
+function compound(base: number, exponent: number): number {
+  let result = base;
+  result **= exponent;
+  result %= 1000;
+  result >>>= 1;
+  return result;
+}
+
+// 字面量依 JS 的結果折疊
+function masks(): number[] {
+  return [-1 >>> 0, 1 << 31, 2 ** 10, -7 % 2];
+}
+
+export { distance, wrapIndex, toUnsigned, highBits, compound, masks };
@@ -0,0 +1,39 @@
+/**
+ * 測試 17: AbortController 與 AbortSignal
+ * AbortController 降階為 context.WithCancel / WithTimeout，signal 即衍生的 ctx，abort() 為其 cancel
+ */
+
+async function download(url: string, signal: AbortSignal): Promise<string> {
+  if (signal.aborted) {
+    throw new Error('Download aborted');
+  }
+  return `body of ${url}`;
+}
+
+// setTimeout 中止併入為 context.WithTimeout，函式結束時以 defer 釋放
+async function downloadWithin(url: string, ms: number): Promise<string> {
+  const controller = new AbortController();
+  setTimeout(() => controller.abort(), ms);
+  return await download(url, controller.signal);
+}
+
+// AbortSignal.timeout 提升為 context.WithTimeout
+async function downloadQuickly(url: string): Promise<string> {
+  return await download(url, AbortSignal.timeout(500));
+}
+
+// 迴圈中的 controller 於每次迭代離開前釋放，不以 defer 累積至函式結束
+async function downloadAll(urls: string[]): Promise<string[]> {
+  const bodies: string[] = [];
+  for (const url of urls) {
+    const controller = new AbortController();
+    const body = await download(url, controller.signal);
+    if (body === '') {
+      return bodies;
+    }
+    bodies.push(body);
+  }
+  return bodies;
+}
+
+export { download, downloadWithin, downloadQuickly, downloadAll };
@@ -33,9 +33,10 @@ type Tuple3_string_float64_bool struct {
 
 var tuple3 = Tuple3_string_float64_bool{"test", 1, true}
 
-func Greet(name string, age *float64, title string) string {
-	if title == "" {
-		title = "Mr."
+func Greet(name string, age *float64, titleArg *string) string {
+	title := "Mr."
+	if titleArg != nil {
+		title = *titleArg
 	}
 	if age != nil {
 		return fmt.Sprintf("%s %s, age %v", title, name, *age)
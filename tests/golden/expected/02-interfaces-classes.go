@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"slices"
 	"time"
 )
 
@@ -56,12 +57,7 @@ func NewAdminUser(id string, name string, email string, permissions []string) *A
 }
 
 func (a *AdminUser) HasPermission(permission string) bool {
-	for _, p := range a.Permissions {
-		if p == permission {
-			return true
-		}
-	}
-	return false
+	return slices.Contains(a.Permissions, permission)
 }
 
 type Counter struct {
@@ -39,12 +39,14 @@ func MapBox[T any, U any](b *Box[T], fn func(T) U) *Box[U] {
 	return NewBox(fn(b.Value))
 }
 
-type Lengthwise interface {
-	Len() int
+type Lengthwise struct {
+	Length float64
 }
 
-func LogLength[T Lengthwise](arg T) T {
-	fmt.Println(arg.Len())
+func (v Lengthwise) GetLength() float64 { return v.Length }
+
+func LogLength[T interface{ GetLength() float64 }](arg T) T {
+	fmt.Println(arg.GetLength())
 	return arg
 }
 
@@ -71,10 +73,15 @@ type Response[T any] struct {
 	Message string
 }
 
+func latest[T any](updates []T) T {
+	return updates[len(updates)-1]
+}
+
 var (
-	num  = Identity(42)
-	str  = Identity("hello")
-	arr  = Map([]int{1, 2, 3}, func(x int) int { return x * 2 })
-	box  = NewBox(10)
-	pair = NewPair("key", "value")
+	num   = Identity(42)
+	str   = Identity("hello")
+	arr   = Map([]int{1, 2, 3}, func(x int) int { return x * 2 })
+	box   = NewBox(10)
+	label = MapBox(MapBox(box, func(x int) int { return x * 2 }), func(x int) string { return fmt.Sprintf("#%d", x) })
+	pair  = NewPair("key", "value")
 )
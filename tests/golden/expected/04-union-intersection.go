@@ -1,45 +1,73 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
+
+	"ts2go/runtime"
 )
 
 type StringOrNumber struct {
 	tag    int
-	str    *string
-	number *float64
-}
-
-func NewStringOrNumberFromString(s string) StringOrNumber {
-	return StringOrNumber{tag: 0, str: &s}
+	value0 *string
+	value1 *float64
 }
 
-func NewStringOrNumberFromNumber(n float64) StringOrNumber {
-	return StringOrNumber{tag: 1, number: &n}
-}
-
-func (u StringOrNumber) IsString() bool {
-	return u.tag == 0
+func (u StringOrNumber) IsType0() bool { return u.tag == 0 }
+func (u StringOrNumber) AsType0() string {
+	if u.value0 != nil {
+		return *u.value0
+	}
+	var zero string
+	return zero
 }
 
-func (u StringOrNumber) IsNumber() bool {
-	return u.tag == 1
+func (u StringOrNumber) IsType1() bool { return u.tag == 1 }
+func (u StringOrNumber) AsType1() float64 {
+	if u.value1 != nil {
+		return *u.value1
+	}
+	var zero float64
+	return zero
 }
 
-func (u StringOrNumber) AsString() string {
-	if u.str != nil {
-		return *u.str
+func (u StringOrNumber) MarshalJSON() ([]byte, error) {
+	switch u.tag {
+	case 0:
+		return json.Marshal(u.value0)
+	case 1:
+		return json.Marshal(u.value1)
 	}
-	return ""
+	return []byte("null"), nil
 }
 
-func (u StringOrNumber) AsNumber() float64 {
-	if u.number != nil {
-		return *u.number
+func (u *StringOrNumber) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = StringOrNumber{}
+		return nil
+	}
+	{
+		var v string
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&v); err == nil {
+			*u = StringOrNumber{tag: 0, value0: &v}
+			return nil
+		}
+	}
+	{
+		var v float64
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&v); err == nil {
+			*u = StringOrNumber{tag: 1, value1: &v}
+			return nil
+		}
 	}
-	return 0
+	return fmt.Errorf("StringOrNumber: no variant matches %s", data)
 }
 
 type Status string
@@ -50,11 +78,20 @@ const (
 	StatusError   Status = "error"
 )
 
+type Alignment string
+
+const (
+	AlignmentEmpty    Alignment = ""
+	AlignmentLeft     Alignment = "left"
+	AlignmentTopLeft  Alignment = "top-left"
+	AlignmentTopLeft2 Alignment = "top_left"
+)
+
 func ProcessValue(value StringOrNumber) string {
-	if value.IsString() {
-		return value.AsString()
+	if value.IsType0() {
+		return strings.ToUpper(value.AsType0())
 	} else {
-		return fmt.Sprintf("%.2f", value.AsNumber())
+		return runtime.ToFixed(value.AsType1(), 2)
 	}
 }
 
@@ -63,6 +100,31 @@ type Result interface {
 	GetStatus() string
 }
 
+// UnmarshalResult 依判別欄位 status 解碼 Result
+func UnmarshalResult(data []byte) (Result, error) {
+	var probe struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	switch probe.Status {
+	case "success":
+		var v SuccessResult
+		err := json.Unmarshal(data, &v)
+		return v, err
+	case "error":
+		var v ErrorResult
+		err := json.Unmarshal(data, &v)
+		return v, err
+	case "loading":
+		var v LoadingResult
+		err := json.Unmarshal(data, &v)
+		return v, err
+	}
+	return nil, fmt.Errorf("Result: unknown status %v", probe.Status)
+}
+
 type SuccessResult struct {
 	Status string
 	Data   interface{}
@@ -97,10 +159,49 @@ func HandleResult(result Result) string {
 	case LoadingResult:
 		return "Loading..."
 	default:
-		return ""
+		panic(fmt.Sprintf("unreachable: %v", result))
 	}
 }
 
+type Job struct {
+	Id      string    `json:"id"`
+	Result  Result    `json:"result"`
+	History *[]Result `json:"history,omitempty"`
+}
+
+// UnmarshalJSON 以 UnmarshalResult 等解碼 sealed union 欄位
+func (v *Job) UnmarshalJSON(data []byte) error {
+	type plain Job
+	var raw struct {
+		*plain
+		Result  json.RawMessage   `json:"result"`
+		History []json.RawMessage `json:"history"`
+	}
+	raw.plain = (*plain)(v)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Result) > 0 && string(raw.Result) != "null" {
+		value, err := UnmarshalResult(raw.Result)
+		if err != nil {
+			return err
+		}
+		v.Result = value
+	}
+	if raw.History != nil {
+		items := make([]Result, len(raw.History))
+		for i, item := range raw.History {
+			value, err := UnmarshalResult(item)
+			if err != nil {
+				return err
+			}
+			items[i] = value
+		}
+		v.History = &items
+	}
+	return nil
+}
+
 type Named struct {
 	Name string
 }
@@ -189,4 +290,23 @@ func ProcessResult(result Result) {
 	} else {
 		fmt.Println("Still loading...")
 	}
-}
\ No newline at end of file
+}
+
+func LoadResult(data string) Result {
+	if data == "" {
+		return ErrorResult{Status: "error", Error: "empty payload", Code: 400}
+	}
+	return SuccessResult{Status: "success", Data: data}
+}
+
+func DescribeResult(result Result) string {
+	switch r := result.(type) {
+	case ErrorResult:
+		label := func(result string) string {
+			return fmt.Sprintf("[%s]", result)
+		}
+		return label(r.Error)
+	default:
+		return "ok"
+	}
+}
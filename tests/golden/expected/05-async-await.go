@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
+
+	"ts2go/runtime"
 )
 
 func FetchData(ctx context.Context, url string) (string, error) {
@@ -11,9 +14,10 @@ func FetchData(ctx context.Context, url string) (string, error) {
 	return fmt.Sprintf("Data from %s", url), nil
 }
 
-func FetchWithRetry(ctx context.Context, url string, maxRetries int) (string, error) {
-	if maxRetries == 0 {
-		maxRetries = 3
+func FetchWithRetry(ctx context.Context, url string, maxRetriesArg *int) (string, error) {
+	maxRetries := 3
+	if maxRetriesArg != nil {
+		maxRetries = *maxRetriesArg
 	}
 
 	var lastError error
@@ -92,18 +96,18 @@ func FetchFirstAvailable(ctx context.Context, urls []string) (string, error) {
 }
 
 type DataService struct {
-	cache map[string]string
+	cache *runtime.OrderedMap[string, string]
 }
 
 func NewDataService() *DataService {
 	return &DataService{
-		cache: make(map[string]string),
+		cache: runtime.NewOrderedMap[string, string](),
 	}
 }
 
 func (d *DataService) GetData(ctx context.Context, key string) (string, error) {
-	if val, ok := d.cache[key]; ok {
-		return val, nil
+	if d.cache.Has(key) {
+		return d.cache.Get(key), nil
 	}
 
 	data, err := FetchData(ctx, key)
@@ -111,12 +115,12 @@ func (d *DataService) GetData(ctx context.Context, key string) (string, error) {
 		return "", err
 	}
 
-	d.cache[key] = data
+	d.cache.Set(key, data)
 	return data, nil
 }
 
 func (d *DataService) ClearCache(ctx context.Context) error {
-	d.cache = make(map[string]string)
+	d.cache.Clear()
 	return nil
 }
 
@@ -135,32 +139,37 @@ func FetchAndProcess(ctx context.Context, url string) (int, error) {
 		return 0, nil
 	}
 
-	upperData := data
+	upperData := strings.ToUpper(data)
 
 	return len(upperData), nil
 }
 
-func GenerateData(ctx context.Context, count int) <-chan int {
-	ch := make(chan int)
-	go func() {
-		defer close(ch)
+func GenerateData(ctx context.Context, count int) *runtime.Stream[int] {
+	return runtime.Generate(ctx, func(ctx context.Context, yield func(int) bool) error {
 		for i := 0; i < count; i++ {
 			select {
 			case <-ctx.Done():
-				return
+				return ctx.Err()
 			case <-time.After(10 * time.Millisecond):
-				ch <- i
+			}
+			if !yield(i) {
+				return nil
 			}
 		}
-	}()
-	return ch
+		return nil
+	})
 }
 
 func ConsumeGenerator(ctx context.Context) ([]int, error) {
 	results := make([]int, 0)
-	for value := range GenerateData(ctx, 5) {
+	stream := GenerateData(ctx, 5)
+	for stream.Next() {
+		value := stream.Value()
 		results = append(results, value)
 	}
+	if err := stream.Close(); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
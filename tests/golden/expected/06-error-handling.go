@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 type ValidationError struct {
@@ -70,7 +71,7 @@ func ProcessData(data interface{}) (string, error) {
 		return "", fmt.Errorf("Empty data")
 	}
 
-	return str, nil
+	return strings.ToUpper(str), nil
 }
 
 func Divide(a float64, b float64) (float64, error) {
@@ -130,7 +131,7 @@ func FetchWithErrorHandling(url string) Result[string] {
 		}
 	}()
 
-	if len(url) > 0 && url[0] == 'e' {
+	if strings.Contains(url, "error") {
 		return Result[string]{
 			Success: false,
 			Error:   NewNetworkError("Failed to fetch", 404, url),
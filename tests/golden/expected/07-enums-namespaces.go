@@ -1,6 +1,11 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"ts2go/runtime"
+)
 
 type Direction int
 
@@ -76,12 +81,12 @@ func Move(direction Direction) string {
 
 type Utils struct{}
 
-func (Utils) FormatDate(date string) string {
-	return date
+func (Utils) FormatDate(date time.Time) string {
+	return date.UTC().Format("2006-01-02T15:04:05.000Z")
 }
 
-func (Utils) ParseDate(str string) string {
-	return str
+func (Utils) ParseDate(str string) (time.Time, error) {
+	return runtime.ParseDate(str)
 }
 
 const UtilsVERSION = "1.0.0"
@@ -111,7 +116,7 @@ func (UtilsInternal) SecretFunction() string {
 
 var (
 	logger  = NewUtilsLogger("App")
-	dateStr = Utils{}.FormatDate("")
+	dateStr = Utils{}.FormatDate(time.Now())
 	config  = UtilsConfig{
 		Debug:   true,
 		Timeout: 5000,
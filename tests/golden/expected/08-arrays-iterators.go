@@ -1,50 +1,21 @@
 package main
 
+import (
+	"context"
+	"fmt"
+
+	"ts2go/runtime"
+)
+
 var (
 	numbers  = []int{1, 2, 3, 4, 5}
-	doubled  []int
-	filtered []int
-	sum      int
+	doubled  = runtime.Map(numbers, func(n int) int { return n * 2 })
+	filtered = runtime.Filter(numbers, func(n int) bool { return n > 2 })
+	sum      = runtime.Reduce(numbers, 0, func(acc int, n int) int { return acc + n })
 )
 
-func init() {
-	doubled = make([]int, len(numbers))
-	for i, n := range numbers {
-		doubled[i] = n * 2
-	}
-
-	filtered = make([]int, 0)
-	for _, n := range numbers {
-		if n > 2 {
-			filtered = append(filtered, n)
-		}
-	}
-
-	sum = 0
-	for _, n := range numbers {
-		sum += n
-	}
-}
-
 func ProcessNumbers(nums []int) int {
-	filtered := make([]int, 0)
-	for _, n := range nums {
-		if n > 0 {
-			filtered = append(filtered, n)
-		}
-	}
-
-	mapped := make([]int, len(filtered))
-	for i, n := range filtered {
-		mapped[i] = n * 2
-	}
-
-	sum := 0
-	for _, n := range mapped {
-		sum += n
-	}
-
-	return sum
+	return runtime.Reduce(runtime.Map(runtime.Filter(nums, func(n int) bool { return n > 0 }), func(n int) int { return n * 2 }), 0, func(sum int, n int) int { return sum + n })
 }
 
 func First[T any](arr []T) *T {
@@ -62,20 +33,13 @@ func Last[T any](arr []T) *T {
 }
 
 func Take[T any](arr []T, n int) []T {
-	if n > len(arr) {
-		n = len(arr)
-	}
-	return arr[0:n]
+	return runtime.Slice(arr, 0, n)
 }
 
 func Chunk[T any](arr []T, size int) [][]T {
 	chunks := make([][]T, 0)
 	for i := 0; i < len(arr); i += size {
-		end := i + size
-		if end > len(arr) {
-			end = len(arr)
-		}
-		chunks = append(chunks, arr[i:end])
+		chunks = append(chunks, runtime.Slice(arr, i, i+size))
 	}
 	return chunks
 }
@@ -89,13 +53,26 @@ func SumArray(numbers []int) int {
 }
 
 func GetKeys(obj map[string]interface{}) []string {
-	keys := make([]string, 0, len(obj))
-	for key := range obj {
+	keys := []string{}
+	for _, key := range runtime.SortedKeys(obj) {
 		keys = append(keys, key)
 	}
 	return keys
 }
 
+func DescribeScores(scores map[string]float64) []string {
+	lines := []string{}
+	for _, entry := range runtime.SortedEntries(scores) {
+		name := entry.Key
+		score := entry.Value
+		lines = append(lines, fmt.Sprintf("%s: %v", name, score))
+	}
+	if len(scores) > 0 {
+		return lines
+	}
+	return []string{"(empty)"}
+}
+
 func ProcessCoordinates(coords [][2]int) {
 	for _, coord := range coords {
 		x, y := coord[0], coord[1]
@@ -123,6 +100,18 @@ func init() {
 	cloned = append([]int{}, arr1...)
 }
 
+type Theme struct {
+	Color string
+	Size  *int
+}
+
+var baseTheme = Theme{Color: "black"}
+var largeTheme = func() Theme {
+	merged := baseTheme
+	merged.Size = runtime.Ptr[int](16)
+	return merged
+}()
+
 func Range(start int, end int) []int {
 	length := end - start
 	result := make([]int, length)
@@ -149,43 +138,35 @@ func NewRange(start int, end int, step int) *RangeIterator {
 	}
 }
 
-func (r *RangeIterator) Iterator() <-chan int {
-	ch := make(chan int)
-	go func() {
-		defer close(ch)
+func (r *RangeIterator) Iterator() *runtime.Stream[int] {
+	return runtime.Generate(context.Background(), func(ctx context.Context, yield func(int) bool) error {
 		for i := r.start; i < r.end; i += r.step {
-			ch <- i
+			if !yield(i) {
+				return nil
+			}
 		}
-	}()
-	return ch
+		return nil
+	})
 }
 
 func (r *RangeIterator) ToArray() []int {
 	result := make([]int, 0)
-	for val := range r.Iterator() {
-		result = append(result, val)
+	stream := r.Iterator()
+	for stream.Next() {
+		result = append(result, stream.Value())
 	}
+	stream.Close()
 	return result
 }
 
 func UniqueValues[T comparable](arr []T) []T {
-	seen := make(map[T]bool)
-	result := make([]T, 0)
-
-	for _, item := range arr {
-		if !seen[item] {
-			seen[item] = true
-			result = append(result, item)
-		}
-	}
-
-	return result
+	return runtime.NewOrderedSet[T](arr...).Values()
 }
 
-func CountOccurrences[T comparable](arr []T) map[T]int {
-	counts := make(map[T]int)
+func CountOccurrences[T comparable](arr []T) *runtime.OrderedMap[T, int] {
+	counts := runtime.NewOrderedMap[T, int]()
 	for _, item := range arr {
-		counts[item]++
+		counts.Set(item, counts.Get(item)+1)
 	}
 	return counts
 }
@@ -205,7 +186,7 @@ func Partition[T any](arr []T, predicate func(T) bool) ([]T, []T) {
 	return pass, fail
 }
 
-func GroupBy[T any, K comparable](arr []T, keyFn func(T) K) map[K][]T {
+func GroupBy[T any, K ~string | ~float64](arr []T, keyFn func(T) K) map[K][]T {
 	groups := make(map[K][]T)
 
 	for _, item := range arr {
@@ -1,5 +1,7 @@
 package main
 
+import "ts2go/runtime"
+
 const (
 	API_VERSION  = "1.0.0"
 	API_ENDPOINT = "https://api.example.com"
@@ -110,7 +112,15 @@ func NewHttpRequest(url string, method HttpMethod) *HttpRequest {
 	}
 }
 
-func Fetch(url string, options *RequestOptions) (interface{}, error) {
+func Fetch(url string) (interface{}, error) {
+	return fetchImpl(url, nil)
+}
+
+func FetchWithOptions(url string, options RequestOptions) (interface{}, error) {
+	return fetchImpl(url, runtime.Ptr(options))
+}
+
+func fetchImpl(url string, options *RequestOptions) (interface{}, error) {
 	return map[string]interface{}{
 		"url":     url,
 		"options": options,
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 type User struct {
@@ -205,13 +206,30 @@ func ProcessNullable(value *string) string {
 	if value == nil {
 		panic("value is nil")
 	}
-	return *value
+	return strings.ToUpper(*value)
 }
 
+type UserKey string
+
+const (
+	UserKeyId    UserKey = "id"
+	UserKeyName  UserKey = "name"
+	UserKeyEmail UserKey = "email"
+	UserKeyAge   UserKey = "age"
+)
+
 func GetProperty(obj map[string]interface{}, key string) interface{} {
 	return obj[key]
 }
 
+func DescribeField(key UserKey) string {
+	return fmt.Sprintf("field %s", key)
+}
+
+func NormalizeName(name string) string {
+	return strings.TrimSpace(name)
+}
+
 type UserId string
 type PostId string
 
@@ -219,6 +237,20 @@ func GetUserById(id UserId) User {
 	return user
 }
 
+func ToUserId(raw string) UserId {
+	return UserId(raw)
+}
+
+type PostSlug string
+
+func ToPostSlug(title string) PostSlug {
+	return PostSlug(strings.ToLower(title))
+}
+
+func Shout(value string) string {
+	return strings.ToUpper(value)
+}
+
 func ExampleUsage() {
 	var value interface{} = "test"
 	if IsString(value) {
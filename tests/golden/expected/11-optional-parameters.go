@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"ts2go/runtime"
+)
+
+func Retry(task string, attemptsArg *float64, verboseArg *bool) string {
+	var attempts float64 = 3
+	if attemptsArg != nil {
+		attempts = *attemptsArg
+	}
+	verbose := true
+	if verboseArg != nil {
+		verbose = *verboseArg
+	}
+	if verbose {
+		return fmt.Sprintf("%s x%v", task, attempts)
+	}
+	return task
+}
+
+func Label(name string, suffix *string) string {
+	if suffix != nil && *suffix != "" {
+		return fmt.Sprintf("%s-%s", name, *suffix)
+	}
+	return name
+}
+
+var scale = func(x float64, factorArg *float64) float64 {
+	var factor float64 = 2
+	if factorArg != nil {
+		factor = *factorArg
+	}
+	return x * factor
+}
+
+var pad = func(text string, fillArg *string) string {
+	fill := " "
+	if fillArg != nil {
+		fill = *fillArg
+	}
+	return fill + text + fill
+}
+
+type Server struct {
+	Host string
+	Port float64
+}
+
+func NewServer(host string, portArg *float64) *Server {
+	var port float64 = 80
+	if portArg != nil {
+		port = *portArg
+	}
+	return &Server{
+		Host: host,
+		Port: port,
+	}
+}
+
+var defaults = Retry("sync", nil, nil)
+var noRetry = Retry("sync", runtime.Ptr[float64](0), runtime.Ptr[bool](false))
+var plain = Label("app", nil)
+var empty = Label("app", runtime.Ptr[string](""))
+var doubled = scale(3, nil)
+var zeroed = scale(3, runtime.Ptr[float64](0))
+var padded = pad("x", nil)
+var unpadded = pad("x", runtime.Ptr[string](""))
+var server = NewServer("localhost", nil)
+var ephemeral = NewServer("localhost", runtime.Ptr[float64](0))
@@ -0,0 +1,68 @@
+package main
+
+import "fmt"
+
+type Shape int
+
+const (
+	ShapeCircle Shape = iota
+	ShapeSquare
+	ShapeTriangle
+)
+
+func (s Shape) String() string {
+	switch s {
+	case ShapeCircle:
+		return "Circle"
+	case ShapeSquare:
+		return "Square"
+	case ShapeTriangle:
+		return "Triangle"
+	default:
+		return "Unknown"
+	}
+}
+
+func Sides(shape Shape) float64 {
+	switch shape {
+	case ShapeCircle:
+		return 0
+	case ShapeSquare:
+		return 4
+	case ShapeTriangle:
+		return 3
+	default:
+		panic(fmt.Sprintf("unreachable: %v", shape))
+	}
+}
+
+type Level string
+
+const (
+	LevelLow    Level = "low"
+	LevelMedium Level = "medium"
+	LevelHigh   Level = "high"
+)
+
+func Weight(level Level) float64 {
+	switch level {
+	case "low":
+		return 1
+	case "medium":
+		return 5
+	case "high":
+		return 10
+	default:
+		panic(fmt.Sprintf("unreachable: %v", level))
+	}
+}
+
+func Label(level Level) string {
+	switch level {
+	case "low":
+		return "relaxed"
+	case "high":
+		return "urgent"
+	}
+	return "normal"
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"math"
+
+	"ts2go/runtime"
+)
+
+func Digits() *runtime.Stream[float64] {
+	return runtime.Generate(context.Background(), func(ctx context.Context, yield func(float64) bool) error {
+		if !yield(1) {
+			return nil
+		}
+		if !yield(2) {
+			return nil
+		}
+		return nil
+	})
+}
+
+func Sequence(extra []float64) *runtime.Stream[float64] {
+	return runtime.Generate(context.Background(), func(ctx context.Context, yield func(float64) bool) error {
+		if err := Digits().Each(yield); err != nil {
+			return err
+		}
+		for _, v := range extra {
+			if !yield(v) {
+				return nil
+			}
+		}
+		if !yield(0) {
+			return nil
+		}
+		return nil
+	})
+}
+
+func FindAbove(limit float64) float64 {
+	stream := Sequence([]float64{5, 8})
+	for stream.Next() {
+		value := stream.Value()
+		if value > limit {
+			stream.Close()
+			return value
+		}
+	}
+	stream.Close()
+	return -1
+}
+
+func CountAll(rounds []float64) float64 {
+	var count float64 = 0
+	for _, round := range rounds {
+		stream := Sequence([]float64{round})
+		for stream.Next() {
+			value := stream.Value()
+			if value == 0 {
+				break
+			}
+			count++
+		}
+		stream.Close()
+	}
+	return count
+}
+
+func Countdown(ctx context.Context, from float64) *runtime.Stream[float64] {
+	return runtime.Generate(ctx, func(ctx context.Context, yield func(float64) bool) error {
+		for n := from; n > 0; n-- {
+			if !yield(n) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func FirstEven(ctx context.Context, from float64) (float64, error) {
+	stream := Countdown(ctx, from)
+	for stream.Next() {
+		n := stream.Value()
+		if math.Mod(n, 2) == 0 {
+			stream.Close()
+			return n, nil
+		}
+	}
+	if err := stream.Close(); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ts2go/runtime"
+)
+
+func FetchName(ctx context.Context, id string) (string, error) {
+	return fmt.Sprintf("user-%s", id), nil
+}
+
+func Touch(ctx context.Context, id string) error {
+	if _, err := FetchName(ctx, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func LoadNames(ctx context.Context, ids []string) ([]string, error) {
+	tasks := make([]runtime.Task[string], len(ids))
+	for i, id := range ids {
+		tasks[i] = func(ctx context.Context) (string, error) {
+			name, err := FetchName(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			return strings.ToUpper(name), nil
+		}
+	}
+	names, err := runtime.AllTasks(ctx, tasks...)
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func TouchAll(ctx context.Context, ids []string) error {
+	tasks := make([]runtime.Task[struct{}], len(ids))
+	for i, id := range ids {
+		tasks[i] = func(ctx context.Context) (struct{}, error) {
+			if err := Touch(ctx, id); err != nil {
+				return struct{}{}, err
+			}
+			return struct{}{}, nil
+		}
+	}
+	if _, err := runtime.AllTasks(ctx, tasks...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func FirstName(ctx context.Context, a string, b string) (string, error) {
+	return runtime.RaceTasks(ctx, func(ctx context.Context) (string, error) {
+		return FetchName(ctx, a)
+	}, func(ctx context.Context) (string, error) {
+		return FetchName(ctx, b)
+	})
+}
+
+func AnyName(ctx context.Context, ids []string) (string, error) {
+	tasks := make([]runtime.Task[string], len(ids))
+	for i, id := range ids {
+		tasks[i] = func(ctx context.Context) (string, error) {
+			return FetchName(ctx, id)
+		}
+	}
+	return runtime.AnyTasks(ctx, tasks...)
+}
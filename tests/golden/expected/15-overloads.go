@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"ts2go/runtime"
+)
+
+type Point struct {
+	X float64
+	Y float64
+}
+
+type Span struct {
+	Start float64
+	End   float64
+}
+
+func ParseString(x string) Point {
+	return parseImpl(x).(Point)
+}
+
+func ParseNumber(x float64) Span {
+	return parseImpl(x).(Span)
+}
+
+func parseImpl(x interface{}) interface{} {
+	if s, ok := x.(string); ok {
+		return Point{X: float64(len(s)), Y: 0}
+	}
+	return Span{Start: 0, End: x.(float64)}
+}
+
+func PadString(value string, width float64) string {
+	return padImpl(value, width)
+}
+
+func PadNumber(value float64, width float64) string {
+	return padImpl(value, width)
+}
+
+func padImpl(value interface{}, width float64) string {
+	return runtime.PadStart(fmt.Sprint(value), width)
+}
+
+func Summarize(text string, size float64) string {
+	point := ParseString(text)
+	span := ParseNumber(size)
+	return fmt.Sprintf("%s..%s", PadNumber(point.X, 3), PadNumber(span.End, 3))
+}
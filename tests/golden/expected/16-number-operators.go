@@ -0,0 +1,39 @@
+package main
+
+import (
+	"math"
+
+	"ts2go/runtime"
+)
+
+func Distance(dx float64, dy float64) float64 {
+	return math.Sqrt(math.Pow(dx, 2) + math.Pow(dy, 2))
+}
+
+func WrapIndex(index float64, size float64) float64 {
+	r := math.Mod(index, size)
+	if r < 0 {
+		return r + size
+	}
+	return r
+}
+
+func ToUnsigned(value float64) float64 {
+	return float64(runtime.ToUint32(value))
+}
+
+func HighBits(value float64, bits float64) float64 {
+	return float64(runtime.ToUint32(value) >> (runtime.ToUint32(32-bits) & 31))
+}
+
+func Compound(base float64, exponent float64) float64 {
+	result := base
+	result = math.Pow(result, exponent)
+	result = math.Mod(result, 1000)
+	result = float64(runtime.ToUint32(result) >> 1)
+	return result
+}
+
+func Masks() []float64 {
+	return []float64{4294967295, -2147483648, math.Pow(2, 10), math.Mod(-7, 2)}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func Download(ctx context.Context, url string, signal context.Context) (string, error) {
+	if signal.Err() != nil {
+		return "", fmt.Errorf("Download aborted")
+	}
+	return fmt.Sprintf("body of %s", url), nil
+}
+
+func DownloadWithin(ctx context.Context, url string, ms float64) (string, error) {
+	controllerCtx, controllerCancel := context.WithTimeout(ctx, time.Duration(ms)*time.Millisecond)
+	defer controllerCancel()
+	return Download(ctx, url, controllerCtx)
+}
+
+func DownloadQuickly(ctx context.Context, url string) (string, error) {
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer timeoutCancel()
+	return Download(ctx, url, timeoutCtx)
+}
+
+func DownloadAll(ctx context.Context, urls []string) ([]string, error) {
+	bodies := []string{}
+	for _, url := range urls {
+		controllerCtx, controllerCancel := context.WithCancel(ctx)
+		body, err := Download(ctx, url, controllerCtx)
+		if err != nil {
+			controllerCancel()
+			return nil, err
+		}
+		if body == "" {
+			controllerCancel()
+			return bodies, nil
+		}
+		bodies = append(bodies, body)
+		controllerCancel()
+	}
+	return bodies, nil
+}
@@ -3,8 +3,11 @@
  * 測試 TypeScript 到 Go 的轉譯是否符合預期
  */
 
+import * as path from 'path';
 import { runGoldenTest } from '../helpers/golden-test';
 import { GoldenTestRunner } from '../helpers/golden-test';
+import { Compiler } from '../../src/compiler/compiler';
+import { CompilerOptions, defaultOptions } from '../../src/config/options';
 
 describe('Golden Tests - Basic Types', () => {
   test('01-basic-types', async () => {
@@ -106,6 +109,86 @@ describe('Golden Tests - Advanced Types', () => {
   });
 });
 
+describe('Golden Tests - Optional Parameters', () => {
+  test('11-optional-parameters', async () => {
+    await runGoldenTest(
+      '11-optional-parameters',
+      '11-optional-parameters.ts',
+      '11-optional-parameters.go'
+    );
+  });
+});
+
+describe('Golden Tests - Switch Exhaustiveness', () => {
+  test('12-switch-exhaustiveness', async () => {
+    await runGoldenTest(
+      '12-switch-exhaustiveness',
+      '12-switch-exhaustiveness.ts',
+      '12-switch-exhaustiveness.go'
+    );
+  });
+
+  test('12-switch-exhaustiveness reports only the non-exhaustive switch', async () => {
+    const input = path.join(__dirname, '12-switch-exhaustiveness.ts');
+    const compiler = new Compiler({ ...defaultOptions, input, output: '/tmp' } as CompilerOptions);
+    const result = await compiler.compileFile(input);
+
+    const warnings = (result.warnings || []).filter(w => w.code === 'W4002');
+    expect(warnings).toHaveLength(1);
+    expect(warnings[0].message).toMatch(/missing: "medium"$/);
+  });
+});
+
+describe('Golden Tests - Generators', () => {
+  test('13-generators', async () => {
+    await runGoldenTest(
+      '13-generators',
+      '13-generators.ts',
+      '13-generators.go'
+    );
+  });
+});
+
+describe('Golden Tests - Promise Combinators', () => {
+  test('14-promise-combinators', async () => {
+    await runGoldenTest(
+      '14-promise-combinators',
+      '14-promise-combinators.ts',
+      '14-promise-combinators.go'
+    );
+  });
+});
+
+describe('Golden Tests - Overloads', () => {
+  test('15-overloads', async () => {
+    await runGoldenTest(
+      '15-overloads',
+      '15-overloads.ts',
+      '15-overloads.go'
+    );
+  });
+});
+
+describe('Golden Tests - Number Operators', () => {
+  test('16-number-operators', async () => {
+    await runGoldenTest(
+      '16-number-operators',
+      '16-number-operators.ts',
+      '16-number-operators.go'
+    );
+  });
+});
+
+describe('Golden Tests - Abort Signals', () => {
+  test('17-abort-signals', async () => {
+    await runGoldenTest(
+      '17-abort-signals',
+      '17-abort-signals.ts',
+      '17-abort-signals.go'
+    );
+  });
+});
+
 describe('Golden Tests - All Tests', () => {
   test('run all golden tests', async () => {
     const runner = new GoldenTestRunner();
@@ -4,10 +4,12 @@
  */
 
 import * as fs from 'fs';
+import * as os from 'os';
 import * as path from 'path';
 import { execSync } from 'child_process';
 import { Compiler } from '../../src/compiler/compiler';
 import { CompilerOptions, defaultOptions } from '../../src/config/options';
+import { RuntimeGenerator } from '../../src/runtime/runtime-generator';
 
 export interface GoldenTestCase {
   name: string;
@@ -30,16 +32,26 @@ export class GoldenTestRunner {
 
   /**
    * Run go vet on a Go source file
+   * 於暫存 module（ts2go）中執行，內含與 generateRuntime 相同的 runtime package，使 "ts2go/runtime" 可解析
    */
   private runGoVet(code: string, testName: string): { passed: boolean; errors: string[] } {
-    const tmpFile = path.join('/tmp', `${testName}.go`);
     const errors: string[] = [];
+    let moduleDir: string | undefined;
 
     try {
-      fs.writeFileSync(tmpFile, code, 'utf-8');
+      moduleDir = fs.mkdtempSync(path.join(os.tmpdir(), 'ts2go-golden-'));
+      fs.writeFileSync(path.join(moduleDir, 'go.mod'), `module ts2go\n\ngo ${defaultOptions.goVersion}\n`, 'utf-8');
+      fs.mkdirSync(path.join(moduleDir, 'runtime'));
+      fs.writeFileSync(
+        path.join(moduleDir, 'runtime', 'runtime.go'),
+        new RuntimeGenerator().generate({ features: ['all'], outputDir: '', packageName: 'runtime' }),
+        'utf-8'
+      );
+      fs.mkdirSync(path.join(moduleDir, testName));
+      fs.writeFileSync(path.join(moduleDir, testName, `${testName}.go`), code, 'utf-8');
 
       try {
-        execSync(`go vet ${tmpFile}`, { encoding: 'utf-8', stdio: 'pipe' });
+        execSync(`go vet ./${testName}`, { cwd: moduleDir, encoding: 'utf-8', stdio: 'pipe' });
         return { passed: true, errors: [] };
       } catch (error: any) {
         const stderr = error.stderr || error.stdout || error.message;
@@ -47,13 +59,13 @@ export class GoldenTestRunner {
         return { passed: false, errors };
       }
     } catch (error: any) {
-      errors.push(`Failed to write temp file: ${error.message}`);
+      errors.push(`Failed to write temp module: ${error.message}`);
       return { passed: false, errors };
     } finally {
-      // Clean up temp file
+      // Clean up temp module
       try {
-        if (fs.existsSync(tmpFile)) {
-          fs.unlinkSync(tmpFile);
+        if (moduleDir) {
+          fs.rmSync(moduleDir, { recursive: true, force: true });
         }
       } catch (e) {
         // Ignore cleanup errors
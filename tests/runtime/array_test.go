@@ -0,0 +1,120 @@
+package runtime
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestJoinFormatsElementsLikeJavaScript(t *testing.T) {
+	one, missing := 1.5, (*float64)(nil)
+	cases := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"large numbers", Join([]float64{1000000, 1e21, 0.1, -0}, ","), "1000000,1e+21,0.1,0"},
+		{"ints", Join([]int{1, 2, 3}, "-"), "1-2-3"},
+		{"pointers", Join([]*float64{&one, missing, nil}, ","), "1.5,,"},
+		{"interfaces", Join([]interface{}{"a", nil, 2.0, true}, " "), "a  2 true"},
+		{"nested", Join([][]float64{{1, 2}, {}, {3}}, ";"), "1,2;;3"},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestRelativeIndices(t *testing.T) {
+	nums := []float64{1, 2, 3, 4, 5}
+	if got := Slice(nums, -2); !slices.Equal(got, []float64{4, 5}) {
+		t.Errorf("Slice(-2) = %v", got)
+	}
+	if got := Slice(nums, 1, -1); !slices.Equal(got, []float64{2, 3, 4}) {
+		t.Errorf("Slice(1, -1) = %v", got)
+	}
+	if got := Slice(nums, -10, math.Inf(1)); !slices.Equal(got, nums) {
+		t.Errorf("Slice(-10, Infinity) = %v", got)
+	}
+	if got := Slice(nums, 3, 1); len(got) != 0 {
+		t.Errorf("Slice(3, 1) = %v, want empty", got)
+	}
+	if got := At(nums, -1); got == nil || *got != 5 {
+		t.Errorf("At(-1) = %v", got)
+	}
+	if got := At(nums, -6); got != nil {
+		t.Errorf("At(-6) = %v, want nil", *got)
+	}
+	if got := At(nums, 5); got != nil {
+		t.Errorf("At(5) = %v, want nil", *got)
+	}
+}
+
+func TestIndexOfNotFound(t *testing.T) {
+	nums := []int{1, 2, 3, 1}
+	cases := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"IndexOf missing", IndexOf(nums, 9, 0), -1},
+		{"IndexOf from negative", IndexOf(nums, 1, -2), 3},
+		{"IndexOf from past the end", IndexOf(nums, 1, 10), -1},
+		{"LastIndexOf from the end", LastIndexOf(nums, 1, -1), 3},
+		{"LastIndexOf from negative", LastIndexOf(nums, 1, -2), 0},
+		{"LastIndexOf before the start", LastIndexOf(nums, 1, -10), -1},
+		{"FindIndex missing", FindIndex(nums, func(n int) bool { return n > 3 }), -1},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s: got %d, want %d", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestSpliceBounds(t *testing.T) {
+	cases := []struct {
+		name        string
+		start       float64
+		deleteCount float64
+		items       []int
+		removed     []int
+		rest        []int
+	}{
+		{"middle", 1, 2, []int{9}, []int{2, 3}, []int{1, 9, 4, 5}},
+		{"negative start", -2, 1, nil, []int{4}, []int{1, 2, 3, 5}},
+		{"start before the beginning", -10, 1, nil, []int{1}, []int{2, 3, 4, 5}},
+		{"start past the end", 10, 1, []int{6}, []int{}, []int{1, 2, 3, 4, 5, 6}},
+		{"count past the end", 3, 10, nil, []int{4, 5}, []int{1, 2, 3}},
+		{"negative count", 1, -1, []int{7}, []int{}, []int{1, 7, 2, 3, 4, 5}},
+		{"infinite count", 0, math.Inf(1), nil, []int{1, 2, 3, 4, 5}, []int{}},
+	}
+	for _, c := range cases {
+		s := []int{1, 2, 3, 4, 5}
+		removed := Splice(&s, c.start, c.deleteCount, c.items...)
+		if !slices.Equal(removed, c.removed) || !slices.Equal(s, c.rest) {
+			t.Errorf("%s: removed %v leaving %v, want %v leaving %v", c.name, removed, s, c.removed, c.rest)
+		}
+	}
+}
+
+func TestFillBounds(t *testing.T) {
+	cases := []struct {
+		name string
+		args []int
+		want []int
+	}{
+		{"all", nil, []int{0, 0, 0, 0}},
+		{"from start", []int{2}, []int{1, 2, 0, 0}},
+		{"negative range", []int{-3, -1}, []int{1, 0, 0, 4}},
+		{"empty range", []int{3, 1}, []int{1, 2, 3, 4}},
+		{"out of range", []int{-10, 10}, []int{0, 0, 0, 0}},
+	}
+	for _, c := range cases {
+		got := Fill([]int{1, 2, 3, 4}, 0, c.args...)
+		if !slices.Equal(got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
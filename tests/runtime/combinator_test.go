@@ -0,0 +1,181 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// blockUntilCancelled is a sibling task that only finishes once the
+// combinator cancels it, recording that it did
+func blockUntilCancelled(cancelled *bool) Task[int] {
+	return func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		*cancelled = true
+		return 0, ctx.Err()
+	}
+}
+
+func TestAllTasksCancelsSiblingsOnFirstError(t *testing.T) {
+	boom := errors.New("boom")
+	var cancelled bool
+	values, err := AllTasks(context.Background(),
+		blockUntilCancelled(&cancelled),
+		func(ctx context.Context) (int, error) { return 0, boom },
+	)
+	if values != nil || err != boom {
+		t.Errorf("AllTasks = %v, %v; want nil, boom", values, err)
+	}
+	// AllTasks waits for every goroutine, so the sibling has already stopped
+	if !cancelled {
+		t.Error("sibling task was not cancelled")
+	}
+}
+
+func TestAllTasksReturnsFirstErrorByTime(t *testing.T) {
+	slow, fast := errors.New("slow"), errors.New("fast")
+	_, err := AllTasks(context.Background(),
+		// 輸入順序在前，但只在 fast 失敗、ctx 取消後才失敗
+		func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, slow
+		},
+		func(ctx context.Context) (int, error) { return 0, fast },
+	)
+	if err != fast {
+		t.Errorf("AllTasks error = %v, want fast", err)
+	}
+}
+
+func TestAllTasksKeepsInputOrder(t *testing.T) {
+	second := make(chan struct{})
+	values, err := AllTasks(context.Background(),
+		func(ctx context.Context) (int, error) {
+			<-second
+			return 1, nil
+		},
+		func(ctx context.Context) (int, error) {
+			close(second)
+			return 2, nil
+		},
+	)
+	if err != nil || len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Errorf("AllTasks = %v, %v; want [1 2], nil", values, err)
+	}
+}
+
+func TestRaceTasksCancelsTheLosers(t *testing.T) {
+	var cancelled bool
+	value, err := RaceTasks(context.Background(),
+		blockUntilCancelled(&cancelled),
+		func(ctx context.Context) (int, error) { return 7, nil },
+	)
+	if value != 7 || err != nil {
+		t.Errorf("RaceTasks = %v, %v; want 7, nil", value, err)
+	}
+	if !cancelled {
+		t.Error("losing task was not cancelled")
+	}
+}
+
+func TestRaceTasksWithNoTasksWaitsForContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	value, err := RaceTasks[int](ctx)
+	if value != 0 || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RaceTasks() = %v, %v; want 0, context.DeadlineExceeded", value, err)
+	}
+}
+
+func TestAnyTasksReturnsFirstFulfilledValue(t *testing.T) {
+	var cancelled bool
+	value, err := AnyTasks(context.Background(),
+		func(ctx context.Context) (string, error) { return "", errors.New("nope") },
+		func(ctx context.Context) (string, error) { return "ok", nil },
+		func(ctx context.Context) (string, error) {
+			_, err := blockUntilCancelled(&cancelled)(ctx)
+			return "", err
+		},
+	)
+	if value != "ok" || err != nil {
+		t.Errorf("AnyTasks = %q, %v; want ok, nil", value, err)
+	}
+	if !cancelled {
+		t.Error("pending task was not cancelled")
+	}
+}
+
+func TestAnyTasksAggregatesErrorsInInputOrder(t *testing.T) {
+	errs := []error{errors.New("first"), errors.New("second"), errors.New("third")}
+	// 以相反順序失敗：third、second、first
+	thirdDone, secondDone := make(chan struct{}), make(chan struct{})
+	_, err := AnyTasks(context.Background(),
+		func(ctx context.Context) (int, error) {
+			<-secondDone
+			return 0, errs[0]
+		},
+		func(ctx context.Context) (int, error) {
+			<-thirdDone
+			defer close(secondDone)
+			return 0, errs[1]
+		},
+		func(ctx context.Context) (int, error) {
+			defer close(thirdDone)
+			return 0, errs[2]
+		},
+	)
+
+	var aggregate *AggregateError
+	if !errors.As(err, &aggregate) {
+		t.Fatalf("AnyTasks error = %v, want *AggregateError", err)
+	}
+	if aggregate.Message != "All promises were rejected" {
+		t.Errorf("Message = %q", aggregate.Message)
+	}
+	if len(aggregate.Errors) != len(errs) {
+		t.Fatalf("Errors = %v, want %v", aggregate.Errors, errs)
+	}
+	for i, e := range errs {
+		if aggregate.Errors[i] != e {
+			t.Errorf("Errors[%d] = %v, want %v", i, aggregate.Errors[i], e)
+		}
+		if !errors.Is(err, e) {
+			t.Errorf("errors.Is(err, %v) = false", e)
+		}
+	}
+}
+
+func TestAllSettledTasksReportsEveryOutcome(t *testing.T) {
+	boom := errors.New("boom")
+	results, err := AllSettledTasks(context.Background(),
+		func(ctx context.Context) (int, error) { return 0, boom },
+		func(ctx context.Context) (int, error) { return 2, nil },
+	)
+	if err != nil || len(results) != 2 {
+		t.Fatalf("AllSettledTasks = %v, %v", results, err)
+	}
+	if results[0].Status != "rejected" || results[0].Reason != boom {
+		t.Errorf("results[0] = %+v, want rejected with boom", results[0])
+	}
+	if results[1].Status != "fulfilled" || results[1].Value != 2 {
+		t.Errorf("results[1] = %+v, want fulfilled with 2", results[1])
+	}
+}
+
+func TestFutureCombinatorsStopWaitingOnceDecided(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	never := NewFuture(func() (int, error) {
+		<-release
+		return 0, nil
+	})
+	value, err := Race(never, Resolve(3)).Await()
+	if value != 3 || err != nil {
+		t.Errorf("Race = %v, %v; want 3, nil", value, err)
+	}
+	boom := errors.New("boom")
+	if _, err := All(never, Reject[int](boom)).Await(); err != boom {
+		t.Errorf("All error = %v, want boom", err)
+	}
+}
@@ -0,0 +1,132 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateISOForms(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Time
+	}{
+		// Date-only forms are UTC
+		{"2024-03-15", time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"2024-03", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"+002024-03-15", time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		// Date-time forms without an offset are local time
+		{"2024-03-15T10:20", time.Date(2024, time.March, 15, 10, 20, 0, 0, time.Local)},
+		{"2024-03-15 10:20:30", time.Date(2024, time.March, 15, 10, 20, 30, 0, time.Local)},
+		{"2024-03-15T10:20:30.5Z", time.Date(2024, time.March, 15, 10, 20, 30, 500e6, time.UTC)},
+		// Digits past milliseconds are ignored
+		{"2024-03-15T10:20:30.123456Z", time.Date(2024, time.March, 15, 10, 20, 30, 123e6, time.UTC)},
+		{"2024-03-15T10:20:30+08:00", time.Date(2024, time.March, 15, 2, 20, 30, 0, time.UTC)},
+		{"2024-03-15T10:20:30-05:30", time.Date(2024, time.March, 15, 15, 50, 30, 0, time.UTC)},
+		{"  2024-03-15  ", time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		got, err := ParseDate(c.in)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %v", c.in, err)
+			continue
+		}
+		if !got.Equal(c.want) {
+			t.Errorf("ParseDate(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParseDateRollsOverLikeV8(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024-02-30", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"2023-02-29", time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-04-31", time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		// 24:00 is midnight at the end of the day
+		{"2024-12-31T24:00Z", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		got, err := ParseDate(c.in)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %v", c.in, err)
+			continue
+		}
+		if !got.Equal(c.want) {
+			t.Errorf("ParseDate(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParseDateRejectsOutOfRangeFields(t *testing.T) {
+	for _, in := range []string{
+		"2024-13-01",
+		"2024-00-10",
+		"2024-01-32",
+		"2024-01-00",
+		"2024-01-01T25:00",
+		"2024-01-01T24:01",
+		"2024-01-01T10:60",
+		"2024-01-01T10:00:60",
+		"-000000-01-01",
+		"not a date",
+		"",
+	} {
+		if got, err := ParseDate(in); err == nil {
+			t.Errorf("ParseDate(%q) = %v, want an error", in, got)
+		}
+	}
+}
+
+func TestParseDateFallsBackToLayouts(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Time
+	}{
+		// toUTCString
+		{"Fri, 15 Mar 2024 10:20:30 GMT", time.Date(2024, time.March, 15, 10, 20, 30, 0, time.UTC)},
+		{"Fri, 15 Mar 2024 10:20:30 +0800", time.Date(2024, time.March, 15, 2, 20, 30, 0, time.UTC)},
+		// toString, with the zone name in parentheses
+		{"Fri Mar 15 2024 10:20:30 GMT+0800 (China Standard Time)", time.Date(2024, time.March, 15, 2, 20, 30, 0, time.UTC)},
+		{"Fri Mar 15 2024 10:20:30 GMT-0500", time.Date(2024, time.March, 15, 15, 20, 30, 0, time.UTC)},
+		// Formats without a zone are local time
+		{"Fri Mar 15 2024", time.Date(2024, time.March, 15, 0, 0, 0, 0, time.Local)},
+		{"March 15, 2024", time.Date(2024, time.March, 15, 0, 0, 0, 0, time.Local)},
+		{"Mar 15, 2024", time.Date(2024, time.March, 15, 0, 0, 0, 0, time.Local)},
+		{"2024/03/15 10:20:30", time.Date(2024, time.March, 15, 10, 20, 30, 0, time.Local)},
+		{"03/15/2024", time.Date(2024, time.March, 15, 0, 0, 0, 0, time.Local)},
+	}
+	for _, c := range cases {
+		got, err := ParseDate(c.in)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %v", c.in, err)
+			continue
+		}
+		if !got.Equal(c.want) {
+			t.Errorf("ParseDate(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParseDateOrZero(t *testing.T) {
+	if got := ParseDateOrZero("garbage"); !got.IsZero() {
+		t.Errorf("ParseDateOrZero(garbage) = %v, want the zero time", got)
+	}
+	if got := ParseDateOrZero("2024-03-15"); !got.Equal(time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseDateOrZero(2024-03-15) = %v", got)
+	}
+}
+
+func TestMust(t *testing.T) {
+	if got := Must(ParseDate("2024-03-15")); got.Year() != 2024 {
+		t.Errorf("Must(ParseDate) = %v", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Must did not panic on an error")
+		}
+	}()
+	Must(ParseDate("garbage"))
+}
@@ -0,0 +1,93 @@
+package runtime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newVirtualLoop() *EventLoop {
+	return NewEventLoop(NewVirtualClock(time.Unix(0, 0)))
+}
+
+func TestEventLoopFiresTimersInDeadlineOrder(t *testing.T) {
+	loop := newVirtualLoop()
+	var got []string
+	log := func(s string) func() { return func() { got = append(got, s) } }
+
+	loop.SetTimeout(log("30ms"), 30*time.Millisecond)
+	loop.SetTimeout(log("10ms"), 10*time.Millisecond)
+	loop.SetTimeout(log("10ms again"), 10*time.Millisecond)
+	loop.SetTimeout(log("zero"), 0)
+	loop.QueueMicrotask(log("micro"))
+	loop.Run()
+
+	want := []string{"micro", "zero", "10ms", "10ms again", "30ms"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestEventLoopRunsMicrotasksAfterEachTimer(t *testing.T) {
+	loop := newVirtualLoop()
+	var got []string
+
+	loop.SetTimeout(func() {
+		got = append(got, "a")
+		loop.QueueMicrotask(func() { got = append(got, "a micro") })
+	}, 5*time.Millisecond)
+	loop.SetTimeout(func() { got = append(got, "b") }, 5*time.Millisecond)
+	loop.Run()
+
+	want := []string{"a", "a micro", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestEventLoopClearTimeout(t *testing.T) {
+	loop := newVirtualLoop()
+	fired := false
+
+	id := loop.SetTimeout(func() { fired = true }, 10*time.Millisecond)
+	loop.SetTimeout(func() { loop.ClearTimer(id) }, 5*time.Millisecond)
+	loop.ClearTimer(TimerID(999)) // unknown IDs are ignored
+	loop.Run()
+
+	if fired {
+		t.Fatal("cleared timeout fired")
+	}
+}
+
+func TestEventLoopIntervalCancellation(t *testing.T) {
+	clock := NewVirtualClock(time.Unix(0, 0))
+	loop := NewEventLoop(clock)
+	var ticks []time.Duration
+
+	var id TimerID
+	id = loop.SetInterval(func() {
+		ticks = append(ticks, clock.Now().Sub(time.Unix(0, 0)))
+		if len(ticks) == 3 {
+			loop.ClearTimer(id)
+		}
+	}, 10*time.Millisecond)
+	loop.Run()
+
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}
+	if !reflect.DeepEqual(ticks, want) {
+		t.Fatalf("got ticks at %v, want %v", ticks, want)
+	}
+}
+
+func TestEventLoopIntervalClearedByTimeout(t *testing.T) {
+	loop := newVirtualLoop()
+	ticks := 0
+
+	id := loop.SetInterval(func() { ticks++ }, 10*time.Millisecond)
+	loop.SetTimeout(func() { loop.ClearTimer(id) }, 35*time.Millisecond)
+	loop.Run()
+
+	if ticks != 3 {
+		t.Fatalf("got %d ticks, want 3", ticks)
+	}
+}
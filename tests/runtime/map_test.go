@@ -0,0 +1,176 @@
+package runtime
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func mapOf(keys ...string) *OrderedMap[string, int] {
+	m := NewOrderedMap[string, int]()
+	for i, k := range keys {
+		m.Set(k, i)
+	}
+	return m
+}
+
+func TestOrderedMapDeleteDuringIteration(t *testing.T) {
+	m := mapOf("a", "b", "c", "d", "e")
+	var visited []string
+	m.All()(func(key string, _ int) bool {
+		visited = append(visited, key)
+		if key == "b" {
+			m.Delete("a") // 已走訪
+			m.Delete("c") // 尚未走訪，略過
+			m.Set("f", 5) // 新增的項目會被走訪
+		}
+		return true
+	})
+	if want := []string{"a", "b", "d", "e", "f"}; !slices.Equal(visited, want) {
+		t.Errorf("visited %v, want %v", visited, want)
+	}
+	if want := []string{"b", "d", "e", "f"}; !slices.Equal(m.Keys(), want) {
+		t.Errorf("Keys() = %v, want %v", m.Keys(), want)
+	}
+	if m.Len() != 4 || m.Has("c") || m.Get("f") != 5 {
+		t.Errorf("after iteration: %v", m)
+	}
+}
+
+func TestOrderedMapClearDuringIteration(t *testing.T) {
+	m := mapOf("a", "b", "c")
+	var visited []string
+	m.ForEach(func(_ int, key string) {
+		visited = append(visited, key)
+		if key == "a" {
+			m.Clear()
+			m.Set("z", 26)
+		}
+	})
+	if want := []string{"a", "z"}; !slices.Equal(visited, want) {
+		t.Errorf("visited %v, want %v", visited, want)
+	}
+	if m.Len() != 1 || !slices.Equal(m.Keys(), []string{"z"}) {
+		t.Errorf("after Clear: %v", m)
+	}
+}
+
+func TestOrderedMapReinsertedKeyMovesToEnd(t *testing.T) {
+	m := mapOf("a", "b", "c")
+	m.Set("a", 10) // 既有的鍵保留位置
+	m.Delete("b")
+	m.Set("b", 20) // 刪除後再加入的鍵排在最後
+	want := []Entry[string, int]{{"a", 10}, {"c", 2}, {"b", 20}}
+	if got := m.Entries(); !slices.Equal(got, want) {
+		t.Errorf("Entries() = %v, want %v", got, want)
+	}
+}
+
+func TestOrderedMapCompact(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	for i := 0; i < 40; i++ {
+		m.Set(fmt.Sprint(i), i)
+	}
+	for i := 0; i < 30; i++ {
+		m.Delete(fmt.Sprint(i))
+	}
+	if len(m.slots) >= 40 || m.deleted >= 16 {
+		t.Errorf("slots were not compacted: %d slots, %d deleted", len(m.slots), m.deleted)
+	}
+	for i := 30; i < 40; i++ {
+		if key := fmt.Sprint(i); m.Get(key) != i || m.slots[m.index[key]].key != key {
+			t.Errorf("index of %q is stale after compact", key)
+		}
+	}
+	if want := []string{"30", "31", "32", "33", "34", "35", "36", "37", "38", "39"}; !slices.Equal(m.Keys(), want) {
+		t.Errorf("Keys() = %v, want %v", m.Keys(), want)
+	}
+}
+
+func TestOrderedMapDoesNotCompactWhileIterating(t *testing.T) {
+	m := NewOrderedMap[int, int]()
+	for i := 0; i < 40; i++ {
+		m.Set(i, i)
+	}
+	var visited []int
+	m.All()(func(key, _ int) bool {
+		visited = append(visited, key)
+		// 刪除下一個鍵；slots 於走訪期間不可移動，否則會跳過或重複走訪
+		m.Delete(key + 1)
+		if len(m.slots) != 40 {
+			t.Fatalf("slots compacted during iteration: %d", len(m.slots))
+		}
+		return true
+	})
+	if len(visited) != 20 || visited[1] != 2 || visited[19] != 38 {
+		t.Errorf("visited %v", visited)
+	}
+	// 走訪結束後才壓縮
+	if len(m.slots) != 20 || m.deleted != 0 {
+		t.Errorf("slots were not compacted after iteration: %d slots, %d deleted", len(m.slots), m.deleted)
+	}
+}
+
+func TestOrderedMapNestedIteration(t *testing.T) {
+	m := mapOf("a", "b")
+	var pairs []string
+	m.All()(func(outer string, _ int) bool {
+		m.All()(func(inner string, _ int) bool {
+			pairs = append(pairs, outer+inner)
+			return true
+		})
+		m.Delete(outer)
+		return true
+	})
+	if want := []string{"aa", "ab", "bb"}; !slices.Equal(pairs, want) {
+		t.Errorf("pairs %v, want %v", pairs, want)
+	}
+	if m.Len() != 0 || m.iterating != 0 {
+		t.Errorf("after iteration: len %d, iterating %d", m.Len(), m.iterating)
+	}
+}
+
+func TestOrderedMapZeroValue(t *testing.T) {
+	var m OrderedMap[string, int]
+	if m.Len() != 0 || m.Has("a") || m.Get("a") != 0 || m.Delete("a") {
+		t.Error("zero value is not an empty map")
+	}
+	m.Set("a", 1)
+	if got := m.String(); got != "Map(1) {a => 1}" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestOrderedSetDeleteAndClearDuringIteration(t *testing.T) {
+	s := NewOrderedSet("a", "b", "c", "d")
+	var visited []string
+	s.ForEach(func(value string) {
+		visited = append(visited, value)
+		switch value {
+		case "a":
+			s.Delete("b")
+			s.Add("a") // 既有的值不會重複走訪
+		case "c":
+			s.Clear()
+			s.Add("e")
+		}
+	})
+	if want := []string{"a", "c", "e"}; !slices.Equal(visited, want) {
+		t.Errorf("visited %v, want %v", visited, want)
+	}
+	if got := s.String(); got != "Set(1) {e}" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestOrderedSetClone(t *testing.T) {
+	s := NewOrderedSet(3, 1, 2, 1)
+	c := s.Clone()
+	s.Delete(1)
+	if want := []int{3, 1, 2}; !slices.Equal(c.Values(), want) {
+		t.Errorf("Clone().Values() = %v, want %v", c.Values(), want)
+	}
+	if want := []int{3, 2}; !slices.Equal(s.Values(), want) {
+		t.Errorf("Values() = %v, want %v", s.Values(), want)
+	}
+}
@@ -0,0 +1,170 @@
+package runtime
+
+import (
+	"math"
+	"testing"
+)
+
+// sameNumber compares like Object.is: NaN equals NaN and -0 differs from +0
+func sameNumber(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return a == b && math.Signbit(a) == math.Signbit(b)
+}
+
+func TestRoundHalvesUpLikeJavaScript(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	cases := []struct{ x, want float64 }{
+		{2.5, 3},
+		{-2.5, -2},
+		{1.5, 2},
+		{-0.4, negZero},
+		{-0.5, negZero},
+		{0.49999999999999994, 0},
+		{4503599627370497, 4503599627370497},
+		{math.Inf(-1), math.Inf(-1)},
+		{math.NaN(), math.NaN()},
+	}
+	for _, c := range cases {
+		if got := Round(c.x); !sameNumber(got, c.want) {
+			t.Errorf("Round(%v) = %v, want %v", c.x, got, c.want)
+		}
+	}
+}
+
+func TestToFixed(t *testing.T) {
+	cases := []struct {
+		x      float64
+		digits int
+		want   string
+	}{
+		{1.005, 2, "1.00"}, // 1.005 is slightly below 1.005 in binary
+		{0.125, 2, "0.13"}, // exact half rounds up, unlike strconv
+		{1.45, 1, "1.4"},
+		{2.5, 0, "3"},
+		{-1.5, 0, "-2"},
+		{123.456, 0, "123"},
+		{0, 2, "0.00"},
+		{-0.0001, 2, "-0.00"},
+		{1e-7, 3, "0.000"},
+		{1e21, 2, "1e+21"},
+		{math.NaN(), 2, "NaN"},
+	}
+	for _, c := range cases {
+		if got := ToFixed(c.x, c.digits); got != c.want {
+			t.Errorf("ToFixed(%v, %d) = %q, want %q", c.x, c.digits, got, c.want)
+		}
+	}
+}
+
+func TestToFixedRejectsDigitsOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("ToFixed(1, 101) did not panic")
+		}
+	}()
+	ToFixed(1, 101)
+}
+
+func TestNumberToStringRadix(t *testing.T) {
+	cases := []struct {
+		x     float64
+		radix int
+		want  string
+	}{
+		{255, 16, "ff"},
+		{-255, 2, "-11111111"},
+		{35, 36, "z"},
+		{0.5, 2, "0.1"},
+		{-0.75, 4, "-0.3"},
+		{0.1, 3, "0.0022002200220022002200220022002201"},
+		{3.14159, 16, "3.243f3e0370cdc"},
+		{1e21, 36, "5v1j4f4ds7c000"},
+		{1 << 60, 2, "1000000000000000000000000000000000000000000000000000000000000"},
+		{12.5, 10, "12.5"},
+		{math.Inf(-1), 2, "-Infinity"},
+	}
+	for _, c := range cases {
+		if got := NumberToStringRadix(c.x, c.radix); got != c.want {
+			t.Errorf("NumberToStringRadix(%v, %d) = %q, want %q", c.x, c.radix, got, c.want)
+		}
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	cases := []struct {
+		s     string
+		radix int
+		want  float64
+	}{
+		{"  42px", 10, 42},
+		{"-0x1F", 0, -31},
+		{"0x1F", 16, 31},
+		{"ff", 16, 255},
+		{"z", 36, 35},
+		{"1010", 2, 10},
+		{"0b1", 0, 0},
+		{"1e3", 10, 1},
+		{"-0", 10, negZero},
+		{"123456789012345678901234567890", 10, 1.2345678901234568e+29},
+		{"12", 1, math.NaN()},
+		{"12", 37, math.NaN()},
+		{"", 10, math.NaN()},
+	}
+	for _, c := range cases {
+		if got := ParseInt(c.s, c.radix); !sameNumber(got, c.want) {
+			t.Errorf("ParseInt(%q, %d) = %v, want %v", c.s, c.radix, got, c.want)
+		}
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	cases := []struct {
+		s    string
+		want float64
+	}{
+		{"3.14abc", 3.14},
+		{"  -.5e2x", -50},
+		{"1.", 1},
+		{"  7", 7},
+		{"+1_000", 1},
+		{"Infinityx", math.Inf(1)},
+		{"-Infinity", math.Inf(-1)},
+		{"1e400", math.Inf(1)},
+		{"e5", math.NaN()},
+		{".", math.NaN()},
+	}
+	for _, c := range cases {
+		if got := ParseFloat(c.s); !sameNumber(got, c.want) {
+			t.Errorf("ParseFloat(%q) = %v, want %v", c.s, got, c.want)
+		}
+	}
+}
+
+func TestToInt32AndToUint32WrapAround(t *testing.T) {
+	cases := []struct {
+		x      float64
+		int32  int32
+		uint32 uint32
+	}{
+		{1 << 31, -1 << 31, 1 << 31},
+		{1<<32 + 5, 5, 5},
+		{-1, -1, 1<<32 - 1},
+		{1.9, 1, 1},
+		{-1.9, -1, 1<<32 - 1},
+		{-1<<31 - 1, 1<<31 - 1, 1<<31 - 1},
+		{1 << 53, 0, 0},
+		{math.NaN(), 0, 0},
+		{math.Inf(1), 0, 0},
+	}
+	for _, c := range cases {
+		if got := ToInt32(c.x); got != c.int32 {
+			t.Errorf("ToInt32(%v) = %d, want %d", c.x, got, c.int32)
+		}
+		if got := ToUint32(c.x); got != c.uint32 {
+			t.Errorf("ToUint32(%v) = %d, want %d", c.x, got, c.uint32)
+		}
+	}
+}
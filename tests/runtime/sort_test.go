@@ -0,0 +1,43 @@
+package runtime
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortDefaultComparesNumbersAsStrings(t *testing.T) {
+	if got := SortDefault([]float64{10, 9, 1}); !slices.Equal(got, []float64{1, 10, 9}) {
+		t.Errorf("got %v, want [1 10 9]", got)
+	}
+	if got := SortDefault([]float64{123456789, 12, 1e21, 5}); !slices.Equal(got, []float64{12, 123456789, 1e21, 5}) {
+		t.Errorf("got %v, want [12 123456789 1e+21 5]", got)
+	}
+	if got := SortDefault([]int{100, 20, 3}); !slices.Equal(got, []int{100, 20, 3}) {
+		t.Errorf("got %v, want [100 20 3]", got)
+	}
+}
+
+func TestSortDefaultPutsUndefinedLast(t *testing.T) {
+	ten, nine, one := 10.0, 9.0, 1.0
+	got := SortDefault([]*float64{nil, &ten, nil, &nine, &one})
+
+	var values []float64
+	for _, p := range got[:3] {
+		if p == nil {
+			t.Fatalf("got nil before the defined elements: %v", got)
+		}
+		values = append(values, *p)
+	}
+	if !slices.Equal(values, []float64{1, 10, 9}) || got[3] != nil || got[4] != nil {
+		t.Errorf("got %v followed by %v, %v; want [1 10 9] followed by nil, nil", values, got[3], got[4])
+	}
+}
+
+func TestSortDefaultUsesUTF16Order(t *testing.T) {
+	// U+FF61 sorts after the surrogate pair of U+1F600 in UTF-16, unlike in UTF-8
+	got := SortDefault([]string{"｡", "\U0001F600", "b", "B"})
+	want := []string{"B", "b", "\U0001F600", "｡"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+package runtime
+
+import (
+	"math"
+	"testing"
+)
+
+func TestByteIndexHelpersClampLikeJavaScript(t *testing.T) {
+	s := "hello"
+	cases := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"slice past the end", ByteSlice(s, 0, 10), "hello"},
+		{"slice negative", ByteSlice(s, -3), "llo"},
+		{"slice before the start", ByteSlice(s, -10, 2), "he"},
+		{"slice reversed", ByteSlice(s, 3, 1), ""},
+		{"charAt", ByteAt(s, 1), "e"},
+		{"charAt out of range", ByteAt(s, 7), ""},
+		{"charAt negative", ByteAt(s, -1), ""},
+		{"substring swapped", ByteSubstring(s, 4, 1), "ell"},
+		{"substring past the end", ByteSubstring(s, 2.0, math.Inf(1)), "llo"},
+		{"substring negative", ByteSubstring(s, -1, 2), "he"},
+		{"substring NaN", ByteSubstring(s, math.NaN(), 1), "h"},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, c.got, c.want)
+		}
+	}
+}
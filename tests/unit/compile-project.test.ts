@@ -0,0 +1,487 @@
+/**
+ * 專案編譯（compileProject）測試
+ * 於暫存目錄建立多個檔案的專案，檢查跨檔案的降階結果
+ */
+
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { execSync } from 'child_process';
+import { Compiler } from '../../src/compiler/compiler';
+import { GoProject } from '../../src/compiler/result';
+import { CompilerOptions, defaultOptions } from '../../src/config/options';
+import { RuntimeGenerator } from '../../src/runtime/runtime-generator';
+
+const hasGo = (() => {
+  try {
+    execSync('go version', { stdio: 'pipe' });
+    return true;
+  } catch {
+    return false;
+  }
+})();
+
+/**
+ * 編譯由 files（檔名 → 內容）組成的專案，回傳 go.mod 與各檔案產生的 Go 程式碼（以檔名為鍵）
+ */
+async function compileGoProject(files: Record<string, string>, options: Partial<CompilerOptions> = {}): Promise<{ goMod: string; files: Record<string, string> }> {
+  const dir = fs.mkdtempSync(path.join(os.tmpdir(), 'ts2go-project-'));
+  try {
+    for (const [name, content] of Object.entries(files)) {
+      fs.writeFileSync(path.join(dir, name), content, 'utf-8');
+    }
+
+    const compiler = new Compiler({ ...defaultOptions, ...options, input: dir, output: dir } as CompilerOptions);
+    const result = await compiler.compileProject(dir);
+    if (!result.success) {
+      throw new Error((result.errors || []).map(error => `${error.code}: ${error.message}`).join('\n'));
+    }
+
+    const project = result.output as GoProject;
+    const output: Record<string, string> = {};
+    for (const [file, code] of project.files) {
+      output[path.basename(file, '.ts') + '.go'] = code;
+    }
+    return { goMod: project.goMod, files: output };
+  } finally {
+    fs.rmSync(dir, { recursive: true, force: true });
+  }
+}
+
+/**
+ * 編譯專案，回傳各檔案產生的 Go 程式碼（以原始檔名為鍵）
+ */
+async function compileProject(files: Record<string, string>, options: Partial<CompilerOptions> = {}): Promise<Record<string, string>> {
+  const project = await compileGoProject(files, options);
+  const output: Record<string, string> = {};
+  for (const [file, code] of Object.entries(project.files)) {
+    output[file.replace(/\.go$/, '.ts')] = code;
+  }
+  return output;
+}
+
+/**
+ * 如同 CLI 寫出 go.mod、各 .go 檔與 runtime/，再以 go build（或 command，例如 go test）建置；
+ * 失敗時拋出含 go 輸出的錯誤
+ */
+function goBuild(project: { goMod: string; files: Record<string, string> }, command = 'go build ./...'): void {
+  const dir = fs.mkdtempSync(path.join(os.tmpdir(), 'ts2go-build-'));
+  try {
+    fs.writeFileSync(path.join(dir, 'go.mod'), project.goMod, 'utf-8');
+    for (const [file, code] of Object.entries(project.files)) {
+      fs.writeFileSync(path.join(dir, file), code, 'utf-8');
+    }
+    fs.mkdirSync(path.join(dir, 'runtime'));
+    fs.writeFileSync(
+      path.join(dir, 'runtime', 'runtime.go'),
+      new RuntimeGenerator().generate({ features: ['all'], outputDir: '', packageName: 'runtime' }),
+      'utf-8'
+    );
+
+    try {
+      execSync(command, { cwd: dir, encoding: 'utf-8', stdio: 'pipe' });
+    } catch (error: any) {
+      throw new Error(`${command} failed:\n${error.stdout || ''}${error.stderr || ''}`);
+    }
+  } finally {
+    fs.rmSync(dir, { recursive: true, force: true });
+  }
+}
+
+describe('compileProject', () => {
+  test('runs the optimizer on every module', async () => {
+    const output = await compileProject({
+      'user.ts': [
+        'export interface User {',
+        '  id: string;',
+        '  name: string;',
+        '}',
+        'export type UserPatch = Partial<User>;',
+        ''
+      ].join('\n'),
+      'main.ts': [
+        "import { User } from './user';",
+        'export function rename(user: User, patch: Partial<User>): string {',
+        '  return patch.name ?? user.name;',
+        '}',
+        ''
+      ].join('\n')
+    });
+
+    // TypeSimplificationPass 將 utility type 具體化為 struct
+    expect(output['user.ts']).toMatch(/type UserPatch struct \{/);
+  });
+
+  test('threads ctx through synchronous callers of async functions in other files', async () => {
+    const output = await compileProject({
+      'api.ts': [
+        'export async function fetchUser(id: string): Promise<string> {',
+        '  return id;',
+        '}',
+        '',
+        'export function prefetch(id: string): void {',
+        '  fetchUser(id);',
+        '}',
+        ''
+      ].join('\n'),
+      'main.ts': [
+        "import { prefetch } from './api';",
+        'export function warmUp(ids: string[]): void {',
+        '  for (const id of ids) {',
+        '    prefetch(id);',
+        '  }',
+        '}',
+        ''
+      ].join('\n')
+    });
+
+    expect(output['api.ts']).toMatch(/func Prefetch\(ctx context\.Context, id string\)/);
+    expect(output['main.ts']).toMatch(/func WarmUp\(ctx context\.Context, ids \[\]string\)/);
+    expect(output['main.ts']).toMatch(/Prefetch\(ctx, id\)/);
+  });
+
+  (hasGo ? test : test.skip)('returns date parse errors through synchronous callers in other files', async () => {
+    const project = await compileGoProject({
+      'dates.ts': [
+        'export function parseDay(text: string): Date {',
+        '  return new Date(text);',
+        '}',
+        '',
+        'export function dayOfMonth(text: string): number {',
+        '  const date = parseDay(text);',
+        '  return date.getDate();',
+        '}',
+        ''
+      ].join('\n'),
+      'main.ts': [
+        "import { dayOfMonth } from './dates';",
+        'export function firstDay(texts: string[]): number {',
+        '  return dayOfMonth(texts[0]);',
+        '}',
+        '',
+        'function main(): void {',
+        "  console.log(firstDay(['2024-01-02']));",
+        '}',
+        ''
+      ].join('\n')
+    });
+
+    expect(project.files['dates.go']).toMatch(/func ParseDay\(text string\) \(time\.Time, error\) \{\n\treturn runtime\.ParseDate\(text\)/);
+    expect(project.files['dates.go']).toMatch(/func DayOfMonth\(text string\) \(\w+, error\)/);
+    expect(project.files['dates.go']).toMatch(/parseDayResult, err := ParseDay\(text\)\n\tif err != nil \{\n\t\treturn 0, err\n\t\}/);
+    expect(project.files['main.go']).toMatch(/func FirstDay\(texts \[\]string\) \(\w+, error\) \{\n\treturn DayOfMonth\(texts\[0\]\)/);
+    // main 無法回傳 error，失敗時 panic
+    expect(project.files['main.go']).toMatch(/runtime\.Must\(FirstDay\(/);
+    goBuild(project);
+  });
+
+  test('rewrites generic method calls on classes declared in another file', async () => {
+    const output = await compileProject({
+      'box.ts': [
+        'export class Box<T> {',
+        '  constructor(public value: T) {}',
+        '',
+        '  map<U>(fn: (value: T) => U): Box<U> {',
+        '    return new Box(fn(this.value));',
+        '  }',
+        '}',
+        ''
+      ].join('\n'),
+      'main.ts': [
+        "import { Box } from './box';",
+        'const box = new Box(10);',
+        'export const label = box.map(x => x * 2).map(x => `#${x}`);',
+        ''
+      ].join('\n')
+    });
+
+    expect(output['box.ts']).toMatch(/func MapBox\[T any, U any\]\(b \*Box\[T\], fn func\(T\) U\) \*Box\[U\]/);
+    expect(output['main.ts']).toMatch(/MapBox\(MapBox\(box, func\(x \w+\) \w+ \{ return x \* 2 \}\), /);
+    expect(output['main.ts']).not.toMatch(/box\.Map\(/);
+  });
+
+  test('adds constraint getters to structs declared in another file', async () => {
+    const output = await compileProject({
+      'models.ts': [
+        'export interface User {',
+        '  id: string;',
+        '  name: string;',
+        '}',
+        ''
+      ].join('\n'),
+      'repo.ts': [
+        "import { User } from './models';",
+        'export interface HasId {',
+        '  id: string;',
+        '}',
+        'export function find<T extends HasId>(items: T[], id: string): T | undefined {',
+        '  for (const item of items) {',
+        '    if (item.id === id) return item;',
+        '  }',
+        '  return undefined;',
+        '}',
+        'export function findUser(users: User[], id: string): User | undefined {',
+        '  return find(users, id);',
+        '}',
+        ''
+      ].join('\n')
+    });
+
+    // User 宣告於 models.ts，仍需具備 find 的限制所要求的 GetId()
+    expect(output['models.ts']).toMatch(/func \(v User\) GetId\(\) string \{ return v\.Id \}/);
+    expect(output['repo.ts']).toMatch(/func Find\[T interface\{ GetId\(\) string \}\]/);
+    expect(output['repo.ts']).toMatch(/item\.GetId\(\) == id/);
+  });
+
+  test('names functional option setters independently of file order', async () => {
+    const output = await compileProject({
+      'fetch.ts': [
+        'export interface RequestOptions {',
+        '  timeout?: number;',
+        '  retries?: number;',
+        '}',
+        'export function fetchAll(url: string, options: RequestOptions): void {}',
+        'export function refetch(url: string, options: RequestOptions): void {}',
+        ''
+      ].join('\n'),
+      'upload.ts': [
+        'export interface UploadOptions {',
+        '  timeout?: number;',
+        '  size?: number;',
+        '}',
+        'export function upload(path: string, options: UploadOptions): void {}',
+        ''
+      ].join('\n'),
+      'main.ts': [
+        "import { fetchAll } from './fetch';",
+        "import { upload } from './upload';",
+        'export function run(): void {',
+        "  upload('a.txt', { timeout: 5, size: 10 });",
+        "  fetchAll('/users', { timeout: 100, retries: 3 });",
+        '}',
+        ''
+      ].join('\n')
+    }, { optionsObjectStrategy: 'functional' });
+
+    // timeout 同時屬於兩個 options 型別，兩者皆加上型別名稱；設定函式每個型別只產生一次
+    expect(output['fetch.ts']).toMatch(/func WithRequestTimeout\(v float64\) RequestOption/);
+    expect(output['fetch.ts']).toMatch(/func WithRetries\(v float64\) RequestOption/);
+    expect(output['fetch.ts'].match(/type RequestOption func/g)).toHaveLength(1);
+    expect(output['upload.ts']).toMatch(/func WithUploadTimeout\(v float64\) UploadOption/);
+    expect(output['upload.ts']).toMatch(/func WithSize\(v float64\) UploadOption/);
+    expect(output['main.ts']).toMatch(/Upload\("a\.txt", WithUploadTimeout\(5\), WithSize\(10\)\)/);
+    expect(output['main.ts']).toMatch(/FetchAll\("\/users", WithRequestTimeout\(100\), WithRetries\(3\)\)/);
+  });
+
+  (hasGo ? test : test.skip)('passes options objects as params structs under the struct strategy', async () => {
+    const project = await compileGoProject({
+      'fetch.ts': [
+        'export interface RequestOptions {',
+        '  timeout?: number;',
+        '  retries?: number;',
+        '}',
+        '',
+        'export function fetchAll(url: string, { timeout = 1000 }: RequestOptions): string {',
+        '  if (timeout > 0) {',
+        '    return url;',
+        '  }',
+        "  return '';",
+        '}',
+        ''
+      ].join('\n'),
+      'main.ts': [
+        "import { fetchAll, RequestOptions } from './fetch';",
+        'export function run(shared: RequestOptions): string {',
+        "  return fetchAll('/a', { retries: 2 }) + fetchAll('/b', shared);",
+        '}',
+        ''
+      ].join('\n')
+    }, { optionsObjectStrategy: 'struct' });
+
+    const fetch = project.files['fetch.go'];
+    // 預設值記錄於函式文件
+    expect(fetch).toMatch(/\/\/ options 未設定的欄位使用預設值：Timeout = 1000\nfunc FetchAll\(url string, options RequestOptions\) string \{/);
+    expect(fetch).not.toMatch(/type RequestOption func/);
+    // 物件字面量展開為 struct 字面量，其他運算式整體傳入
+    expect(project.files['main.go']).toMatch(/FetchAll\("\/a", RequestOptions\{Retries: runtime\.Ptr\[float64\]\(2\)\}\)/);
+    expect(project.files['main.go']).toMatch(/FetchAll\("\/b", shared\)/);
+    goBuild(project);
+  });
+
+  (hasGo ? test : test.skip)('lowers for...in and struct entries without clashing with declared names', async () => {
+    const project = await compileGoProject({
+      'user.ts': [
+        'export interface User {',
+        '  id: string;',
+        '  nickname?: string;',
+        '}',
+        '',
+        'function userEntries(): number {',
+        '  return 0;',
+        '}',
+        '',
+        'export function fields(user: User, scores: Record<string, number>): string[] {',
+        '  const names: string[] = [];',
+        '  for (const key in user) {',
+        '    names.push(key);',
+        '  }',
+        '  for (const key in scores) {',
+        '    if (scores.hasOwnProperty(key)) {',
+        '      names.push(key);',
+        '    }',
+        '  }',
+        '  return names;',
+        '}',
+        ''
+      ].join('\n')
+    });
+
+    const code = project.files['user.go'];
+    expect(code).toMatch(/func userEntries2\(v User\) \[\]runtime\.Entry\[string, string\]/);
+    expect(code).toMatch(/for _, key := range runtime\.EntryKeys\(userEntries2\(user\)\) \{/);
+    expect(code).toMatch(/for _, key := range runtime\.SortedKeys\(scores\) \{\n\t\tnames = append\(names, key\)/);
+    expect(code).not.toMatch(/hasOwnProperty|HasOwnProperty/);
+    goBuild(project);
+  });
+
+  (hasGo ? test : test.skip)('round-trips tagged unions and their containing structs through encoding/json', async () => {
+    const project = await compileGoProject({
+      'shapes.ts': [
+        'export type StringOrNumber = string | number;',
+        'export type MaybeName = string | null;',
+        "export type Width = 'auto' | number;",
+        '',
+        'export interface Box {',
+        '  width: Width;',
+        '  label: MaybeName;',
+        '}',
+        ''
+      ].join('\n')
+    });
+
+    // 含 tagged union 欄位的 struct 以 TS 的屬性名稱編碼
+    expect(project.files['shapes.go']).toMatch(/Width +Width +`json:"width"`/);
+
+    project.files['roundtrip_test.go'] = fs.readFileSync(path.join(__dirname, 'testdata', 'union_roundtrip_test.go'), 'utf-8');
+    goBuild(project, 'go test ./...');
+  });
+
+  (hasGo ? test : test.skip)('names inline keyof enums apart from declared types', async () => {
+    const project = await compileGoProject({
+      'user.ts': [
+        'export interface User {',
+        '  id: string;',
+        '  name: string;',
+        '}',
+        '',
+        'export type UserKey = keyof User;',
+        '',
+        'export function label(key: keyof User): string {',
+        "  return `field ${key}`;",
+        '}',
+        ''
+      ].join('\n'),
+      'post.ts': [
+        'export interface Post {',
+        '  title: string;',
+        '}',
+        '',
+        'export interface PostKey {',
+        '  raw: string;',
+        '}',
+        '',
+        'export function heading(key: keyof Post): string {',
+        "  return `field ${key}`;",
+        '}',
+        ''
+      ].join('\n')
+    });
+
+    const user = project.files['user.go'];
+    expect(user.match(/type UserKey string/g)).toHaveLength(1);
+    expect(user).toMatch(/func Label\(key UserKey\) string/);
+    const post = project.files['post.go'];
+    expect(post).toMatch(/type PostKey struct/);
+    expect(post).toMatch(/type PostKey2 string/);
+    expect(post).toMatch(/func Heading\(key PostKey2\) string/);
+    goBuild(project);
+  });
+
+  (hasGo ? test : test.skip)('releases abort controllers created in loops on every exit path', async () => {
+    const project = await compileGoProject({
+      'fetch.ts': [
+        'async function download(url: string, signal: AbortSignal): Promise<string> {',
+        '  return url;',
+        '}',
+        '',
+        'export async function downloadAll(urls: string[]): Promise<string[]> {',
+        '  const bodies: string[] = [];',
+        '  for (const url of urls) {',
+        '    const controller = new AbortController();',
+        '    const body = await download(url, controller.signal);',
+        "    if (body === '') {",
+        '      return bodies;',
+        '    }',
+        '    bodies.push(body);',
+        '  }',
+        '  return bodies;',
+        '}',
+        ''
+      ].join('\n')
+    });
+
+    const code = project.files['fetch.go'];
+    expect(code).not.toMatch(/defer controllerCancel\(\)/);
+    expect(code.match(/controllerCancel\(\)/g)).toHaveLength(3);
+    // go vet 的 lostcancel 檢查每條返回路徑皆呼叫 cancel
+    goBuild(project, 'go vet ./...');
+  });
+
+  test('shares one collator across faithful localeCompare calls', async () => {
+    const files = await compileProject({
+      'order.ts': [
+        'export function isOrdered(a: string, b: string): boolean {',
+        '  return a.localeCompare(b) < 0 && b.localeCompare(a) > 0;',
+        '}',
+        ''
+      ].join('\n')
+    }, { stringFidelity: 'faithful' });
+
+    const code = files['order.ts'];
+    expect(code).toContain('localeCompareCollator = collate.New(language.Und)');
+    expect(code.match(/collate\.New/g)).toHaveLength(1);
+    expect(code).toContain('localeCompareMu.Lock()');
+    expect(code).toContain('return localeCompare(a, b) < 0 && localeCompare(b, a) > 0');
+  });
+
+  test('emits a go.mod whose module provides the runtime import path', async () => {
+    const project = await compileGoProject({ 'main.ts': 'export function main(): void {}\n' });
+
+    expect(project.goMod).toMatch(/^module ts2go\n/);
+  });
+
+  (hasGo ? test : test.skip)('builds the generated project together with the runtime', async () => {
+    const project = await compileGoProject({
+      'words.ts': [
+        'export function tally(words: string[]): Map<string, number> {',
+        '  const counts = new Map<string, number>();',
+        '  for (const word of words) {',
+        '    counts.set(word, word.length);',
+        '  }',
+        '  return counts;',
+        '}',
+        ''
+      ].join('\n'),
+      'main.ts': [
+        "import { tally } from './words';",
+        'function main(): void {',
+        "  console.log(tally(['a', 'bc']).size);",
+        '}',
+        ''
+      ].join('\n')
+    });
+
+    expect(project.files['words.go']).toMatch(/"ts2go\/runtime"/);
+    goBuild(project);
+  });
+});
@@ -0,0 +1,109 @@
+/**
+ * JavaScript 正規表達式 → Go regexp 的轉換測試
+ * 支援的構造以 Go 實際比對，逐一與 JS 的 exec 結果比較
+ */
+
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { execSync } from 'child_process';
+import { translateRegExp } from '../../src/backend/go-regexp';
+import { goStringLiteral } from '../../src/backend/go-string';
+
+const hasGo = (() => {
+  try {
+    execSync('go version', { stdio: 'pipe' });
+    return true;
+  } catch {
+    return false;
+  }
+})();
+
+/**
+ * 以 Go 比對每組 pattern 與輸入，回傳 FindStringSubmatch 的 JSON（無符合為 null）
+ */
+function matchInGo(cases: [string, string][]): string[] {
+  const dir = fs.mkdtempSync(path.join(os.tmpdir(), 'ts2go-regexp-'));
+  const file = path.join(dir, 'main.go');
+  const lines = cases.map(([source, input]) =>
+    `\tprint(regexp.MustCompile(${goStringLiteral(source)}).FindStringSubmatch(${goStringLiteral(input)}))`);
+  fs.writeFileSync(file, [
+    'package main',
+    '',
+    'import (\n\t"encoding/json"\n\t"fmt"\n\t"regexp"\n)',
+    '',
+    'func print(groups []string) {\n\tout, _ := json.Marshal(groups)\n\tfmt.Println(string(out))\n}',
+    '',
+    `func main() {\n${lines.join('\n')}\n}`,
+    ''
+  ].join('\n'), 'utf-8');
+
+  try {
+    const output = execSync(`go run ${file}`, { encoding: 'utf-8', stdio: 'pipe' });
+    return output.split('\n').slice(0, cases.length);
+  } finally {
+    fs.rmSync(dir, { recursive: true, force: true });
+  }
+}
+
+describe('translateRegExp', () => {
+  test('translates flags to an inline group and keeps g separate', () => {
+    expect(translateRegExp('abc', 'gims')).toEqual({ source: '(?ims)abc', global: true, issues: [] });
+    expect(translateRegExp('abc', 'u').source).toBe('abc');
+  });
+
+  test('rewrites JavaScript-only syntax to RE2 equivalents', () => {
+    expect(translateRegExp('(?<year>\\d{4})', '').source).toBe('(?P<year>\\d{4})');
+    expect(translateRegExp('[^]', '').source).toBe('[\\x{0}-\\x{10FFFF}]');
+    expect(translateRegExp('\\u00e9\\u{1F600}', 'u').source).toBe('\\x{E9}\\x{1F600}');
+    expect(translateRegExp('\\ud83d\\ude00', '').source).toBe('\\x{1F600}');
+    expect(translateRegExp('\\p{Script=Greek}', 'u').source).toBe('\\p{Greek}');
+    expect(translateRegExp('[\\b]\\cJ\\0', '').source).toBe('[\\x08]\\x{A}\\x00');
+    expect(translateRegExp('a\\/b\\e', '').source).toBe('a/be');
+    // JS 的 \s 較 Go 寬（\v、U+00A0、U+FEFF、U+2028 等），展開為明確的範圍
+    expect(translateRegExp('\\s', '').source).toBe(
+      '[\\x{9}-\\x{D}\\x{20}\\x{A0}\\x{1680}\\x{2000}-\\x{200A}\\x{2028}-\\x{2029}\\x{202F}\\x{205F}\\x{3000}\\x{FEFF}]');
+    expect(translateRegExp('[\\S,]', '').source).toMatch(/^\[\\x\{0\}-\\x\{8\}\\x\{E\}-.*\\x\{FF00\}-\\x\{10FFFF\},\]$/);
+  });
+
+  test('reports constructs RE2 cannot express at their offset', () => {
+    const issues = (pattern: string, flags = '') =>
+      translateRegExp(pattern, flags).issues.map(issue => [issue.offset, issue.length]);
+
+    expect(issues('\\d+(?=px)')).toEqual([[3, 3]]);
+    expect(issues('(?<!\\$)\\d')).toEqual([[0, 4]]);
+    expect(issues('(a)\\1')).toEqual([[3, 2]]);
+    expect(issues('(?<q>")\\k<q>')).toEqual([[7, 5]]);
+    expect(issues('a{1001}')).toEqual([[1, 6]]);
+    expect(issues('a', 'y')).toEqual([[2, 1]]);
+    expect(issues('[\\1]')).toEqual([]);
+  });
+
+  (hasGo ? test : test.skip)('matches like JavaScript once compiled by Go', () => {
+    const cases: [string, string, string][] = [
+      ['(?<user>\\w+)@(\\w+)\\.com', 'i', 'Mail BOB@example.COM now'],
+      ['^\\d+$', 'm', 'abc\n123\nxyz'],
+      ['a.b', 's', 'a\nb'],
+      ['[^]+', '', 'x\ny'],
+      ['\\u00e9+', '', 'caféé'],
+      ['[\\u4e00-\\u9fff]+', '', 'abc中文def'],
+      ['(x)?y', '', 'y'],
+      ['\\p{Lu}\\p{Ll}+', 'u', 'hello World'],
+      ['[.\\-\\/]', '', '2024/01'],
+      ['\\s+', '', 'a\u000b\u00a0\ufeff\u3000b'],
+      // Go 的 JSON 會跳脫 U+2028，僅用於不出現在結果中的位置
+      ['[^\\s]+', '', '\u2028ab\u2029'],
+      ['\\S+\\s\\S+', '', '\u3000x\u202fy '],
+      ['[^\\s,]+', '', ' ,ab\u00a0cd'],
+      ['[\\S]+', '', '\u1680abc\u205f']
+    ];
+
+    const actual = matchInGo(cases.map(([pattern, flags, input]) => [translateRegExp(pattern, flags).source, input]));
+    const expected = cases.map(([pattern, flags, input]) => {
+      const match = new RegExp(pattern, flags).exec(input);
+      // Go 以空字串表示未參與比對的群組
+      return JSON.stringify(match && Array.from(match, group => group ?? ''));
+    });
+    expect(actual).toEqual(expected);
+  });
+});
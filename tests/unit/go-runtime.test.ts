@@ -0,0 +1,57 @@
+/**
+ * runtime 套件的 Go 測試
+ * tests/runtime/*_test.go 與產生的 runtime.go 一同放入暫存 module，以 go test 執行
+ */
+
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { execSync } from 'child_process';
+import { defaultOptions } from '../../src/config/options';
+import { RuntimeGenerator } from '../../src/runtime/runtime-generator';
+
+const hasGo = (() => {
+  try {
+    execSync('go version', { stdio: 'pipe' });
+    return true;
+  } catch {
+    return false;
+  }
+})();
+
+const testDir = path.join(__dirname, '..', 'runtime');
+
+/**
+ * 以 go test 執行單一測試檔；失敗時拋出含 go test 輸出的錯誤
+ */
+function goTest(testFile: string): void {
+  const dir = fs.mkdtempSync(path.join(os.tmpdir(), 'ts2go-runtime-'));
+  try {
+    fs.writeFileSync(path.join(dir, 'go.mod'), `module ts2go\n\ngo ${defaultOptions.goVersion}\n`, 'utf-8');
+    fs.mkdirSync(path.join(dir, 'runtime'));
+    fs.writeFileSync(
+      path.join(dir, 'runtime', 'runtime.go'),
+      new RuntimeGenerator().generate({ features: ['all'], outputDir: '', packageName: 'runtime' }),
+      'utf-8'
+    );
+    fs.copyFileSync(path.join(testDir, testFile), path.join(dir, 'runtime', testFile));
+
+    try {
+      execSync('go test ./runtime', { cwd: dir, encoding: 'utf-8', stdio: 'pipe' });
+    } catch (error: any) {
+      throw new Error(`go test ${testFile} failed:\n${error.stdout || ''}${error.stderr || ''}`);
+    }
+  } finally {
+    fs.rmSync(dir, { recursive: true, force: true });
+  }
+}
+
+describe('runtime', () => {
+  const testFiles = fs.readdirSync(testDir).filter(file => file.endsWith('_test.go')).sort();
+
+  for (const testFile of testFiles) {
+    (hasGo ? test : test.skip)(testFile, () => {
+      goTest(testFile);
+    });
+  }
+});
@@ -0,0 +1,105 @@
+/**
+ * Go 字串字面量編碼的 property test
+ * 以 Go 實際求值後的位元組，逐位元組比對 TS 字串值的 UTF-8 編碼
+ */
+
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { execSync } from 'child_process';
+import { goStringLiteral, escapeFormatString } from '../../src/backend/go-string';
+
+const hasGo = (() => {
+  try {
+    execSync('go version', { stdio: 'pipe' });
+    return true;
+  } catch {
+    return false;
+  }
+})();
+
+/**
+ * 可重現的亂數產生器 (mulberry32)
+ */
+function createRandom(seed: number): () => number {
+  return () => {
+    seed |= 0;
+    seed = (seed + 0x6d2b79f5) | 0;
+    let t = Math.imul(seed ^ (seed >>> 15), 1 | seed);
+    t = (t + Math.imul(t ^ (t >>> 7), 61 | t)) ^ t;
+    return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+  };
+}
+
+const INTERESTING = [
+  '"', '\'', '`', '\\', '%', '\n', '\r', '\t', '\0', '\x07', '\x7f', '\u2028', '\u2029',
+  '\ufeff', '\u200b', 'é', '中', '\u{1F600}', '\ud800', '\udfff', '$', '{', '}', 'a', ' '
+];
+
+function randomString(random: () => number): string {
+  const length = Math.floor(random() * 12);
+  let value = '';
+  for (let i = 0; i < length; i++) {
+    const pick = random();
+    if (pick < 0.6) {
+      value += INTERESTING[Math.floor(random() * INTERESTING.length)];
+    } else if (pick < 0.8) {
+      value += String.fromCharCode(Math.floor(random() * 0x80));
+    } else {
+      // 任意 UTF-16 code unit，包含孤立 surrogate
+      value += String.fromCharCode(Math.floor(random() * 0x10000));
+    }
+  }
+  return value;
+}
+
+/**
+ * 以 Go 求值每個運算式並回傳其位元組 (hex)
+ */
+function evaluateInGo(expressions: string[]): string[] {
+  const dir = fs.mkdtempSync(path.join(os.tmpdir(), 'ts2go-string-'));
+  const file = path.join(dir, 'main.go');
+  const lines = expressions.map(expr => `\tfmt.Printf("%x\\n", ${expr})`);
+  fs.writeFileSync(file, `package main\n\nimport "fmt"\n\nfunc main() {\n${lines.join('\n')}\n}\n`, 'utf-8');
+
+  try {
+    const output = execSync(`go run ${file}`, { encoding: 'utf-8', stdio: 'pipe' });
+    return output.split('\n').slice(0, expressions.length);
+  } finally {
+    fs.rmSync(dir, { recursive: true, force: true });
+  }
+}
+
+describe('goStringLiteral', () => {
+  test('uses raw strings only when more readable and lossless', () => {
+    expect(goStringLiteral('plain')).toBe('"plain"');
+    expect(goStringLiteral('say "hi"')).toBe('`say "hi"`');
+    expect(goStringLiteral('C:\\path')).toBe('`C:\\path`');
+    expect(goStringLiteral('a"b`c')).toBe('"a\\"b`c"');
+    expect(goStringLiteral('line\n"x"')).toBe('"line\\n\\"x\\""');
+    expect(goStringLiteral('say "hi"', { allowRaw: false })).toBe('"say \\"hi\\""');
+  });
+
+  test('escapes control characters, separators and lone surrogates', () => {
+    expect(goStringLiteral('\0\x07\x7f')).toBe('"\\x00\\a\\x7F"');
+    expect(goStringLiteral('\u2028')).toBe('"\\u2028"');
+    expect(goStringLiteral('\ud800')).toBe('"\\uFFFD"');
+    expect(goStringLiteral('\u{1F600}')).toBe('"\u{1F600}"');
+  });
+
+  (hasGo ? test : test.skip)('round-trips random strings byte-for-byte through Go', () => {
+    const random = createRandom(0x5eed);
+    const values = Array.from({ length: 300 }, () => randomString(random));
+
+    const expressions = [
+      ...values.map(v => goStringLiteral(v)),
+      // 模板字串的格式字串經 %% 跳脫後，Sprintf 的結果應與原值相同
+      ...values.map(v => `fmt.Sprintf(${goStringLiteral(escapeFormatString(v) + '%v')}, "")`)
+    ];
+    const actual = evaluateInGo(expressions);
+
+    // 孤立 surrogate 於 UTF-8 中以 U+FFFD 表示，與 Buffer 的編碼一致
+    const expected = [...values, ...values].map(v => Buffer.from(v, 'utf8').toString('hex'));
+    expect(actual).toEqual(expected);
+  });
+});
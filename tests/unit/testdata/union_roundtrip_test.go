@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTaggedUnionJSONRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		json string
+		tag  int
+		into func() interface{}
+	}{
+		{"string variant", `"abc"`, 0, func() interface{} { return new(StringOrNumber) }},
+		{"number variant", `42`, 1, func() interface{} { return new(StringOrNumber) }},
+		{"non-null variant", `"bob"`, 0, func() interface{} { return new(MaybeName) }},
+		{"null variant", `null`, 1, func() interface{} { return new(MaybeName) }},
+		{"literal variant", `"auto"`, 0, func() interface{} { return new(Width) }},
+		{"number beside a literal", `12.5`, 1, func() interface{} { return new(Width) }},
+	}
+	for _, c := range cases {
+		v := c.into()
+		if err := json.Unmarshal([]byte(c.json), v); err != nil {
+			t.Errorf("%s: Unmarshal(%s): %v", c.name, c.json, err)
+			continue
+		}
+		var tag int
+		switch u := v.(type) {
+		case *StringOrNumber:
+			tag = u.tag
+		case *MaybeName:
+			tag = u.tag
+		case *Width:
+			tag = u.tag
+		}
+		if tag != c.tag {
+			t.Errorf("%s: Unmarshal(%s) chose variant %d, want %d", c.name, c.json, tag, c.tag)
+		}
+		out, err := json.Marshal(v)
+		if err != nil || string(out) != c.json {
+			t.Errorf("%s: Marshal = %s, %v; want %s", c.name, out, err, c.json)
+		}
+	}
+}
+
+func TestTaggedUnionJSONRejectsOtherValues(t *testing.T) {
+	var w Width
+	if err := json.Unmarshal([]byte(`"wide"`), &w); err == nil {
+		t.Errorf(`Unmarshal("wide") into Width = %+v, want an error`, w)
+	}
+	var s StringOrNumber
+	if err := json.Unmarshal([]byte(`true`), &s); err == nil {
+		t.Errorf("Unmarshal(true) into StringOrNumber = %+v, want an error", s)
+	}
+}
+
+func TestStructFieldsUseTypeScriptNames(t *testing.T) {
+	const in = `{"width":"auto","label":null}`
+	var box Box
+	if err := json.Unmarshal([]byte(in), &box); err != nil {
+		t.Fatal(err)
+	}
+	if !box.Width.IsType0() || box.Width.AsType0() != "auto" || !box.Label.IsType1() {
+		t.Errorf("Unmarshal(%s) = %+v", in, box)
+	}
+	out, err := json.Marshal(box)
+	if err != nil || string(out) != in {
+		t.Errorf("Marshal = %s, %v; want %s", out, err, in)
+	}
+}